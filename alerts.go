@@ -0,0 +1,528 @@
+//
+// Raise alerts when the state of our fleet breaches a rule.
+//
+// Rules are read from a YAML file, for example:
+//
+//   rules:
+//     - name: web-failing
+//       state: failed
+//       role: web
+//       above: 5%
+//       for: 15m
+//     - name: no-reports
+//       reports: 10m
+//       below: 1
+//     - name: production-failed
+//       state: failed
+//       branch: production
+//       above: 0
+//       notify: webhook
+//
+// Each rule is evaluated periodically.  An alert is "pending" once its
+// rule is breached, "firing" once it has been breached for long enough,
+// and "resolved" once it is no longer breached.  Notifications are only
+// sent when an alert fires, or is resolved, so a rule which remains
+// breached doesn't repeat itself.
+//
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/smallfish/simpleyaml"
+)
+
+//
+// AlertRule is a single rule, as read from our configuration file.
+//
+type AlertRule struct {
+
+	//
+	// The name of the rule, which must be unique.
+	//
+	Name string
+
+	//
+	// The state of the nodes we count, such as "failed".
+	//
+	State string
+
+	//
+	// Alternatively count the reports received over this period.
+	//
+	Reports time.Duration
+
+	//
+	// Only consider the nodes with this role, branch, or environment.
+	// Acknowledged nodes, and those in maintenance, are always ignored.
+	//
+	Role        string
+	Branch      string
+	Environment string
+
+	//
+	// Count nodes which are flapping, which are otherwise ignored
+	// unless SuppressFlapping is disabled.
+	//
+	IncludeFlapping bool
+
+	//
+	// Whether the rule is breached when the value is above, or below,
+	// the threshold, and whether that is a percentage of the nodes.
+	//
+	Above     bool
+	Threshold float64
+	Percent   bool
+
+	//
+	// How long the rule must be breached before the alert fires.
+	//
+	For time.Duration
+
+	//
+	// The notifiers to use, if empty all of them are.
+	//
+	Notify []string
+}
+
+//
+// AlertRules are the rules we evaluate.
+//
+var AlertRules []AlertRule
+
+//
+// AlertRetention is how long resolved alerts are kept for.
+//
+var AlertRetention = int64(14 * 24 * 60 * 60)
+
+//
+// AlertNotifier is implemented by each of the ways we can tell somebody
+// about an alert firing, or being resolved.
+//
+type AlertNotifier interface {
+	Notify(alert PuppetAlert) error
+}
+
+//
+// AlertNotifiers are the notifiers which rules may use, by name.
+//
+var AlertNotifiers = map[string]AlertNotifier{
+	"log":     logNotifier{},
+	"webhook": webhookNotifier{},
+	"email":   emailNotifier{},
+}
+
+//
+// logNotifier writes alerts to STDOUT.
+//
+type logNotifier struct{}
+
+func (logNotifier) Notify(alert PuppetAlert) error {
+	fmt.Printf("Alert %s is %s: %s\n", alert.Rule, alert.State, alert.Message)
+	return nil
+}
+
+//
+// webhookNotifier queues the alert for each of our webhooks, so that
+// it is delivered, and retried, along with our other notifications.
+//
+type webhookNotifier struct{}
+
+func (webhookNotifier) Notify(alert PuppetAlert) error {
+	if len(Webhooks) < 1 {
+		return nil
+	}
+
+	src, err := getResource("data/alert.template")
+	if err != nil {
+		return err
+	}
+	tmpl, err := parseWebhookTemplate(string(src))
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, alert)
+	if err != nil {
+		return err
+	}
+
+	for _, url := range Webhooks {
+		err = addNotification("webhook", url, buf.String())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//
+// emailNotifier mails the alert to our recipients.
+//
+type emailNotifier struct{}
+
+func (emailNotifier) Notify(alert PuppetAlert) error {
+	if len(EmailTo) < 1 {
+		return nil
+	}
+
+	subject := fmt.Sprintf("[puppet-summary] %s: %s", strings.ToUpper(alert.State), alert.Rule)
+	body := fmt.Sprintf("%s\n\nThe rule %s was first breached at %s.\n", alert.Message, alert.Rule, time.Unix(alert.StartedAt, 0).Format("2006-01-02 15:04:05"))
+	return sendMail(subject, body)
+}
+
+//
+// Get a field of a rule as a string, or a list of strings.
+//
+func ruleField(rule map[interface{}]interface{}, name string) []string {
+	value, ok := rule[name]
+	if !ok || value == nil {
+		return nil
+	}
+
+	var res []string
+	if list, ok := value.([]interface{}); ok {
+		for _, v := range list {
+			res = append(res, fmt.Sprint(v))
+		}
+		return res
+	}
+	for _, v := range strings.Split(fmt.Sprint(value), ",") {
+		if strings.TrimSpace(v) != "" {
+			res = append(res, strings.TrimSpace(v))
+		}
+	}
+	return res
+}
+
+//
+// parseRules reads our rules from the given YAML.
+//
+func parseRules(content []byte) ([]AlertRule, error) {
+
+	y, err := simpleyaml.NewYaml(content)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := y.Get("rules").Array()
+	if err != nil {
+		return nil, errors.New("failed to get 'rules' from YAML")
+	}
+
+	var rules []AlertRule
+	seen := make(map[string]bool)
+
+	for i := range entries {
+		entry, err := y.Get("rules").GetIndex(i).Map()
+		if err != nil {
+			return nil, fmt.Errorf("rule %d isn't a map", i+1)
+		}
+
+		field := func(name string) string {
+			return strings.Join(ruleField(entry, name), ",")
+		}
+
+		var rule AlertRule
+		rule.Name = field("name")
+		rule.State = field("state")
+		rule.Role = field("role")
+		rule.Branch = field("branch")
+		rule.Environment = field("environment")
+		rule.Notify = ruleField(entry, "notify")
+
+		if rule.Name == "" {
+			return nil, fmt.Errorf("rule %d has no name", i+1)
+		}
+		if seen[rule.Name] {
+			return nil, fmt.Errorf("rule %s is defined twice", rule.Name)
+		}
+		seen[rule.Name] = true
+
+		if field("reports") != "" {
+			rule.Reports, err = parsePeriod(field("reports"))
+			if err != nil {
+				return nil, fmt.Errorf("rule %s: %s", rule.Name, err.Error())
+			}
+		}
+		if (rule.State == "") == (rule.Reports == 0) {
+			return nil, fmt.Errorf("rule %s must have one of 'state' or 'reports'", rule.Name)
+		}
+
+		//
+		// The threshold.
+		//
+		threshold := field("above")
+		rule.Above = true
+		if threshold == "" {
+			threshold = field("below")
+			rule.Above = false
+		}
+		if threshold == "" || (field("above") != "" && field("below") != "") {
+			return nil, fmt.Errorf("rule %s must have one of 'above' or 'below'", rule.Name)
+		}
+		if strings.HasSuffix(threshold, "%") {
+			if rule.Reports != 0 {
+				return nil, fmt.Errorf("rule %s: a count of reports can't be a percentage", rule.Name)
+			}
+			rule.Percent = true
+			threshold = strings.TrimSuffix(threshold, "%")
+		}
+		rule.Threshold, err = strconv.ParseFloat(threshold, 64)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: invalid threshold '%s'", rule.Name, threshold)
+		}
+
+		if field("include-flapping") != "" {
+			rule.IncludeFlapping, err = strconv.ParseBool(field("include-flapping"))
+			if err != nil {
+				return nil, fmt.Errorf("rule %s: invalid include-flapping '%s'", rule.Name, field("include-flapping"))
+			}
+		}
+
+		if field("for") != "" {
+			rule.For, err = parsePeriod(field("for"))
+			if err != nil {
+				return nil, fmt.Errorf("rule %s: %s", rule.Name, err.Error())
+			}
+		}
+
+		for _, name := range rule.Notify {
+			if _, ok := AlertNotifiers[name]; !ok {
+				return nil, fmt.Errorf("rule %s: unknown notifier '%s'", rule.Name, name)
+			}
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+//
+// loadRules reads our rules from the given file.
+//
+func loadRules(path string) ([]AlertRule, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseRules(content)
+}
+
+//
+// Describe the nodes a rule applies to.
+//
+func (r AlertRule) scope() string {
+	var filters []string
+	if r.Role != "" {
+		filters = append(filters, "role="+r.Role)
+	}
+	if r.Branch != "" {
+		filters = append(filters, "branch="+r.Branch)
+	}
+	if r.Environment != "" {
+		filters = append(filters, "environment="+r.Environment)
+	}
+	if len(filters) < 1 {
+		return "nodes"
+	}
+	return "nodes with " + strings.Join(filters, ", ")
+}
+
+//
+// Work out the current value of a rule, given our nodes.
+//
+func (r AlertRule) value(nodes []PuppetRuns, now int64) (float64, error) {
+
+	if r.Reports != 0 {
+		activity, err := getActivity(now - int64(r.Reports.Seconds()))
+		return float64(activity.Reports), err
+	}
+
+	total := 0
+	count := 0
+	for _, node := range nodes {
+		if node.Ack != nil || node.Maintenance != "" {
+			continue
+		}
+		if node.Flapping && SuppressFlapping && !r.IncludeFlapping {
+			continue
+		}
+		if r.Role != "" && node.Role != r.Role {
+			continue
+		}
+		if r.Branch != "" && node.Branch != r.Branch {
+			continue
+		}
+		if r.Environment != "" && node.Environment != r.Environment {
+			continue
+		}
+		total++
+		if node.State == r.State {
+			count++
+		}
+	}
+
+	if !r.Percent {
+		return float64(count), nil
+	}
+	if total == 0 {
+		return 0, nil
+	}
+	return float64(count) * 100 / float64(total), nil
+}
+
+//
+// Is the rule breached by the given value?
+//
+func (r AlertRule) breached(value float64) bool {
+	if r.Above {
+		return value > r.Threshold
+	}
+	return value < r.Threshold
+}
+
+//
+// Describe the value of a rule.
+//
+func (r AlertRule) describe(value float64) string {
+	limit := "below"
+	if r.Above {
+		limit = "above"
+	}
+
+	unit := ""
+	if r.Percent {
+		unit = "%"
+	}
+
+	if r.Reports != 0 {
+		return fmt.Sprintf("%s reports received in the past %s, the limit is %s %s", strconv.FormatFloat(value, 'f', -1, 64), r.Reports, limit, strconv.FormatFloat(r.Threshold, 'f', -1, 64))
+	}
+	return fmt.Sprintf("%s%s of %s are %s, the limit is %s %s%s", strconv.FormatFloat(value, 'f', 2, 64), unit, r.scope(), r.State, limit, strconv.FormatFloat(r.Threshold, 'f', -1, 64), unit)
+}
+
+//
+// Send the alert via the notifiers the rule uses.
+//
+func notifyAlert(rule AlertRule, alert PuppetAlert) {
+	names := rule.Notify
+	if len(names) < 1 {
+		for name := range AlertNotifiers {
+			names = append(names, name)
+		}
+	}
+
+	for _, name := range names {
+		notifier, ok := AlertNotifiers[name]
+		if !ok {
+			continue
+		}
+		err := notifier.Notify(alert)
+		if err != nil {
+			fmt.Printf("Error sending alert %s via %s: %s\n", alert.Rule, name, err.Error())
+		}
+	}
+}
+
+//
+// evaluateRules checks each of our rules, updating their alerts and
+// sending notifications as they fire and are resolved.
+//
+func evaluateRules() {
+
+	if len(AlertRules) < 1 {
+		return
+	}
+
+	now := time.Now().Unix()
+
+	nodes, err := getIndexNodes()
+	if err != nil {
+		fmt.Printf("Error evaluating alert-rules: %s\n", err.Error())
+		return
+	}
+
+	//
+	// The alerts which are currently pending, or firing, by rule.
+	//
+	alerts, err := getAlerts(now + 1)
+	if err != nil {
+		fmt.Printf("Error evaluating alert-rules: %s\n", err.Error())
+		return
+	}
+	active := make(map[string]PuppetAlert)
+	for _, alert := range alerts {
+		if alert.State != "resolved" {
+			active[alert.Rule] = alert
+		}
+	}
+
+	for _, rule := range AlertRules {
+
+		value, err := rule.value(nodes, now)
+		if err != nil {
+			fmt.Printf("Error evaluating alert-rule %s: %s\n", rule.Name, err.Error())
+			continue
+		}
+
+		alert, found := active[rule.Name]
+
+		if !rule.breached(value) {
+			if !found {
+				continue
+			}
+
+			//
+			// Alerts which never fired are forgotten, the rest
+			// are resolved.
+			//
+			if alert.State == "pending" {
+				deleteAlert(alert.ID)
+				continue
+			}
+			alert.State = "resolved"
+			alert.Value = value
+			alert.Message = rule.describe(value)
+			alert.ResolvedAt = now
+			err = saveAlert(&alert)
+			if err == nil {
+				notifyAlert(rule, alert)
+			}
+			continue
+		}
+
+		if !found {
+			alert = PuppetAlert{Rule: rule.Name, State: "pending", StartedAt: now}
+		}
+		alert.Value = value
+		alert.Message = rule.describe(value)
+
+		fire := alert.State == "pending" && now-alert.StartedAt >= int64(rule.For.Seconds())
+		if fire {
+			alert.State = "firing"
+			alert.FiredAt = now
+		}
+
+		err = saveAlert(&alert)
+		if err != nil {
+			fmt.Printf("Error saving alert %s: %s\n", rule.Name, err.Error())
+			continue
+		}
+		if fire {
+			notifyAlert(rule, alert)
+		}
+	}
+
+	pruneAlerts(now - AlertRetention)
+}
+
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+//
+// A notifier which records the alerts it is given.
+//
+type testNotifier struct {
+	alerts *[]PuppetAlert
+}
+
+func (n testNotifier) Notify(alert PuppetAlert) error {
+	*n.alerts = append(*n.alerts, alert)
+	return nil
+}
+
+//
+// Test parsing our rules.
+//
+func TestParseRules(t *testing.T) {
+
+	rules, err := parseRules([]byte(`
+rules:
+  - name: web-failing
+    state: failed
+    role: web
+    above: 5%
+    for: 15m
+  - name: no-reports
+    reports: 10m
+    below: 1
+  - name: production-failed
+    state: failed
+    branch: production
+    above: 0
+    notify: [webhook, email]
+    include-flapping: true
+`))
+	if err != nil {
+		t.Fatalf("Failed to parse rules: %s", err.Error())
+	}
+	if len(rules) != 3 {
+		t.Fatalf("Unexpected number of rules: %d", len(rules))
+	}
+
+	if !rules[0].Percent || !rules[0].Above || rules[0].Threshold != 5 || rules[0].For != 15*time.Minute || rules[0].Role != "web" {
+		t.Errorf("Unexpected rule: %v", rules[0])
+	}
+	if rules[1].Reports != 10*time.Minute || rules[1].Above || rules[1].Threshold != 1 {
+		t.Errorf("Unexpected rule: %v", rules[1])
+	}
+	if rules[0].IncludeFlapping || !rules[2].IncludeFlapping {
+		t.Errorf("Unexpected include-flapping: %v", rules)
+	}
+	if len(rules[2].Notify) != 2 || rules[2].Notify[1] != "email" || rules[2].Branch != "production" {
+		t.Errorf("Unexpected rule: %v", rules[2])
+	}
+
+	//
+	// Broken rules are reported.
+	//
+	tests := map[string]string{
+		"rules:\n  - state: failed\n    above: 1\n":                                                            "has no name",
+		"rules:\n  - name: x\n    above: 1\n":                                                                  "one of 'state' or 'reports'",
+		"rules:\n  - name: x\n    state: failed\n":                                                             "one of 'above' or 'below'",
+		"rules:\n  - name: x\n    state: failed\n    above: 1\n    below: 2\n":                                 "one of 'above' or 'below'",
+		"rules:\n  - name: x\n    reports: 10m\n    below: 1%\n":                                               "percentage",
+		"rules:\n  - name: x\n    state: failed\n    above: lots\n":                                            "invalid threshold",
+		"rules:\n  - name: x\n    state: failed\n    above: 1\n    notify: pager\n":                            "unknown notifier",
+		"rules:\n  - name: x\n    state: failed\n    above: 1\n    for: soon\n":                                "invalid period",
+		"rules:\n  - name: x\n    state: failed\n    above: 1\n    include-flapping: often\n":                  "invalid include-flapping",
+		"rules:\n  - name: x\n    state: failed\n    above: 1\n  - name: x\n    state: failed\n    above: 1\n": "defined twice",
+		"steve: 1\n": "failed to get 'rules'",
+	}
+	for input, expected := range tests {
+		_, err := parseRules([]byte(input))
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected an error containing '%s', got %v", expected, err)
+		}
+	}
+}
+
+//
+// Test that alerts fire once, and are resolved.
+//
+func TestEvaluateRules(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	var sent []PuppetAlert
+	AlertNotifiers["test"] = testNotifier{alerts: &sent}
+	defer delete(AlertNotifiers, "test")
+
+	AlertRules = []AlertRule{
+		{Name: "failing", State: "failed", Above: true, Threshold: 25, Percent: true, Notify: []string{"test"}},
+		{Name: "slow-failing", State: "failed", Above: true, Threshold: 0, For: time.Hour, Notify: []string{"test"}},
+	}
+	defer func() { AlertRules = nil }()
+
+	addFakeRuns("foo.example.com", "failed")
+	addFakeRuns("bar.example.com", "changed")
+
+	evaluateRules()
+
+	//
+	// Half our nodes are failing, so the first rule fires at once,
+	// and the second is pending.
+	//
+	if len(sent) != 1 || sent[0].Rule != "failing" || sent[0].State != "firing" || sent[0].Value != 50 {
+		t.Fatalf("Unexpected notifications: %v", sent)
+	}
+	if !strings.Contains(sent[0].Message, "50.00% of nodes are failed") {
+		t.Errorf("Unexpected message: %s", sent[0].Message)
+	}
+
+	alerts, _ := getAlerts(0)
+	if len(alerts) != 2 {
+		t.Fatalf("Unexpected alerts: %v", alerts)
+	}
+
+	//
+	// Evaluating again doesn't notify again.
+	//
+	evaluateRules()
+	if len(sent) != 1 {
+		t.Errorf("Alert was repeated: %v", sent)
+	}
+
+	//
+	// Once the node recovers the firing alert is resolved, and the
+	// pending one is forgotten.
+	//
+	addFakeRuns("foo.example.com", "unchanged")
+	evaluateRules()
+
+	if len(sent) != 2 || sent[1].Rule != "failing" || sent[1].State != "resolved" {
+		t.Fatalf("Unexpected notifications: %v", sent)
+	}
+
+	alerts, _ = getAlerts(0)
+	if len(alerts) != 1 || alerts[0].State != "resolved" || alerts[0].FiredAt == 0 || alerts[0].ResolvedAt == 0 {
+		t.Errorf("Unexpected alerts: %v", alerts)
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test the filters, and the count of reports received.
+//
+func TestAlertRuleValue(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	nodes := []PuppetRuns{
+		{Fqdn: "a", State: "failed", Role: "web", Branch: "production"},
+		{Fqdn: "b", State: "changed", Role: "web", Branch: "staging"},
+		{Fqdn: "c", State: "failed", Role: "db", Branch: "production"},
+		{Fqdn: "d", State: "failed", Role: "db", Ack: &PuppetAck{Fqdn: "d"}},
+		{Fqdn: "e", State: "failed", Role: "db", Flapping: true},
+	}
+
+	tests := []struct {
+		Rule  AlertRule
+		Value float64
+	}{
+		{AlertRule{State: "failed"}, 2},
+		{AlertRule{State: "failed", Role: "web"}, 1},
+		{AlertRule{State: "failed", Role: "web", Percent: true}, 50},
+		{AlertRule{State: "failed", Branch: "production", Percent: true}, 100},
+		{AlertRule{State: "failed", Environment: "production", Percent: true}, 0},
+		{AlertRule{Reports: 10 * time.Minute}, 0},
+		{AlertRule{State: "failed", Role: "db", IncludeFlapping: true}, 2},
+	}
+
+	for _, test := range tests {
+		value, err := test.Rule.value(nodes, time.Now().Unix())
+		if err != nil {
+			t.Fatalf("Failed to get value: %s", err.Error())
+		}
+		if value != test.Value {
+			t.Errorf("Unexpected value for %v: %f", test.Rule, value)
+		}
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
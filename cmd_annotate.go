@@ -0,0 +1,110 @@
+//
+// Record an event, such as a deployment, to be drawn upon our graphs.
+//
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/subcommands"
+)
+
+//
+// The options set by our command-line flags.
+//
+type annotateCmd struct {
+	at     string
+	server string
+	tags   string
+}
+
+//
+// Submit the event to the given server.
+//
+func postEvent(server string, event PuppetEvent) (PuppetEvent, error) {
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return event, err
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	res, err := client.Post(strings.TrimSuffix(server, "/")+"/api/v1/events", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return event, err
+	}
+	defer res.Body.Close()
+
+	reply, _ := ioutil.ReadAll(res.Body)
+	if res.StatusCode != http.StatusCreated {
+		return event, fmt.Errorf("%s: %s", res.Status, strings.TrimSpace(string(reply)))
+	}
+
+	err = json.Unmarshal(reply, &event)
+	return event, err
+}
+
+//
+// Glue
+//
+func (*annotateCmd) Name() string     { return "annotate" }
+func (*annotateCmd) Synopsis() string { return "Record an event, such as a deployment." }
+func (*annotateCmd) Usage() string {
+	return `annotate [options] text..:
+  Record an event, which will be drawn upon the graphs of a running server.
+
+  For example:
+
+    puppet-summary annotate -tags deploy,production "Deployed build 1234"
+`
+}
+
+//
+// Flag setup
+//
+func (p *annotateCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&p.at, "at", "", "The time of the event, if not now, such as '2017-07-29 15:04'.")
+	f.StringVar(&p.server, "server", "http://localhost:3001", "The server to record the event with.")
+	f.StringVar(&p.tags, "tags", "", "A comma-separated list of tags for the event.")
+}
+
+//
+// Entry-point.
+//
+func (p *annotateCmd) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+
+	var event PuppetEvent
+	event.Text = strings.Join(f.Args(), " ")
+	event.Tags = splitTags(p.tags)
+
+	var err error
+	event.At, err = parseFormTime(p.at)
+	if err != nil {
+		fmt.Printf("%s\n", err.Error())
+		return subcommands.ExitFailure
+	}
+
+	err = validateEvent(&event)
+	if err != nil {
+		fmt.Printf("%s\n", err.Error())
+		return subcommands.ExitUsageError
+	}
+
+	event, err = postEvent(p.server, event)
+	if err != nil {
+		fmt.Printf("Failed to record the event: %s\n", err.Error())
+		return subcommands.ExitFailure
+	}
+
+	fmt.Fprintf(out, "Recorded event %d at %s\n", event.ID, time.Unix(event.At, 0).Format("2006-01-02 15:04:05"))
+	return subcommands.ExitSuccess
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/subcommands"
+)
+
+//
+// Test that events are submitted to a server.
+//
+func TestAnnotate(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	server := httptest.NewServer(http.HandlerFunc(APIAddEvent))
+	defer server.Close()
+
+	bak := out
+	out = new(bytes.Buffer)
+	defer func() { out = bak }()
+
+	cmd := annotateCmd{server: server.URL, tags: "deploy,production", at: "2017-07-29 15:04"}
+	flags := flag.NewFlagSet("annotate", flag.ContinueOnError)
+	flags.Parse([]string{"Deployed", "build", "1234"})
+
+	if cmd.Execute(context.TODO(), flags) != subcommands.ExitSuccess {
+		t.Fatalf("Failed to annotate")
+	}
+	if !strings.HasPrefix(out.(*bytes.Buffer).String(), "Recorded event 1 at 2017-07-29 15:04:00") {
+		t.Errorf("Unexpected output: %s", out)
+	}
+
+	events, _ := getEvents(0, "deploy")
+	if len(events) != 1 || events[0].Text != "Deployed build 1234" {
+		t.Errorf("Unexpected events: %v", events)
+	}
+
+	//
+	// An event needs some text.
+	//
+	flags = flag.NewFlagSet("annotate", flag.ContinueOnError)
+	if cmd.Execute(context.TODO(), flags) != subcommands.ExitUsageError {
+		t.Errorf("Expected a usage-error")
+	}
+
+	//
+	// Failures from the server are reported.
+	//
+	db.Close()
+	db = nil
+	flags.Parse([]string{"Deployed"})
+	if cmd.Execute(context.TODO(), flags) != subcommands.ExitFailure {
+		t.Errorf("Expected a failure")
+	}
+
+	os.RemoveAll(path)
+}
@@ -0,0 +1,445 @@
+//
+// Import an existing directory of reports, such as those written by the
+// `store` processor of a puppet-master.
+//
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/subcommands"
+)
+
+//
+// The options set by our command-line flags.
+//
+type importCmd struct {
+	batch   int
+	workers int
+	dbFile  string
+	dbType  string
+	path    string
+	pattern string
+	prefix  string
+	verbose bool
+}
+
+//
+// A report file, once read and parsed.
+//
+type importFile struct {
+	path    string
+	content []byte
+	report  PuppetReport
+	err     error
+}
+
+//
+// The outcome of an import.
+//
+type importSummary struct {
+	Imported   int
+	Duplicates int
+
+	// The reason each rejected file was rejected, by its path.
+	Rejected map[string]string
+}
+
+//
+// Find the files beneath the given directory whose names match the
+// given pattern.
+//
+func findReports(dir string, pattern string) ([]string, error) {
+	var files []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		match, err := filepath.Match(pattern, info.Name())
+		if err != nil {
+			return err
+		}
+		if match {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
+
+//
+// Read, and parse, the given files with a pool of workers.
+//
+// The files are returned as they are parsed, so not necessarily in the
+// order they were given.  Once `stop` is closed no more files are read.
+//
+func parseReports(files []string, workers int, stop <-chan struct{}) <-chan importFile {
+	paths := make(chan string)
+	parsed := make(chan importFile, workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range paths {
+				f := importFile{path: path}
+				f.content, f.err = ioutil.ReadFile(path)
+				if f.err == nil {
+					f.report, f.err = ParsePuppetReport(f.content)
+				}
+				parsed <- f
+			}
+		}()
+	}
+
+	go func() {
+	feed:
+		for _, path := range files {
+			select {
+			case paths <- path:
+			case <-stop:
+				break feed
+			}
+		}
+		close(paths)
+		wg.Wait()
+		close(parsed)
+	}()
+
+	return parsed
+}
+
+//
+// Find which of the given report files we already hold, with a single
+// query rather than one per report.
+//
+func knownReports(tx *sql.Tx, names []string) (map[string]bool, error) {
+
+	known := make(map[string]bool)
+	if len(names) == 0 {
+		return known, nil
+	}
+
+	args := make([]interface{}, len(names))
+	for i, name := range names {
+		args[i] = name
+	}
+	marks := strings.TrimSuffix(strings.Repeat("?,", len(names)), ",")
+
+	rows, err := tx.Query("SELECT yaml_file FROM reports WHERE yaml_file IN ("+marks+")", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		err = rows.Scan(&name)
+		if err != nil {
+			return nil, err
+		}
+		known[name] = true
+	}
+	return known, rows.Err()
+}
+
+//
+// Store a batch of reports, within a single transaction, skipping those
+// we already hold.
+//
+// Each report is written beneath our prefix, just as if it had been
+// uploaded, and recorded at the time it was executed.  If the batch
+// can't be stored the files we wrote are removed again.
+//
+func importBatch(batch []importFile, prefix string, summary *importSummary, fingerprints map[string]reportFingerprint) error {
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	var written []string
+	rollback := func(err error) error {
+		tx.Rollback()
+		for _, path := range written {
+			os.Remove(path)
+		}
+		return err
+	}
+
+	names := make([]string, len(batch))
+	for i, f := range batch {
+		names[i] = filepath.Join(f.report.Fqdn, f.report.Hash)
+	}
+	known, err := knownReports(tx, names)
+	if err != nil {
+		return rollback(err)
+	}
+
+	imported := 0
+	found := make(map[string]reportFingerprint)
+
+	for i, f := range batch {
+		relative := names[i]
+		if known[relative] {
+			summary.Duplicates++
+			continue
+		}
+
+		//
+		// A report already beneath our prefix, but not recorded,
+		// isn't written again, nor removed if we fail.
+		//
+		dir := filepath.Join(prefix, f.report.Fqdn)
+		path := filepath.Join(dir, f.report.Hash)
+		if !Exists(path) {
+			err = os.MkdirAll(dir, 0755)
+			if err != nil {
+				return rollback(err)
+			}
+			err = ioutil.WriteFile(path, f.content, 0644)
+			if err != nil {
+				return rollback(err)
+			}
+			written = append(written, path)
+		}
+
+		host_id, err := txHostId(tx, f.report.Fqdn)
+		if err != nil {
+			return rollback(err)
+		}
+
+		at := executedAt(f.report, 0)
+		if at == 0 {
+			info, err := os.Stat(f.path)
+			if err == nil {
+				at = info.ModTime().Unix()
+			}
+		}
+
+		_, err = insertReport(tx, f.report, host_id, relative, at)
+		if err != nil {
+			return rollback(err)
+		}
+
+		addFingerprint(found, f.report, at)
+		imported++
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		for _, path := range written {
+			os.Remove(path)
+		}
+		return err
+	}
+
+	for fqdn, f := range found {
+		if prev, ok := fingerprints[fqdn]; !ok || prev.At <= f.At {
+			fingerprints[fqdn] = f
+		}
+	}
+	summary.Imported += imported
+	return nil
+}
+
+//
+// Run an import
+//
+func runImport(x importCmd) (importSummary, error) {
+
+	summary := importSummary{Rejected: make(map[string]string)}
+
+	if x.batch < 1 {
+		x.batch = RebuildBatch
+	}
+	if x.workers < 1 {
+		x.workers = 1
+	}
+
+	files, err := findReports(x.path, x.pattern)
+	if err != nil {
+		return summary, err
+	}
+	fmt.Fprintf(out, "Found %d reports beneath %s\n", len(files), x.path)
+
+	//
+	// Duplicates are found by their hash, which we'll also see when
+	// the same report is found twice within this import.
+	//
+	seen := make(map[string]bool)
+	fingerprints := make(map[string]reportFingerprint)
+
+	done := 0
+	var batch []importFile
+
+	flush := func() error {
+		err := importBatch(batch, x.prefix, &summary, fingerprints)
+		batch = nil
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Processed %d of %d reports\n", done, len(files))
+		return nil
+	}
+
+	stop := make(chan struct{})
+	parsed := parseReports(files, x.workers, stop)
+
+	for f := range parsed {
+		done++
+
+		if f.err != nil {
+			summary.Rejected[f.path] = f.err.Error()
+			if x.verbose {
+				fmt.Fprintf(out, "Rejected %s: %s\n", f.path, f.err.Error())
+			}
+			continue
+		}
+
+		key := filepath.Join(f.report.Fqdn, f.report.Hash)
+		if seen[key] {
+			summary.Duplicates++
+			continue
+		}
+		seen[key] = true
+
+		batch = append(batch, f)
+		if len(batch) >= x.batch {
+			err = flush()
+			if err != nil {
+				//
+				// Stop, and drain, the workers so they
+				// aren't left blocked.
+				//
+				close(stop)
+				for range parsed {
+				}
+				return summary, err
+			}
+		}
+	}
+
+	if len(batch) > 0 {
+		err = flush()
+		if err != nil {
+			return summary, err
+		}
+	}
+
+	//
+	// Now the reports are held we can bring our history, and hosts,
+	// up to date.
+	//
+	if summary.Imported > 0 {
+		_, err = rebuildFromReports(fingerprints, x.batch, x.verbose)
+	}
+	return summary, err
+}
+
+//
+// Show the outcome of an import, grouping the rejected files by the
+// reason they were rejected.
+//
+func showImport(summary importSummary) {
+	fmt.Fprintf(out, "Imported %d reports, skipped %d duplicates, rejected %d files.\n", summary.Imported, summary.Duplicates, len(summary.Rejected))
+
+	reasons := make(map[string][]string)
+	for path, reason := range summary.Rejected {
+		reasons[reason] = append(reasons[reason], path)
+	}
+
+	var keys []string
+	for reason := range reasons {
+		keys = append(keys, reason)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(reasons[keys[i]]) != len(reasons[keys[j]]) {
+			return len(reasons[keys[i]]) > len(reasons[keys[j]])
+		}
+		return keys[i] < keys[j]
+	})
+
+	for _, reason := range keys {
+		paths := reasons[reason]
+		sort.Strings(paths)
+		fmt.Fprintf(out, "\n%d rejected: %s\n", len(paths), reason)
+		for _, path := range paths {
+			fmt.Fprintf(out, "  %s\n", path)
+		}
+	}
+}
+
+//
+// Glue
+//
+func (*importCmd) Name() string     { return "import" }
+func (*importCmd) Synopsis() string { return "Import an existing directory of reports." }
+func (*importCmd) Usage() string {
+	return `import [options]:
+  Import the YAML reports beneath a directory, such as those stored by your
+  puppet-master, keeping the time each was executed.
+
+  For example:
+
+    puppet-summary import -path /var/lib/puppet/reports
+`
+}
+
+//
+// Flag setup
+//
+func (p *importCmd) SetFlags(f *flag.FlagSet) {
+	f.BoolVar(&p.verbose, "verbose", false, "Be verbose in reporting output")
+	f.StringVar(&p.path, "path", "/var/lib/puppet/reports", "The directory of reports to import.")
+	f.StringVar(&p.pattern, "pattern", "*.yaml", "Import the files whose names match this pattern.")
+	f.IntVar(&p.workers, "workers", runtime.NumCPU(), "The number of reports to parse at once.")
+	f.IntVar(&p.batch, "batch", 1000, "The number of reports to store within each transaction.")
+	f.StringVar(&p.dbType, "db-type", "sqlite3", "The SQLite database to use.")
+	f.StringVar(&p.dbFile, "db-file", "ps.db", "The SQLite database to use or DSN for mysql (`db_user:db_password@tcp(db_hostname:db_port)/db_name`)")
+	f.StringVar(&p.prefix, "prefix", "./reports/", "The prefix to the local YAML hierarchy.")
+}
+
+//
+// Entry-point.
+//
+func (p *importCmd) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+
+	//
+	// Setup the database, by opening a handle, and creating it if
+	// missing.
+	//
+	err := SetupDB(p.dbType, p.dbFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening database: %s\n", err.Error())
+		return subcommands.ExitFailure
+	}
+
+	//
+	// Invoke the import
+	//
+	summary, err := runImport(*p)
+	showImport(summary)
+
+	if err == nil {
+		return subcommands.ExitSuccess
+	}
+
+	fmt.Fprintf(os.Stderr, "Error importing: %s\n", err.Error())
+	return subcommands.ExitFailure
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/subcommands"
+)
+
+//
+// Test a directory of reports may be imported.
+//
+func TestImportCommand(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	bak := out
+	out = new(bytes.Buffer)
+	defer func() { out = bak }()
+
+	dir, err := ioutil.TempDir("", "import")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	prefix, err := ioutil.TempDir("", "prefix")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(prefix)
+
+	content, err := getResource("data/valid.yaml")
+	if err != nil {
+		t.Fatal("Failed to load YAML asset data/valid.yaml")
+	}
+
+	//
+	// The same report twice, a broken one, and a file which isn't
+	// a report at all.
+	//
+	os.MkdirAll(filepath.Join(dir, "www.steve.org.uk"), 0755)
+	ioutil.WriteFile(filepath.Join(dir, "www.steve.org.uk", "201707292317.yaml"), content, 0644)
+	ioutil.WriteFile(filepath.Join(dir, "www.steve.org.uk", "copy.yaml"), content, 0644)
+	ioutil.WriteFile(filepath.Join(dir, "broken.yaml"), []byte("steve"), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "README"), []byte("steve"), 0644)
+
+	summary, err := runImport(importCmd{path: dir, pattern: "*.yaml", prefix: prefix, workers: 2, batch: 1})
+	if err != nil {
+		t.Fatalf("Failed to import: %s", err.Error())
+	}
+	if summary.Imported != 1 || summary.Duplicates != 1 || len(summary.Rejected) != 1 {
+		t.Errorf("Unexpected summary: %v", summary)
+	}
+	if !strings.Contains(summary.Rejected[filepath.Join(dir, "broken.yaml")], "YAML") {
+		t.Errorf("Unexpected rejections: %v", summary.Rejected)
+	}
+
+	showImport(summary)
+	if !strings.Contains(out.(*bytes.Buffer).String(), "Imported 1 reports, skipped 1 duplicates, rejected 1 files.") ||
+		!strings.Contains(out.(*bytes.Buffer).String(), "  "+filepath.Join(dir, "broken.yaml")) {
+		t.Errorf("Unexpected output: %s", out)
+	}
+
+	//
+	// The report is stored, as if it were uploaded, at the time it
+	// was executed.
+	//
+	at := time.Date(2017, 7, 29, 23, 17, 1, 0, time.UTC).Local()
+	reports, _ := getReports("www.steve.org.uk")
+	if len(reports) != 1 || reports[0].At != at.Format("2006-01-02 15:04:05") {
+		t.Fatalf("Unexpected reports: %v", reports)
+	}
+	if !Exists(filepath.Join(prefix, reports[0].YamlFile)) {
+		t.Errorf("The report wasn't stored beneath the prefix")
+	}
+
+	var state string
+	row := db.QueryRow("SELECT state FROM hosts WHERE fqdn = 'www.steve.org.uk'")
+	row.Scan(&state)
+	if state != "unchanged" {
+		t.Errorf("Unexpected state: %s", state)
+	}
+
+	//
+	// Importing again adds nothing.
+	//
+	summary, err = runImport(importCmd{path: dir, pattern: "*.yaml", prefix: prefix, workers: 1})
+	if err != nil || summary.Imported != 0 || summary.Duplicates != 2 {
+		t.Errorf("Unexpected summary: %v %v", summary, err)
+	}
+	count, _ := countReports()
+	if count != 1 {
+		t.Errorf("We have %d reports, not 1", count)
+	}
+
+	//
+	// A database which can't be opened fails.
+	//
+	cmd := importCmd{path: dir, pattern: "*.yaml", prefix: prefix, dbType: "sqlite3", dbFile: path}
+	if cmd.Execute(context.TODO(), nil) != subcommands.ExitFailure {
+		t.Errorf("Expected a failing exit-status for a broken database")
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test the reports written by a batch which can't be stored are removed.
+//
+func TestImportRollback(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	prefix, err := ioutil.TempDir("", "prefix")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(prefix)
+
+	_, err = db.Exec("CREATE TRIGGER refuse BEFORE INSERT ON reports WHEN NEW.fqdn = 'bad.example.com' BEGIN SELECT RAISE(ABORT, 'refused'); END")
+	if err != nil {
+		t.Fatalf("Failed to create trigger: %s", err.Error())
+	}
+
+	batch := []importFile{
+		{path: "good.yaml", content: []byte("good"), report: PuppetReport{Fqdn: "good.example.com", Hash: "1234", State: "changed"}},
+		{path: "bad.yaml", content: []byte("bad"), report: PuppetReport{Fqdn: "bad.example.com", Hash: "5678", State: "changed"}},
+	}
+
+	summary := importSummary{Rejected: make(map[string]string)}
+	fingerprints := make(map[string]reportFingerprint)
+
+	err = importBatch(batch, prefix, &summary, fingerprints)
+	if err == nil || !strings.Contains(err.Error(), "refused") {
+		t.Fatalf("Expected the batch to fail, got %v", err)
+	}
+
+	for _, f := range batch {
+		if Exists(filepath.Join(prefix, f.report.Fqdn, f.report.Hash)) {
+			t.Errorf("The report of %s was left behind", f.report.Fqdn)
+		}
+	}
+	if summary.Imported != 0 || len(fingerprints) != 0 {
+		t.Errorf("Unexpected outcome: %v %v", summary, fingerprints)
+	}
+
+	count, _ := countReports()
+	if count != 0 {
+		t.Errorf("We have %d reports, not 0", count)
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
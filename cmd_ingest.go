@@ -0,0 +1,93 @@
+//
+// Ingest the reports written into a spool-directory.
+//
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/subcommands"
+)
+
+//
+// The options set by our command-line flags.
+//
+type ingestCmd struct {
+	interval time.Duration
+	once     bool
+	dbFile   string
+	dbType   string
+	path     string
+	pattern  string
+	prefix   string
+}
+
+//
+// Glue
+//
+func (*ingestCmd) Name() string     { return "ingest" }
+func (*ingestCmd) Synopsis() string { return "Ingest the reports written into a spool-directory." }
+func (*ingestCmd) Usage() string {
+	return `ingest [options]:
+  Watch a spool-directory, such as that written to by the 'store' processor
+  of a puppet-master, ingesting each report written into it.
+
+  Once ingested each report is moved beneath processed/, or beneath failed/
+  along with the reason it failed.  Only one process should ingest from any
+  spool-directory.
+
+  For example:
+
+    puppet-summary ingest -path /var/lib/puppet/reports
+`
+}
+
+//
+// Flag setup
+//
+func (p *ingestCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&p.path, "path", "", "The spool-directory to ingest reports from.")
+	f.StringVar(&p.pattern, "pattern", "*.yaml", "Ingest the files whose names match this pattern.")
+	f.DurationVar(&p.interval, "interval", 10*time.Second, "How often to scan the spool-directory, in case any reports were missed.")
+	f.BoolVar(&p.once, "once", false, "Ingest the reports present, then exit, rather than watching.")
+	f.StringVar(&p.dbType, "db-type", "sqlite3", "The SQLite database to use.")
+	f.StringVar(&p.dbFile, "db-file", "ps.db", "The SQLite database to use or DSN for mysql (`db_user:db_password@tcp(db_hostname:db_port)/db_name`)")
+	f.StringVar(&p.prefix, "prefix", "./reports/", "The prefix to the local YAML hierarchy.")
+}
+
+//
+// Entry-point.
+//
+func (p *ingestCmd) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+
+	if p.path == "" {
+		fmt.Fprintf(os.Stderr, "Please specify the spool-directory with -path\n")
+		return subcommands.ExitFailure
+	}
+
+	//
+	// Setup the database, by opening a handle, and creating it if
+	// missing.
+	//
+	err := SetupDB(p.dbType, p.dbFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening database: %s\n", err.Error())
+		return subcommands.ExitFailure
+	}
+	ReportPrefix = p.prefix
+
+	s := &spool{path: p.path, pattern: p.pattern}
+	if p.once {
+		s.recover()
+		s.scan(SpoolSettle)
+		return subcommands.ExitSuccess
+	}
+
+	s.run(p.interval, nil)
+	return subcommands.ExitSuccess
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/subcommands"
+)
+
+//
+// Test the reports within a spool-directory are ingested once.
+//
+func TestIngestOnce(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	// The command opens the database itself.
+	db.Close()
+
+	s, cleanup := fakeSpool(t)
+	defer cleanup()
+
+	old := SpoolSettle
+	SpoolSettle = 0
+	defer func() { SpoolSettle = old }()
+
+	content, err := getResource("data/valid.yaml")
+	if err != nil {
+		t.Fatal("Failed to load YAML asset data/valid.yaml")
+	}
+	ioutil.WriteFile(filepath.Join(s.path, "201707292317.yaml"), content, 0644)
+	ioutil.WriteFile(filepath.Join(s.path, "broken.yaml"), []byte("steve"), 0644)
+
+	cmd := ingestCmd{once: true, path: s.path, pattern: "*.yaml", prefix: ReportPrefix, dbType: "sqlite3", dbFile: path + "/db.sql"}
+	if cmd.Execute(context.TODO(), nil) != subcommands.ExitSuccess {
+		t.Fatalf("Expected a successful exit-status")
+	}
+
+	for _, name := range []string{"processed/201707292317.yaml", "failed/broken.yaml", "failed/broken.yaml.error"} {
+		if !Exists(filepath.Join(s.path, name)) {
+			t.Errorf("Expected %s to exist", name)
+		}
+	}
+	for _, name := range []string{"201707292317.yaml", "broken.yaml"} {
+		if Exists(filepath.Join(s.path, name)) {
+			t.Errorf("Expected %s to have been moved", name)
+		}
+	}
+
+	reason, _ := ioutil.ReadFile(filepath.Join(s.path, "failed", "broken.yaml.error"))
+	if len(reason) < 1 {
+		t.Errorf("The reason broken.yaml failed wasn't recorded")
+	}
+
+	count, _ := countReports()
+	if count != 1 {
+		t.Errorf("We have %d reports, not 1", count)
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test a spool-directory, and a database we can open, are required.
+//
+func TestIngestFailures(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	cmd := ingestCmd{once: true, dbType: "sqlite3", dbFile: path + "/db.sql"}
+	if cmd.Execute(context.TODO(), nil) != subcommands.ExitFailure {
+		t.Errorf("Expected a failing exit-status without -path")
+	}
+
+	cmd = ingestCmd{once: true, path: path, dbType: "sqlite3", dbFile: path}
+	if cmd.Execute(context.TODO(), nil) != subcommands.ExitFailure {
+		t.Errorf("Expected a failing exit-status for a broken database")
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
@@ -1,5 +1,5 @@
 //
-// Submit metrics to a graphite host.
+// Submit metrics to a carbon, StatsD, or InfluxDB host.
 //
 
 package main
@@ -9,25 +9,85 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/google/subcommands"
-	graphite "github.com/marpaia/graphite-golang"
 )
 
+//
+// MetricGroups are the optional groups of metrics which may be sent,
+// in addition to the state-counts.
+//
+var MetricGroups = []string{"role", "branch", "runtime", "resources", "reports", "builds"}
+
+//
+// Make a name safe for use as a component of a graphite metric.
+//
+func sanitiseMetric(name string) string {
+	reg := regexp.MustCompile("[^A-Za-z0-9_-]+")
+	name = reg.ReplaceAllString(name, "_")
+	if len(name) < 1 {
+		name = "unknown"
+	}
+	return name
+}
+
+//
+// Record the state-counts of the given nodes, grouped by a value of
+// each node.
+//
+func groupStates(metrics map[string]string, prefix string, nodes []PuppetRuns, group func(PuppetRuns) string) {
+	groups := make(map[string][]PuppetRuns)
+	for _, n := range nodes {
+		name := sanitiseMetric(group(n))
+		groups[name] = append(groups[name], n)
+	}
+	for name, members := range groups {
+		for _, state := range countStates(members) {
+			metrics[fmt.Sprintf("%s.%s.state.%s", prefix, name, state.State)] = fmt.Sprintf("%d", state.Count)
+		}
+	}
+}
+
 //
 // Get all the metrics
 //
-func getMetrics() map[string]string {
+// If `suppress` is set then nodes which are flapping are left out of
+// the state-counts, so that alerts raised upon those counts will not
+// fire for them.
+//
+// Any of the optional MetricGroups may also be requested.
+//
+func getMetrics(suppress bool, groups ...string) (map[string]string, error) {
 
 	// A map to store the names & values which should be sent.
 	metrics := make(map[string]string)
 
-	// Get the node-states.
-	data, err := getStates()
+	// Get the nodes.
+	NodeList, err := getIndexNodes()
 	if err != nil {
-		fmt.Printf("Error getting node states: %s\n", err.Error())
-		os.Exit(1)
+		return nil, fmt.Errorf("failed to get node states: %s", err.Error())
+	}
+
+	// Count those which are flapping, and drop them if we should.
+	var nodes []PuppetRuns
+	flapping := 0
+	for _, n := range NodeList {
+		if n.Flapping {
+			flapping++
+			if suppress {
+				continue
+			}
+		}
+		nodes = append(nodes, n)
 	}
+	metrics["flapping"] = fmt.Sprintf("%d", flapping)
+
+	// Get the node-states.
+	data := countStates(nodes)
 
 	// Now record the metrics we would send.
 	for i := range data {
@@ -40,74 +100,180 @@ func getMetrics() map[string]string {
 		metrics[metric] = value
 	}
 
+	// Which optional groups were requested?
+	enabled := make(map[string]bool)
+	for _, group := range groups {
+		enabled[group] = true
+	}
+
+	// State-counts per role, and per branch.
+	if enabled["role"] {
+		groupStates(metrics, "role", nodes, func(n PuppetRuns) string { return n.Role })
+	}
+	if enabled["branch"] {
+		groupStates(metrics, "branch", nodes, func(n PuppetRuns) string { return n.Branch })
+	}
+
+	// The number of nodes on each build.
+	if enabled["builds"] {
+		builds := make(map[string]int)
+		for _, n := range nodes {
+			builds[sanitiseMetric(n.BuiltEpoch)]++
+		}
+		for build, count := range builds {
+			metrics[fmt.Sprintf("build.%s.nodes", build)] = fmt.Sprintf("%d", count)
+		}
+	}
+
+	// The remaining groups describe the reports of the past hour.
+	if enabled["runtime"] || enabled["resources"] || enabled["reports"] {
+		activity, err := getActivity(time.Now().Unix() - 60*60)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get recent reports: %s", err.Error())
+		}
+
+		if enabled["runtime"] {
+			total := 0.0
+			for _, r := range activity.Runtimes {
+				total += r
+			}
+			average := 0.0
+			if len(activity.Runtimes) > 0 {
+				average = total / float64(len(activity.Runtimes))
+			}
+			metrics["runtime.average"] = fmt.Sprintf("%.2f", average)
+			metrics["runtime.p95"] = fmt.Sprintf("%.2f", percentile(activity.Runtimes, 95))
+		}
+		if enabled["resources"] {
+			metrics["resources.failed"] = fmt.Sprintf("%d", activity.Failed)
+			metrics["resources.changed"] = fmt.Sprintf("%d", activity.Changed)
+		}
+		if enabled["reports"] {
+			metrics["reports.received"] = fmt.Sprintf("%d", activity.Reports)
+		}
+	}
+
 	// And return them
-	return metrics
+	return metrics, nil
 }
 
 //
-// SendMetrics submits the metrics discovered to the specified carbon
-// server - unless `nop` is in-use, in which case they are dumped to
-// STDOUT.
+// metricPoint is a single value of a metric, at a point in time.
 //
-func SendMetrics(host string, port int, prefix string, nop bool) {
+type metricPoint struct {
+	Name  string
+	Value string
+	At    time.Time
+}
 
-	// Get the metrics.
-	metrics := getMetrics()
+//
+// Convert the metrics to points, with the given prefix and time, in
+// a stable order.
+//
+func metricPoints(metrics map[string]string, prefix string, at time.Time) []metricPoint {
+	var names []string
+	for name := range metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
 
-	// Create the helper.
-	g, err := graphite.NewGraphite(host, port)
+	var points []metricPoint
+	for _, name := range names {
+		points = append(points, metricPoint{Name: fmt.Sprintf("%s.%s", prefix, name), Value: metrics[name], At: at})
+	}
+	return points
+}
 
-	//
-	// If there was an error in the helper we're OK,
-	// providing we are running in `-nop`-mode.
-	//
-	if (err != nil) && (!nop) {
-		fmt.Printf("Error creating metrics-helper: %s\n", err.Error())
-		return
+//
+// Send the points to the given output, and close it.
+//
+func sendPoints(output MetricsOutput, points []metricPoint) error {
+	for _, point := range points {
+		err := output.Send(point.Name, point.Value, point.At)
+		if err != nil {
+			output.Close()
+			return err
+		}
 	}
+	return output.Close()
+}
 
-	//
-	// For each one ..
-	//
-	for name, value := range metrics {
+//
+// SendMetrics submits the metrics discovered to the given output, each
+// with the specified prefix.
+//
+func SendMetrics(output MetricsOutput, prefix string, suppress bool, groups ...string) error {
 
-		//
-		// Add the prefix.
-		//
-		name = fmt.Sprintf("%s.%s", prefix, name)
+	// Get the metrics.
+	metrics, err := getMetrics(suppress, groups...)
+	if err != nil {
+		output.Close()
+		return err
+	}
 
-		//
-		// Show/Send.
-		//
-		if nop {
-			fmt.Fprintf(out, "%s %s\n", name, value)
-		} else {
-			g.SimpleSend(name, value)
+	// Send them, all recorded at the same time.
+	return sendPoints(output, metricPoints(metrics, prefix, time.Now()))
+}
+
+//
+// Parse a comma-separated list of metric-groups, expanding "all".
+//
+func parseGroups(list string) ([]string, error) {
+	var groups []string
+
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if len(name) < 1 {
+			continue
+		}
+		if name == "all" {
+			return MetricGroups, nil
 		}
 
+		known := false
+		for _, group := range MetricGroups {
+			if group == name {
+				known = true
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown metric group '%s'", name)
+		}
+		groups = append(groups, name)
 	}
+	return groups, nil
 }
 
 //
 // The options set by our command-line flags.
 //
 type metricsCmd struct {
-	dbFile string
-	dbType string
-	host   string
-	port   int
-	prefix string
-	nop    bool
+	database   string
+	dbFile     string
+	dbType     string
+	groups     string
+	host       string
+	output     string
+	port       int
+	prefix     string
+	resolution string
+	retries    int
+	since      string
+	timeout    time.Duration
+	backfill   bool
+	nop        bool
+	pickle     bool
+	suppress   bool
 }
 
 //
 // Glue
 //
 func (*metricsCmd) Name() string     { return "metrics" }
-func (*metricsCmd) Synopsis() string { return "Submit metrics to a central carbon server." }
+func (*metricsCmd) Synopsis() string { return "Submit metrics to a central metrics server." }
 func (*metricsCmd) Usage() string {
 	return `metrics [options]:
-  Submit metrics to a central carbon server.
+  Submit metrics to a central carbon, StatsD, or InfluxDB server.
 `
 }
 
@@ -117,10 +283,20 @@ func (*metricsCmd) Usage() string {
 func (p *metricsCmd) SetFlags(f *flag.FlagSet) {
 	f.StringVar(&p.dbType, "db-type", "sqlite3", "The SQLite database to use.")
 	f.StringVar(&p.dbFile, "db-file", "ps.db", "The SQLite database to use or DSN for mysql (`db_user:db_password@tcp(db_hostname:db_port)/db_name`)")
-	f.StringVar(&p.host, "host", "localhost", "The carbon host to send metrics to.")
-	f.IntVar(&p.port, "port", 2003, "The carbon port to use, when submitting metrics.")
+	f.StringVar(&p.output, "output", "carbon", "Where to send metrics: carbon, statsd, influxdb, or influxdb-udp.")
+	f.StringVar(&p.host, "host", "localhost", "The host to send metrics to.")
+	f.IntVar(&p.port, "port", 0, "The port to use, when submitting metrics, defaults to the usual port of the output.")
+	f.StringVar(&p.database, "database", "puppet", "The InfluxDB database to write to, when using HTTP.")
+	f.BoolVar(&p.pickle, "pickle", false, "Use carbon's pickle protocol, defaulting to port 2004.")
+	f.IntVar(&p.retries, "retries", 2, "How many times to retry a failed submission.")
+	f.DurationVar(&p.timeout, "timeout", 10*time.Second, "The timeout for each attempt to submit metrics.")
+	f.BoolVar(&p.backfill, "backfill", false, "Rebuild historical metrics from the stored reports, rather than sending current values.")
+	f.StringVar(&p.since, "since", "14d", "How far back to go, when backfilling.")
+	f.StringVar(&p.resolution, "resolution", "1h", "The interval between points, when backfilling.")
 	f.StringVar(&p.prefix, "prefix", "puppet", "The prefix to use when submitting metrics.")
 	f.BoolVar(&p.nop, "nop", false, "Print metrics rather than submitting them.")
+	f.BoolVar(&p.suppress, "suppress-flapping", false, "Leave flapping nodes out of the state counts.")
+	f.StringVar(&p.groups, "groups", "", "A comma-separated list of extra metrics to send: role, branch, runtime, resources, reports, builds, or all.")
 }
 
 //
@@ -132,15 +308,97 @@ func (p *metricsCmd) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{
 	// Setup the database, by opening a handle, and creating it if
 	// missing.
 	//
-	SetupDB(p.dbType, p.dbFile)
+	err := SetupDB(p.dbType, p.dbFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening database: %s\n", err.Error())
+		return subcommands.ExitFailure
+	}
+
+	//
+	// Work out which optional metrics to send.
+	//
+	groups, err := parseGroups(p.groups)
+	if err != nil {
+		fmt.Printf("Error: %s\n", err.Error())
+		return subcommands.ExitFailure
+	}
+
+	//
+	// Create the output.
+	//
+	opts := OutputOptions{Database: p.database, Pickle: p.pickle, Retries: p.retries, Timeout: p.timeout}
+	output, err := newMetricsOutput(p.output, p.host, p.port, opts, p.nop)
+	if err != nil {
+		fmt.Printf("Error creating metrics-output: %s\n", err.Error())
+		return subcommands.ExitFailure
+	}
+
+	//
+	// Rebuild the past, if we should.
+	//
+	if p.backfill {
+		return p.runBackfill(output)
+	}
 
 	//
 	// Run metrics
 	//
-	SendMetrics(p.host, p.port, p.prefix, p.nop)
+	err = SendMetrics(output, p.prefix, p.suppress, groups...)
+	if err != nil {
+		fmt.Printf("Error sending metrics: %s\n", err.Error())
+		return subcommands.ExitFailure
+	}
 
 	//
 	// All done.
 	//
 	return subcommands.ExitSuccess
 }
+
+//
+// Send the historical metrics, as requested by `-backfill`.
+//
+func (p *metricsCmd) runBackfill(output MetricsOutput) subcommands.ExitStatus {
+
+	if p.output == "statsd" && !p.nop {
+		output.Close()
+		fmt.Printf("Error: StatsD cannot accept historical metrics\n")
+		return subcommands.ExitFailure
+	}
+
+	since, err := parsePeriod(p.since)
+	if err != nil {
+		output.Close()
+		fmt.Printf("Error: %s\n", err.Error())
+		return subcommands.ExitFailure
+	}
+	resolution, err := parsePeriod(p.resolution)
+	if err != nil {
+		output.Close()
+		fmt.Printf("Error: %s\n", err.Error())
+		return subcommands.ExitFailure
+	}
+
+	//
+	// Points are aligned to the resolution, so repeated backfills
+	// overwrite, rather than duplicate, one another.
+	//
+	until := time.Now().Truncate(resolution)
+	points, err := backfillPoints(p.prefix, until.Add(-since), until, resolution)
+	if err != nil {
+		output.Close()
+		fmt.Printf("Error rebuilding metrics: %s\n", err.Error())
+		return subcommands.ExitFailure
+	}
+
+	err = sendPoints(output, points)
+	if err != nil {
+		fmt.Printf("Error sending metrics: %s\n", err.Error())
+		return subcommands.ExitFailure
+	}
+
+	if !p.nop {
+		fmt.Printf("Sent %d points, from %s until %s\n", len(points), until.Add(-since).Format("2006-01-02 15:04:05"), until.Format("2006-01-02 15:04:05"))
+	}
+	return subcommands.ExitSuccess
+}
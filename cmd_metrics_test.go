@@ -3,9 +3,13 @@ package main
 import (
 	"bytes"
 	"context"
+	"net"
 	"os"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/google/subcommands"
 )
 
 func TestMetrics(t *testing.T) {
@@ -17,10 +21,13 @@ func TestMetrics(t *testing.T) {
 	addFakeNodes()
 
 	// Get the metrics
-	metrics := getMetrics()
+	metrics, err := getMetrics(false)
+	if err != nil {
+		t.Fatalf("Failed to get metrics: %s", err.Error())
+	}
 
 	// Now test we can find things.
-	if len(metrics) != 4 {
+	if len(metrics) != 6 {
 		t.Errorf("Unexpected metrics-size: %v", len(metrics))
 	}
 
@@ -34,6 +41,12 @@ func TestMetrics(t *testing.T) {
 	if metrics["state.failed"] != "1" {
 		t.Errorf("Unexpected metrics value")
 	}
+	if metrics["flapping"] != "0" {
+		t.Errorf("Unexpected metrics value")
+	}
+	if metrics["state.late"] != "0" {
+		t.Errorf("Unexpected metrics value")
+	}
 	if metrics["state.orphaned"] != "0" {
 		t.Errorf("Unexpected metrics value")
 	}
@@ -67,8 +80,10 @@ func TestMetricNop(t *testing.T) {
 
 	//
 	// Dump our metrics to STDOUT, due to `nop`, which will end up
-	// in our faux buffer.
-	s := metricsCmd{nop: true}
+	// in our faux buffer.  The command opens the database itself.
+	//
+	db.Close()
+	s := metricsCmd{nop: true, dbType: "sqlite3", dbFile: path + "/db.sql"}
 	s.Execute(context.TODO(), nil)
 
 	//
@@ -85,6 +100,7 @@ func TestMetricNop(t *testing.T) {
 	//
 	desired := []string{".state.changed 0",
 		".state.failed 0",
+		".state.late 0",
 		".state.orphaned 0",
 		".state.unchanged 0"}
 
@@ -102,3 +118,187 @@ func TestMetricNop(t *testing.T) {
 	db = nil
 	os.RemoveAll(path)
 }
+
+//
+// Test that flapping nodes can be left out of the state-counts.
+//
+func TestMetricsSuppressFlapping(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	// Add a node which fails every other run.
+	addFakeRuns("flaky.example.com", "failed", "unchanged", "failed", "unchanged", "failed")
+
+	metrics, _ := getMetrics(false)
+	if metrics["state.failed"] != "1" {
+		t.Errorf("Unexpected metrics value")
+	}
+	if metrics["flapping"] != "1" {
+		t.Errorf("Unexpected metrics value")
+	}
+
+	metrics, _ = getMetrics(true)
+	if metrics["state.failed"] != "0" {
+		t.Errorf("Unexpected metrics value")
+	}
+	if metrics["flapping"] != "1" {
+		t.Errorf("Unexpected metrics value")
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test the optional groups of metrics.
+//
+func TestMetricGroups(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	// Add some hosts.
+	addFakeNodes()
+
+	metrics, err := getMetrics(false, MetricGroups...)
+	if err != nil {
+		t.Fatalf("Failed to get metrics: %s", err.Error())
+	}
+
+	expected := map[string]string{
+		"role.unknown.state.failed":    "1",
+		"role.unknown.state.unchanged": "1",
+		"branch.unknown.state.changed": "0",
+		"build.0.nodes":                "2",
+		"runtime.average":              "2.93",
+		"runtime.p95":                  "3.13",
+		"resources.failed":             "0",
+		"resources.changed":            "4",
+		"reports.received":             "2",
+	}
+	for name, value := range expected {
+		if metrics[name] != value {
+			t.Errorf("Unexpected value for %s: '%s'", name, metrics[name])
+		}
+	}
+
+	// None of these are sent by default.
+	metrics, _ = getMetrics(false)
+	if _, ok := metrics["reports.received"]; ok {
+		t.Errorf("Optional metrics sent by default")
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test that metric names are made safe for graphite.
+//
+func TestSanitiseMetric(t *testing.T) {
+
+	tests := map[string]string{
+		"web":            "web",
+		"feature/foo.1":  "feature_foo_1",
+		"":               "unknown",
+		"a b  c":         "a_b_c",
+		"release-2019_1": "release-2019_1",
+	}
+
+	for input, expected := range tests {
+		if sanitiseMetric(input) != expected {
+			t.Errorf("Unexpected sanitised name for '%s': %s", input, sanitiseMetric(input))
+		}
+	}
+}
+
+//
+// Test the parsing of metric-groups.
+//
+func TestParseGroups(t *testing.T) {
+
+	groups, err := parseGroups("role, runtime")
+	if err != nil || len(groups) != 2 || groups[1] != "runtime" {
+		t.Errorf("Unexpected groups: %v %v", groups, err)
+	}
+
+	groups, err = parseGroups("all")
+	if err != nil || len(groups) != len(MetricGroups) {
+		t.Errorf("Unexpected groups: %v %v", groups, err)
+	}
+
+	groups, err = parseGroups("")
+	if err != nil || len(groups) != 0 {
+		t.Errorf("Unexpected groups: %v %v", groups, err)
+	}
+
+	_, err = parseGroups("role,steve")
+	if err == nil {
+		t.Errorf("Expected an error for an unknown group")
+	}
+}
+
+//
+// Test that a failed submission results in a failing exit-status.
+//
+func TestMetricsExitStatus(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	old := RetryDelay
+	RetryDelay = time.Millisecond
+	defer func() { RetryDelay = old }()
+
+	//
+	// Nothing is listening upon this port.
+	//
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+
+	// The command opens the database itself.
+	db.Close()
+
+	s := metricsCmd{host: "127.0.0.1", port: port, retries: 1, timeout: time.Second, dbType: "sqlite3", dbFile: path + "/db.sql"}
+	if s.Execute(context.TODO(), nil) != subcommands.ExitFailure {
+		t.Errorf("Expected a failing exit-status")
+	}
+
+	db.Close()
+
+	s = metricsCmd{output: "steve", dbType: "sqlite3", dbFile: path + "/db.sql"}
+	if s.Execute(context.TODO(), nil) != subcommands.ExitFailure {
+		t.Errorf("Expected a failing exit-status for an unknown output")
+	}
+
+	//
+	// A database which can't be opened fails too.
+	//
+	s = metricsCmd{nop: true, dbType: "sqlite3", dbFile: path}
+	if s.Execute(context.TODO(), nil) != subcommands.ExitFailure {
+		t.Errorf("Expected a failing exit-status for a broken database")
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
@@ -0,0 +1,128 @@
+//
+// Rebuild the history, and the state of our hosts, from stored reports.
+//
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/google/subcommands"
+)
+
+//
+// The options set by our command-line flags.
+//
+type rebuildCmd struct {
+	batch   int
+	dbFile  string
+	dbType  string
+	prefix  string
+	verbose bool
+	yaml    bool
+}
+
+//
+// Run a rebuild
+//
+func runRebuild(x rebuildCmd) error {
+
+	if x.batch < 1 {
+		x.batch = RebuildBatch
+	}
+
+	//
+	// Re-parsing the YAML?
+	//
+	var fingerprints map[string]reportFingerprint
+	if x.yaml {
+		if x.verbose {
+			fmt.Printf("Parsing reports from beneath %s\n", x.prefix)
+		}
+
+		var failures int
+		var err error
+		fingerprints, failures, err = rebuildReports(x.prefix, x.batch, x.verbose)
+		if err != nil {
+			return err
+		}
+		if failures > 0 {
+			fmt.Fprintf(out, "Skipped %d files which couldn't be parsed\n", failures)
+		}
+	}
+
+	count, err := rebuildFromReports(fingerprints, x.batch, x.verbose)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Rebuilt history from %d reports\n", count)
+	return nil
+}
+
+//
+// Glue
+//
+func (*rebuildCmd) Name() string     { return "rebuild" }
+func (*rebuildCmd) Synopsis() string { return "Rebuild history, and hosts, from stored reports." }
+func (*rebuildCmd) Usage() string {
+	return `rebuild [options]:
+  Recompute the history, rollups, state-transitions, and state of each
+  host, from the reports held in our database, or by re-parsing the YAML
+  files beneath -prefix.
+
+  Not everything is rebuilt:
+
+   * History which began before the oldest report we hold is left alone.
+   * Transitions into, or out of, the late and orphaned states are kept,
+     as reports can't tell us about them.
+   * The changed resources, and resource timings, of each report are only
+     recomputed with -yaml, as they are only held within the YAML.
+
+  The server should be stopped while this runs, as reports it receives
+  might be counted twice.
+`
+}
+
+//
+// Flag setup
+//
+func (p *rebuildCmd) SetFlags(f *flag.FlagSet) {
+	f.BoolVar(&p.verbose, "verbose", false, "Be verbose in reporting output")
+	f.BoolVar(&p.yaml, "yaml", false, "Re-parse the YAML files beneath -prefix, updating the reports we hold from them.")
+	f.IntVar(&p.batch, "batch", 1000, "The number of reports to process within each transaction.")
+	f.StringVar(&p.dbType, "db-type", "sqlite3", "The SQLite database to use.")
+	f.StringVar(&p.dbFile, "db-file", "ps.db", "The SQLite database to use or DSN for mysql (`db_user:db_password@tcp(db_hostname:db_port)/db_name`)")
+	f.StringVar(&p.prefix, "prefix", "./reports/", "The prefix to the local YAML hierarchy.")
+}
+
+//
+// Entry-point.
+//
+func (p *rebuildCmd) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+
+	//
+	// Setup the database, by opening a handle, and creating it if
+	// missing.
+	//
+	err := SetupDB(p.dbType, p.dbFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening database: %s\n", err.Error())
+		return subcommands.ExitFailure
+	}
+
+	//
+	// Invoke the rebuild
+	//
+	err = runRebuild(*p)
+
+	if err == nil {
+		return subcommands.ExitSuccess
+	}
+
+	fmt.Printf("Error rebuilding: %s\n", err.Error())
+	return subcommands.ExitFailure
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/subcommands"
+)
+
+//
+// Test the history, and hosts, are rebuilt from the reports we hold.
+//
+func TestRebuildCommand(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	bak := out
+	out = new(bytes.Buffer)
+	defer func() { out = bak }()
+
+	add := func(fqdn string, role string, states ...string) {
+		var n PuppetReport
+		n.Fqdn = fqdn
+		n.Runtime = "1.0"
+		n.Role = role
+
+		for _, state := range states {
+			n.State = state
+			addDB(n, "")
+		}
+	}
+	add("web1.example.com", "web", "unchanged", "changed", "failed", "unchanged")
+	add("db1.example.com", "db", "failed")
+
+	//
+	// Move the reports back in time, an hour apart, starting at the
+	// beginning of a day.
+	//
+	day, _ := historyResolution("day")
+	hour, _ := historyResolution("hour")
+	base := day.bucket(time.Now().Unix()) - 2*24*60*60
+	db.Exec("UPDATE reports SET executed_at = ? + ( id - 1 ) * 3600", base)
+
+	//
+	// Lose our history, and the state of our hosts, except for an
+	// older bucket which we should leave alone.
+	//
+	db.Exec("DELETE FROM history")
+	db.Exec("DELETE FROM rollups")
+	db.Exec("DELETE FROM rollup_nodes")
+	db.Exec("UPDATE hosts SET state = '', last_seen = 0, run_interval = 0, flap_score = 0")
+	updateRollups("old.example.com", "web", "", "", "failed", base-3600)
+
+	//
+	// The transitions we made are lost too, except that db1 became
+	// orphaned, which we should keep.
+	//
+	db.Exec("DELETE FROM state_transitions")
+	db.Exec("INSERT INTO state_transitions(host_id, fqdn, role, from_state, to_state, changed_at) VALUES(0, 'db1.example.com', 'db', 'failed', 'orphaned', ?)", base+10*3600)
+
+	err := runRebuild(rebuildCmd{batch: 2})
+	if err != nil {
+		t.Fatalf("Failed to rebuild: %s", err.Error())
+	}
+	if !strings.Contains(out.(*bytes.Buffer).String(), "Rebuilt history from 5 reports") {
+		t.Errorf("Unexpected output: %s", out)
+	}
+
+	rollups, _ := getRollups(day, "", "", base-24*60*60, base+24*60*60)
+	if len(rollups) != 2 || rollups[0].Failed != 1 || rollups[1].Failed != 2 || rollups[1].FailedNodes != 2 || rollups[1].Unchanged != 2 || rollups[1].UnchangedNodes != 1 {
+		t.Errorf("Unexpected rollups: %v", rollups)
+	}
+
+	rollups, _ = getRollups(hour, "role", "web", base, base+24*60*60)
+	if len(rollups) != 4 || rollups[2].Failed != 1 || rollups[3].Unchanged != 1 {
+		t.Errorf("Unexpected rollups: %v", rollups)
+	}
+
+	var failed, changed, unchanged int
+	row := db.QueryRow("SELECT failed, changed, unchanged FROM history WHERE date = ?", day.label(base))
+	err = row.Scan(&failed, &changed, &unchanged)
+	if err != nil || failed != 2 || changed != 1 || unchanged != 2 {
+		t.Errorf("Unexpected history: %d %d %d %v", failed, changed, unchanged, err)
+	}
+
+	var state string
+	var last_seen, run_interval int64
+	row = db.QueryRow("SELECT state, last_seen, run_interval FROM hosts WHERE fqdn = 'web1.example.com'")
+	row.Scan(&state, &last_seen, &run_interval)
+	if state != "unchanged" || last_seen != base+3*3600 || run_interval != 3600 {
+		t.Errorf("Unexpected host: %s %d %d", state, last_seen, run_interval)
+	}
+
+	//
+	// The transitions are replayed from the reports, newest first.
+	//
+	expected := map[string][]string{
+		"web1.example.com": {"failed->unchanged", "changed->failed", "unchanged->changed", "->unchanged"},
+		"db1.example.com":  {"failed->orphaned", "->failed"},
+	}
+	check := func() {
+		for fqdn, moves := range expected {
+			transitions, _ := getTransitions(fqdn)
+			var found []string
+			for _, tr := range transitions {
+				found = append(found, tr.From+"->"+tr.To)
+			}
+			if strings.Join(found, ",") != strings.Join(moves, ",") {
+				t.Errorf("Unexpected transitions of %s: %v", fqdn, found)
+			}
+		}
+	}
+	check()
+
+	transitions, _ := getTransitions("web1.example.com")
+	if len(transitions) != 4 || transitions[0].Epoch != fmt.Sprintf("%d", base+3*3600) {
+		t.Errorf("Unexpected transitions: %v", transitions)
+	}
+
+	//
+	// Rebuilding again changes nothing.
+	//
+	runRebuild(rebuildCmd{})
+	rollups, _ = getRollups(day, "", "", base, base+24*60*60)
+	if len(rollups) != 1 || rollups[0].Failed != 2 || rollups[0].FailedNodes != 2 {
+		t.Errorf("Unexpected rollups: %v", rollups)
+	}
+	check()
+
+	//
+	// A database which can't be opened fails.
+	//
+	cmd := rebuildCmd{dbType: "sqlite3", dbFile: path}
+	if cmd.Execute(context.TODO(), nil) != subcommands.ExitFailure {
+		t.Errorf("Expected a failing exit-status for a broken database")
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test the reports are rebuilt by re-parsing their YAML.
+//
+func TestRebuildYAML(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	bak := out
+	out = new(bytes.Buffer)
+	defer func() { out = bak }()
+
+	dir, err := ioutil.TempDir("", "prefix")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	content, err := getResource("data/valid.yaml")
+	if err != nil {
+		t.Fatal("Failed to load YAML asset data/valid.yaml")
+	}
+	os.MkdirAll(filepath.Join(dir, "www.steve.org.uk"), 0755)
+	ioutil.WriteFile(filepath.Join(dir, "www.steve.org.uk", "1234"), content, 0644)
+	ioutil.WriteFile(filepath.Join(dir, "www.steve.org.uk", "broken"), []byte("steve"), 0644)
+
+	for i := 0; i < 2; i++ {
+		err = runRebuild(rebuildCmd{prefix: dir, yaml: true})
+		if err != nil {
+			t.Fatalf("Failed to rebuild: %s", err.Error())
+		}
+	}
+	if !strings.Contains(out.(*bytes.Buffer).String(), "Skipped 1 files which couldn't be parsed") {
+		t.Errorf("Unexpected output: %s", out)
+	}
+
+	//
+	// The report is added once, at the time it was executed.
+	//
+	count, _ := countReports()
+	if count != 1 {
+		t.Errorf("We have %d reports, not 1", count)
+	}
+
+	at := time.Date(2017, 7, 29, 23, 17, 1, 0, time.UTC).Local()
+	reports, _ := getReports("www.steve.org.uk")
+	if len(reports) != 1 || reports[0].YamlFile != filepath.Join("www.steve.org.uk", "1234") || reports[0].At != at.Format("2006-01-02 15:04:05") {
+		t.Errorf("Unexpected reports: %v", reports)
+	}
+
+	var state string
+	row := db.QueryRow("SELECT state FROM hosts WHERE fqdn = 'www.steve.org.uk'")
+	row.Scan(&state)
+	if state != "unchanged" {
+		t.Errorf("Unexpected state: %s", state)
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test the time a report was executed respects the node's offset from UTC.
+//
+func TestExecutedAt(t *testing.T) {
+
+	tests := []struct {
+		Report PuppetReport
+		At     int64
+	}{
+		{PuppetReport{At: "2017-07-29 23:17:01", Offset: "+00:00"}, 1501370221},
+		{PuppetReport{At: "2017-07-30 04:47:01", Offset: "+05:30"}, 1501370221},
+		{PuppetReport{At: "2017-07-29 18:17:01", Offset: "-05:00"}, 1501370221},
+		{PuppetReport{At: "steve"}, 42},
+	}
+
+	for _, test := range tests {
+		if at := executedAt(test.Report, 42); at != test.At {
+			t.Errorf("Unexpected time for %v: %d", test.Report, at)
+		}
+	}
+
+	//
+	// Times without an offset are local.
+	//
+	local := time.Date(2017, 7, 29, 23, 17, 1, 0, time.Local).Unix()
+	if at := executedAt(PuppetReport{At: "2017-07-29 23:17:01"}, 42); at != local {
+		t.Errorf("Unexpected local time: %d", at)
+	}
+}
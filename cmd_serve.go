@@ -15,11 +15,13 @@ import (
 	"html/template"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/subcommands"
@@ -84,6 +86,7 @@ func APIState(res http.ResponseWriter, req *http.Request) {
 	case "changed":
 	case "unchanged":
 	case "failed":
+	case "late":
 	case "orphaned":
 	default:
 		err = errors.New("invalid state supplied")
@@ -159,16 +162,1794 @@ func APIState(res http.ResponseWriter, req *http.Request) {
 
 }
 
+//
+// APIFlapping is the handler for the HTTP end-point
+//
+//	 GET /api/flapping
+//
+// It returns the names of the nodes which are currently flapping between
+// failed and working states.
+//
+// This will return JSON by default, but XML and plain-text are both
+// possible via the `Accept:` header or `?accept=XX` parameter.
+//
+func APIFlapping(res http.ResponseWriter, req *http.Request) {
+
+	var (
+		status int
+		err    error
+	)
+	defer func() {
+		if nil != err {
+			http.Error(res, err.Error(), status)
+		}
+	}()
+
+	//
+	// Get the nodes.
+	//
+	NodeList, err := getIndexNodes()
+	if err != nil {
+		status = http.StatusInternalServerError
+		return
+	}
+
+	//
+	// The result
+	//
+	var result []string
+
+	for _, o := range NodeList {
+		if o.Flapping {
+			result = append(result, o.Fqdn)
+		}
+	}
+
+	//
+	// What kind of reply should we send?
+	//
+	// Accept either a "?accept=XXX" URL-parameter, or
+	// the Accept HEADER in the HTTP request
+	//
+	accept := req.FormValue("accept")
+	if len(accept) < 1 {
+		accept = req.Header.Get("Accept")
+	}
+
+	switch accept {
+	case "text/plain":
+		res.Header().Set("Content-Type", "text/plain")
+
+		for _, o := range result {
+			fmt.Fprintf(res, "%s\n", o)
+		}
+	case "application/xml":
+		x, err := xml.MarshalIndent(result, "", "  ")
+		if err != nil {
+			status = http.StatusInternalServerError
+			return
+		}
+
+		res.Header().Set("Content-Type", "application/xml")
+		res.Write(x)
+	default:
+
+		//
+		// Convert the string-array to JSON, and return it.
+		//
+		res.Header().Set("Content-Type", "application/json")
+
+		if len(result) > 0 {
+			out, _ := json.Marshal(result)
+			fmt.Fprintf(res, "%s", out)
+		} else {
+			fmt.Fprintf(res, "[]")
+		}
+
+	}
+}
+
+//
+// APIEvents is the handler for the HTTP end-point
+//
+//	 GET /api/v1/events
+//
+// It returns the events recorded within the past `since` period, which
+// defaults to 30 days, optionally only those with the given `tag`.
+//
+// This will return JSON by default, but XML is also possible via the
+// `Accept:` header or `?accept=XX` parameter.
+//
+func APIEvents(res http.ResponseWriter, req *http.Request) {
+
+	var (
+		status int
+		err    error
+	)
+	defer func() {
+		if nil != err {
+			http.Error(res, err.Error(), status)
+		}
+	}()
+
+	since := 30 * 24 * time.Hour
+	if len(req.FormValue("since")) > 0 {
+		since, err = parsePeriod(req.FormValue("since"))
+		if err != nil {
+			status = http.StatusBadRequest
+			return
+		}
+	}
+
+	events, err := getEvents(time.Now().Add(-since).Unix(), req.FormValue("tag"))
+	if err != nil {
+		status = http.StatusInternalServerError
+		return
+	}
+
+	//
+	// Accept either a "?accept=XXX" URL-parameter, or
+	// the Accept HEADER in the HTTP request
+	//
+	accept := req.FormValue("accept")
+	if len(accept) < 1 {
+		accept = req.Header.Get("Accept")
+	}
+
+	switch accept {
+	case "application/xml":
+		x, err := xml.MarshalIndent(events, "", "  ")
+		if err != nil {
+			status = http.StatusInternalServerError
+			return
+		}
+
+		res.Header().Set("Content-Type", "application/xml")
+		res.Write(x)
+	default:
+		res.Header().Set("Content-Type", "application/json")
+
+		if len(events) > 0 {
+			out, _ := json.Marshal(events)
+			fmt.Fprintf(res, "%s", out)
+		} else {
+			fmt.Fprintf(res, "[]")
+		}
+	}
+}
+
+//
+// APIHistory is the handler for the HTTP end-point
+//
+//	 GET /api/v1/history
+//
+// It returns the rollups of our history at the given `resolution`, which
+// is either "hour" or "day", over the given `range` before now, or the
+// window between `from` and `to`.
+//
+// By default the history of all nodes is returned, but it may be grouped
+// by a `group` of "role", "environment", or "branch", optionally only that
+// with the given `name`.
+//
+// This will return JSON by default, but XML is also possible via the
+// `Accept:` header or `?accept=XX` parameter.
+//
+func APIHistory(res http.ResponseWriter, req *http.Request) {
+
+	var (
+		status int
+		err    error
+	)
+	defer func() {
+		if nil != err {
+			http.Error(res, err.Error(), status)
+		}
+	}()
+
+	r, from, to, err := historyWindow(req.FormValue("resolution"), req.FormValue("range"), req.FormValue("from"), req.FormValue("to"))
+	if err != nil {
+		status = http.StatusBadRequest
+		return
+	}
+
+	group := req.FormValue("group")
+	if len(group) > 0 && !historyDimension(group) {
+		status = http.StatusBadRequest
+		err = fmt.Errorf("unknown group '%s', expected role, environment, or branch", group)
+		return
+	}
+	if len(group) < 1 && len(req.FormValue("name")) > 0 {
+		status = http.StatusBadRequest
+		err = errors.New("a name may only be given along with a group")
+		return
+	}
+
+	rollups, err := getRollups(r, group, req.FormValue("name"), from, to)
+	if err != nil {
+		status = http.StatusInternalServerError
+		return
+	}
+
+	//
+	// Accept either a "?accept=XXX" URL-parameter, or
+	// the Accept HEADER in the HTTP request
+	//
+	accept := req.FormValue("accept")
+	if len(accept) < 1 {
+		accept = req.Header.Get("Accept")
+	}
+
+	switch accept {
+	case "application/xml":
+		x, err := xml.MarshalIndent(rollups, "", "  ")
+		if err != nil {
+			status = http.StatusInternalServerError
+			return
+		}
+
+		res.Header().Set("Content-Type", "application/xml")
+		res.Write(x)
+	default:
+		res.Header().Set("Content-Type", "application/json")
+
+		if len(rollups) > 0 {
+			out, _ := json.Marshal(rollups)
+			fmt.Fprintf(res, "%s", out)
+		} else {
+			fmt.Fprintf(res, "[]")
+		}
+	}
+}
+
+//
+// APIAddEvent is the handler for the HTTP end-point
+//
+//	 POST /api/v1/events
+//
+// It records an event, such as a deployment, which will be drawn upon our
+// graphs.  The event is either submitted as a JSON object:
+//
+//	 {"text": "deployed build 1234", "tags": ["production"], "at": 1501293600}
+//
+// Or as the form-values `text`, `tags` (comma-separated), and `at`.  If
+// no time is given the event happened now.
+//
+func APIAddEvent(res http.ResponseWriter, req *http.Request) {
+
+	var (
+		status int
+		err    error
+	)
+	defer func() {
+		if nil != err {
+			http.Error(res, err.Error(), status)
+
+			// Don't spam stdout when running test-cases.
+			if flag.Lookup("test.v") == nil {
+				fmt.Printf("Error: %s\n", err.Error())
+			}
+		}
+	}()
+
+	status = http.StatusBadRequest
+
+	var event PuppetEvent
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "application/json") {
+		err = json.NewDecoder(req.Body).Decode(&event)
+		if err != nil {
+			return
+		}
+	} else {
+		req.ParseForm()
+		event.Text = req.FormValue("text")
+		event.Tags = splitTags(req.FormValue("tags"))
+		event.At, err = parseFormTime(req.FormValue("at"))
+		if err != nil {
+			return
+		}
+	}
+
+	err = validateEvent(&event)
+	if err != nil {
+		return
+	}
+
+	err = addEvent(&event)
+	if err != nil {
+		status = http.StatusInternalServerError
+		return
+	}
+
+	js, _ := json.Marshal(event)
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(http.StatusCreated)
+	res.Write(js)
+}
+
+//
+// MetricsHandler is the handler for the HTTP end-point
+//
+//	 GET /metrics
+//
+// It reports the state of our fleet in the Prometheus text-format.
+//
+func MetricsHandler(res http.ResponseWriter, req *http.Request) {
+
+	var (
+		status int
+		err    error
+	)
+	defer func() {
+		if nil != err {
+			http.Error(res, err.Error(), status)
+		}
+	}()
+
+	//
+	// Get the nodes.
+	//
+	NodeList, err := getIndexNodes()
+	if err != nil {
+		status = http.StatusInternalServerError
+		return
+	}
+
+	res.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	writePrometheus(res, NodeList)
+}
+
+//
+// HealthHandler is the handler for the HTTP end-point
+//
+//	 GET /health
+//
+// It reports whether the database is reachable, and the status of the
+// most recent metrics push.
+//
+func HealthHandler(res http.ResponseWriter, req *http.Request) {
+
+	type Health struct {
+		Status   string
+		Database string
+		Metrics  PushStatus
+	}
+
+	var x Health
+	x.Status = "ok"
+	x.Database = "ok"
+
+	status := http.StatusOK
+
+	//
+	// Can we reach the database?
+	//
+	if db == nil {
+		x.Database = "SetupDB not called"
+	} else if err := db.Ping(); err != nil {
+		x.Database = err.Error()
+	}
+	if x.Database != "ok" {
+		x.Status = "failed"
+		status = http.StatusServiceUnavailable
+	}
+
+	//
+	// A failing metrics push shouldn't mark us as unhealthy, as we
+	// can still receive reports.
+	//
+	if pusher != nil {
+		x.Metrics = pusher.Status()
+		if x.Metrics.LastError != "" && x.Status == "ok" {
+			x.Status = "degraded"
+		}
+	}
+
+	js, _ := json.Marshal(x)
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(status)
+	res.Write(js)
+}
+
 //
 // RadiatorView is the handler for the HTTP end-point
 //
-//	 GET /radiator/
+//	 GET /radiator/
+//
+// It will respond in either HTML, JSON, or XML depending on the
+// Accepts-header which is received.
+//
+func RadiatorView(res http.ResponseWriter, req *http.Request) {
+	serveRadiator(res, req, "", "")
+}
+
+//
+// SliceRadiatorView is the handler for the HTTP end-points
+//
+//	 GET /role/$role/radiator/
+//	 GET /branch/$branch/radiator/
+//
+// It shows the radiator-view of the nodes with the given role, or on the
+// given branch.
+//
+func SliceRadiatorView(res http.ResponseWriter, req *http.Request) {
+	dimension, name := sliceVars(req)
+	serveRadiator(res, req, dimension, name)
+}
+
+//
+// Show the radiator-view, either of all nodes, or of those with the
+// given role, or on the given branch.
+//
+func serveRadiator(res http.ResponseWriter, req *http.Request, dimension string, name string) {
+
+	var (
+		status int
+		err    error
+	)
+	defer func() {
+		if nil != err {
+			http.Error(res, err.Error(), status)
+		}
+	}()
+
+	// anonymous struct
+	type Pagedata struct {
+		States    []PuppetState
+		Name      string
+		Base      string
+		Urlprefix string
+	}
+
+	//
+	// Get the state of the nodes.
+	//
+	var data []PuppetState
+	if len(dimension) > 0 {
+		var nodes []PuppetRuns
+		nodes, err = getSliceNodes(dimension, name)
+		if err != nil {
+			status = http.StatusInternalServerError
+			return
+		}
+		if len(nodes) < 1 {
+			status = http.StatusNotFound
+			err = fmt.Errorf("no nodes were found with %s '%s'", dimension, name)
+			return
+		}
+		data = countStates(nodes)
+	} else {
+		data, err = getStates()
+		if err != nil {
+			status = http.StatusInternalServerError
+			return
+		}
+	}
+
+	//
+	// Sum up our known-nodes.
+	//
+	total := 0
+	for i := range data {
+		total += data[i].Count
+	}
+
+	//
+	// Add in the total count of nodes.
+	//
+	var tmp PuppetState
+	tmp.State = "Total"
+	tmp.Count = total
+	tmp.Percentage = 0
+	data = append(data, tmp)
+
+	// genereic template args
+	var x Pagedata
+	x.States = data
+	x.Name = name
+	x.Base = sliceBase(dimension, name)
+	x.Urlprefix = templateArgs.urlprefix
+
+	//
+	// What kind of reply should we send?
+	//
+	// Accept either a "?accept=XXX" URL-parameter, or
+	// the Accept HEADER in the HTTP request
+	//
+	accept := req.FormValue("accept")
+	if len(accept) < 1 {
+		accept = req.Header.Get("Accept")
+	}
+
+	switch accept {
+	case "application/json":
+		js, err := json.Marshal(data)
+
+		if err != nil {
+			status = http.StatusInternalServerError
+			return
+		}
+		res.Header().Set("Content-Type", "application/json")
+		res.Write(js)
+
+	case "application/xml":
+		x, err := xml.MarshalIndent(data, "", "  ")
+		if err != nil {
+			status = http.StatusInternalServerError
+			return
+		}
+
+		res.Header().Set("Content-Type", "application/xml")
+		res.Write(x)
+	default:
+		//
+		// Load our template resource.
+		//
+		tmpl, err := getResource("data/radiator.template")
+		if err != nil {
+			fmt.Fprint(res, err.Error())
+			return
+		}
+
+		//
+		//  Load our template, from the resource.
+		//
+		src := string(tmpl)
+		t := template.Must(template.New("tmpl").Parse(src))
+
+		//
+		// Execute the template into our buffer.
+		//
+		buf := &bytes.Buffer{}
+		err = t.Execute(buf, x)
+
+		//
+		// If there were errors, then show them.
+		if err != nil {
+			fmt.Fprint(res, err.Error())
+			return
+		}
+
+		//
+		// Otherwise write the result.
+		//
+		buf.WriteTo(res)
+	}
+}
+
+//
+// errDuplicateReport is returned when a report we already hold is
+// submitted again.
+//
+var errDuplicateReport = errors.New("ignoring duplicate submission")
+
+//
+// Parse a report, write it beneath our prefix, and record it in our
+// database.  This is used for the reports uploaded to us, and those
+// found within a spool-directory.
+//
+// If we've written the report, but not recorded it, then we record it
+// now, so that a submission which was interrupted may be retried.
+//
+func submitReport(content []byte) (PuppetReport, error) {
+
+	//
+	// Parse the YAML into something we can work with.
+	//
+	report, err := ParsePuppetReport(content)
+	if err != nil {
+		atomic.AddUint64(&reportParseFailures, 1)
+		return report, err
+	}
+
+	//
+	// Create a report directory for this host, unless it already exists.
+	//
+	dir := filepath.Join(ReportPrefix, report.Fqdn)
+	if !Exists(dir) {
+		err = os.MkdirAll(dir, 0755)
+		if err != nil {
+			return report, err
+		}
+	}
+
+	//
+	// Does this report already exist?  This shouldn't happen
+	// in a usual setup, but will happen if you're repeatedly
+	// importing reports manually from a puppet-server.
+	//
+	// (Which is something you might do when testing the dashboard.)
+	//
+	path := filepath.Join(dir, report.Hash)
+	relativePath := filepath.Join(report.Fqdn, report.Hash)
+
+	if Exists(path) {
+		stored, err := reportStored(relativePath)
+		if err != nil {
+			return report, err
+		}
+		if stored {
+			atomic.AddUint64(&reportsDuplicate, 1)
+			return report, errDuplicateReport
+		}
+	} else {
+
+		//
+		// Create the new report-file, on-disk.
+		//
+		err = ioutil.WriteFile(path, content, 0644)
+		if err != nil {
+			return report, err
+		}
+	}
+
+	//
+	// Record that report in our SQLite database
+	//
+	err = addDB(report, relativePath)
+	if err != nil {
+		return report, err
+	}
+	atomic.AddUint64(&reportsReceived, 1)
+	return report, nil
+}
+
+//
+// ReportSubmissionHandler is the handler for the HTTP end-point:
+//
+//	POST /upload
+//
+// The input is read, and parsed as Yaml, and assuming that succeeds
+// then the data is written beneath ./reports/$hostname/$timestamp
+// and a summary-record is inserted into our SQLite database.
+//
+//
+func ReportSubmissionHandler(res http.ResponseWriter, req *http.Request) {
+	var (
+		status int
+		err    error
+	)
+	defer func() {
+		if nil != err {
+			http.Error(res, err.Error(), status)
+
+			// Don't spam stdout when running test-cases.
+			if flag.Lookup("test.v") == nil {
+				fmt.Printf("Error: %s\n", err.Error())
+			}
+		}
+	}()
+
+	//
+	// Ensure this was a POST-request
+	//
+	if req.Method != "POST" {
+		err = errors.New("must be called via HTTP-POST")
+		status = http.StatusInternalServerError
+		return
+	}
+
+	//
+	// Read the body of the request.
+	//
+	content, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		status = http.StatusInternalServerError
+		return
+	}
+
+	report, err := submitReport(content)
+	if err == errDuplicateReport {
+		err = nil
+		fmt.Fprintf(res, "Ignoring duplicate submission")
+		return
+	}
+	if err != nil {
+		status = http.StatusInternalServerError
+		return
+	}
+
+	//
+	// Show something to the caller.
+	//
+	out := fmt.Sprintf("{\"host\":\"%s\"}", report.Fqdn)
+	fmt.Fprint(res, string(out))
+
+}
+
+//
+// SearchHandler is the handler for the HTTP end-point:
+//
+//	POST /search
+//
+// We perform a search for nodes matching a given pattern.  The comparison
+// is a regular substring-match, rather than a regular expression.
+//
+func SearchHandler(res http.ResponseWriter, req *http.Request) {
+	var (
+		status int
+		err    error
+	)
+	defer func() {
+		if nil != err {
+			http.Error(res, err.Error(), status)
+
+			// Don't spam stdout when running test-cases.
+			if flag.Lookup("test.v") == nil {
+				fmt.Printf("Error: %s\n", err.Error())
+			}
+		}
+	}()
+
+	//
+	// Ensure this was a POST-request
+	//
+	if req.Method != "POST" {
+		err = errors.New("must be called via HTTP-POST")
+		status = http.StatusInternalServerError
+		return
+	}
+
+	//
+	// Get the term from the form.
+	//
+	req.ParseForm()
+	term := req.FormValue("term")
+
+	//
+	// Ensure we have a term.
+	//
+	if len(term) < 1 {
+		err = errors.New("missing search term")
+		status = http.StatusInternalServerError
+		return
+	}
+
+	//
+	// Annoying struct to allow us to populate our template
+	// with both the matching nodes, and the term used for the search
+	//
+	type Pagedata struct {
+		Nodes     []PuppetRuns
+		Term      string
+		Urlprefix string
+	}
+
+	//
+	// Get all known nodes.
+	//
+	NodeList, err := getIndexNodes()
+	if err != nil {
+		status = http.StatusInternalServerError
+		return
+	}
+
+	//
+	// Populate this structure with the search-term
+	//
+	var x Pagedata
+	x.Term = term
+	x.Urlprefix = templateArgs.urlprefix
+
+	//
+	// Add in any nodes which match our term.
+	//
+	for _, o := range NodeList {
+		if strings.Contains(o.Fqdn, term) {
+			x.Nodes = append(x.Nodes, o)
+		}
+	}
+
+	//
+	// Load our template source.
+	//
+	tmpl, err := getResource("data/results.template")
+	if err != nil {
+		fmt.Fprint(res, err.Error())
+		return
+	}
+
+	//
+	//  Load our template, from the resource.
+	//
+	src := string(tmpl)
+	t := template.Must(template.New("tmpl").Parse(src))
+
+	//
+	// Execute the template into our buffer.
+	//
+	buf := &bytes.Buffer{}
+	err = t.Execute(buf, x)
+
+	//
+	// If there were errors, then show them.
+	if err != nil {
+		fmt.Fprint(res, err.Error())
+		return
+	}
+
+	//
+	// Otherwise write the result.
+	//
+	buf.WriteTo(res)
+}
+
+//
+// AckHandler is the handler for the HTTP end-point:
+//
+//	POST /acknowledge
+//
+// It acknowledges a failing node, given by `fqdn`, or every node failing
+// with the same `fingerprint`.  The `reason` and `user` are recorded,
+// and the acknowledgement may `expire` after a period such as "2d".
+//
+// It responds with the acknowledgement as JSON, if that was requested,
+// otherwise it redirects back to the node.
+//
+func AckHandler(res http.ResponseWriter, req *http.Request) {
+	var (
+		status int
+		err    error
+	)
+	defer func() {
+		if nil != err {
+			http.Error(res, err.Error(), status)
+
+			// Don't spam stdout when running test-cases.
+			if flag.Lookup("test.v") == nil {
+				fmt.Printf("Error: %s\n", err.Error())
+			}
+		}
+	}()
+
+	req.ParseForm()
+
+	var ack PuppetAck
+	ack.Fqdn = req.FormValue("fqdn")
+	ack.Fingerprint = req.FormValue("fingerprint")
+	ack.Reason = req.FormValue("reason")
+	ack.User = req.FormValue("user")
+	if len(ack.User) < 1 {
+		ack.User = req.Header.Get("X-Forwarded-User")
+	}
+
+	if len(req.FormValue("expire")) > 0 {
+		var period time.Duration
+		period, err = parsePeriod(req.FormValue("expire"))
+		if err != nil {
+			status = http.StatusBadRequest
+			return
+		}
+		ack.ExpiresAt = time.Now().Add(period).Unix()
+	}
+
+	//
+	// Only failing nodes may be acknowledged.
+	//
+	if len(ack.Fqdn) > 0 {
+		var node PuppetRuns
+		node, err = getNodeAck(ack.Fqdn)
+		if err != nil {
+			status = http.StatusInternalServerError
+			return
+		}
+		if node.State != "failed" {
+			status = http.StatusBadRequest
+			err = errors.New("only failing nodes may be acknowledged")
+			return
+		}
+	}
+
+	err = addAck(&ack)
+	if err != nil {
+		status = http.StatusBadRequest
+		return
+	}
+
+	accept := req.FormValue("accept")
+	if len(accept) < 1 {
+		accept = req.Header.Get("Accept")
+	}
+
+	if accept == "application/json" {
+		js, _ := json.Marshal(ack)
+		res.Header().Set("Content-Type", "application/json")
+		res.Write(js)
+		return
+	}
+
+	target := templateArgs.urlprefix + "/"
+	if len(ack.Fqdn) > 0 {
+		target = templateArgs.urlprefix + "/node/" + ack.Fqdn
+	}
+	http.Redirect(res, req, target, http.StatusFound)
+}
+
+//
+// UnackHandler is the handler for the HTTP end-point:
+//
+//	POST /unacknowledge
+//
+// It removes the acknowledgement with the given `id`, before it would
+// otherwise expire.
+//
+func UnackHandler(res http.ResponseWriter, req *http.Request) {
+	var (
+		status int
+		err    error
+	)
+	defer func() {
+		if nil != err {
+			http.Error(res, err.Error(), status)
+
+			// Don't spam stdout when running test-cases.
+			if flag.Lookup("test.v") == nil {
+				fmt.Printf("Error: %s\n", err.Error())
+			}
+		}
+	}()
+
+	req.ParseForm()
+
+	id, err := strconv.ParseInt(req.FormValue("id"), 10, 64)
+	if err != nil {
+		status = http.StatusBadRequest
+		err = errors.New("the 'id' parameter must be a number")
+		return
+	}
+
+	err = deleteAck(id)
+	if err != nil {
+		status = http.StatusInternalServerError
+		return
+	}
+
+	target := templateArgs.urlprefix + "/"
+	if len(req.FormValue("fqdn")) > 0 {
+		target = templateArgs.urlprefix + "/node/" + req.FormValue("fqdn")
+	}
+	http.Redirect(res, req, target, http.StatusFound)
+}
+
+//
+// ReportHandler is the handler for the HTTP end-point
+//
+//	 GET /report/NN
+//
+// It will respond in either HTML, JSON, or XML depending on the
+// Accepts-header which is received.
+//
+func ReportHandler(res http.ResponseWriter, req *http.Request) {
+	var (
+		status int
+		err    error
+	)
+	defer func() {
+		if nil != err {
+			http.Error(res, err.Error(), status)
+
+			// Don't spam stdout when running test-cases.
+			if flag.Lookup("test.v") == nil {
+				fmt.Printf("Error: %s\n", err.Error())
+			}
+		}
+	}()
+
+	//
+	// Get the node name we're going to show.
+	//
+	vars := mux.Vars(req)
+	id := vars["id"]
+
+	//
+	// Ensure we received a parameter.
+	//
+	if len(id) < 1 {
+		status = http.StatusNotFound
+		err = errors.New("missing 'id' parameter")
+		return
+	}
+
+	//
+	// If the ID is non-numeric we're in trouble.
+	//
+	reg, _ := regexp.Compile("^([0-9]+)$")
+	if !reg.MatchString(id) {
+		status = http.StatusInternalServerError
+		err = errors.New("the report ID must be numeric")
+		return
+	}
+
+	//
+	// Get the content.
+	//
+	content, err := getYAML(ReportPrefix, id)
+	if err != nil {
+		status = http.StatusInternalServerError
+		return
+	}
+
+	// need generic struct
+	type Pagedata struct {
+		Report    PuppetReport
+		Urlprefix string
+	}
+
+	//
+	// Parse it
+	//
+	report, err := ParsePuppetReport(content)
+	if err != nil {
+		status = http.StatusInternalServerError
+		return
+	}
+
+	var x Pagedata
+	x.Report = report
+	x.Urlprefix = templateArgs.urlprefix
+
+	//
+	// Accept either a "?accept=XXX" URL-parameter, or
+	// the Accept HEADER in the HTTP request
+	//
+	accept := req.FormValue("accept")
+	if len(accept) < 1 {
+		accept = req.Header.Get("Accept")
+	}
+
+	switch accept {
+	case "application/json":
+		js, err := json.Marshal(report)
+
+		if err != nil {
+			status = http.StatusInternalServerError
+			return
+		}
+		res.Header().Set("Content-Type", "application/json")
+		res.Write(js)
+
+	case "application/xml":
+		x, err := xml.MarshalIndent(report, "", "  ")
+		if err != nil {
+			status = http.StatusInternalServerError
+			return
+		}
+
+		res.Header().Set("Content-Type", "application/xml")
+		res.Write(x)
+	default:
+
+		//
+		// Load our template resource.
+		//
+		tmpl, err := getResource("data/report.template")
+		if err != nil {
+			fmt.Fprint(res, err.Error())
+			return
+		}
+
+		//
+		// Helper to allow a float to be truncated
+		// to two/three digits.
+		//
+		funcMap := template.FuncMap{
+
+			"truncate": func(s string) string {
+
+				//
+				// Parse as a float.
+				//
+				f, _ := strconv.ParseFloat(s, 64)
+
+				//
+				// Output to a truncated string
+				//
+				s = fmt.Sprintf("%.2f", f)
+				return s
+			},
+		}
+
+		//
+		//  Load our template, from the resource.
+		//
+		src := string(tmpl)
+		t := template.Must(template.New("tmpl").Funcs(funcMap).Parse(src))
+
+		//
+		// Execute the template into our buffer.
+		//
+		buf := &bytes.Buffer{}
+		err = t.Execute(buf, x)
+
+		//
+		// If there were errors, then show them.
+		if err != nil {
+			fmt.Fprint(res, err.Error())
+			return
+		}
+
+		//
+		// Otherwise write the result.
+		//
+		buf.WriteTo(res)
+	}
+}
+
+//
+// NodeHandler is the handler for the HTTP end-point
+//
+//	 GET /node/$FQDN
+//
+// It will respond in either HTML, JSON, or XML depending on the
+// Accepts-header which is received.
+//
+func NodeHandler(res http.ResponseWriter, req *http.Request) {
+	var (
+		status int
+		err    error
+	)
+	defer func() {
+		if nil != err {
+			http.Error(res, err.Error(), status)
+
+			// Don't spam stdout when running test-cases.
+			if flag.Lookup("test.v") == nil {
+				fmt.Printf("Error: %s\n", err.Error())
+			}
+		}
+	}()
+
+	//
+	// Get the node name we're going to show.
+	//
+	vars := mux.Vars(req)
+	fqdn := vars["fqdn"]
+
+	//
+	// Ensure we received a parameter.
+	//
+	if len(fqdn) < 1 {
+		status = http.StatusNotFound
+		err = errors.New("missing 'fqdn' parameter")
+		return
+	}
+
+	//
+	// Get the reports
+	//
+	reports, err := getReports(fqdn)
+
+	//
+	// Ensure that something was present.
+	//
+	if (reports == nil) || (len(reports) < 1) {
+		status = http.StatusNotFound
+		return
+	}
+
+	//
+	// Handle error(s)
+	//
+	if err != nil {
+		status = http.StatusInternalServerError
+		return
+	}
+
+	//
+	// Annoying struct to allow us to populate our template
+	// with both the reports and the fqdn of the host.
+	//
+	type Pagedata struct {
+		Fqdn      string
+		Nodes     []PuppetReportSummary
+		FlapScore int
+		Flapping  bool
+		Baseline  PuppetRuntime
+		TypeTimes []ResourceTime
+		Node      PuppetRuns
+		Markers   []ChartMarker
+		Urlprefix string
+	}
+
+	//
+	// Get the usual runtime of this node, and flag any runs which
+	// were unusually slow.
+	//
+	baseline, err := getNodeBaseline(fqdn, BaselineDays)
+	if err != nil {
+		status = http.StatusInternalServerError
+		return
+	}
+	for i := range reports {
+		runtime, _ := strconv.ParseFloat(reports[i].Runtime, 64)
+		reports[i].Slow = baseline.Runs > 0 && runtime > baseline.Median*SlowFactor
+	}
+
+	//
+	// Find the types of resources which dominate the runtime.
+	//
+	types, err := getNodeTypeTimes(fqdn, BaselineDays)
+	if err != nil {
+		status = http.StatusInternalServerError
+		return
+	}
+
+	//
+	// Find whether the node's failure has been acknowledged.
+	//
+	node, err := getNodeAck(fqdn)
+	if err != nil {
+		status = http.StatusInternalServerError
+		return
+	}
+
+	//
+	// Get the events to draw upon the runtime graph.
+	//
+	oldest, _ := time.ParseInLocation("2006-01-02 15:04:05", reports[len(reports)-1].At, time.Local)
+	events, err := getEvents(oldest.Unix(), "")
+	if err != nil {
+		status = http.StatusInternalServerError
+		return
+	}
+
+	//
+	// Populate this structure.
+	//
+	var x Pagedata
+	x.Nodes = reports
+	x.Markers = runMarkers(events, reports)
+	x.Fqdn = fqdn
+	x.Node = node
+	x.Baseline = baseline
+	x.TypeTimes = types
+	x.FlapScore = flapScore(reports, FlapWindow)
+	x.Flapping = x.FlapScore > 0 && x.FlapScore >= FlapThreshold
+	x.Urlprefix = templateArgs.urlprefix
+
+	//
+	// Accept either a "?accept=XXX" URL-parameter, or
+	// the Accept HEADER in the HTTP request
+	//
+	accept := req.FormValue("accept")
+	if len(accept) < 1 {
+		accept = req.Header.Get("Accept")
+	}
+
+	switch accept {
+	case "application/json":
+		js, err := json.Marshal(reports)
+
+		if err != nil {
+			status = http.StatusInternalServerError
+			return
+		}
+		res.Header().Set("Content-Type", "application/json")
+		res.Write(js)
+
+	case "application/xml":
+		x, err := xml.MarshalIndent(reports, "", "  ")
+		if err != nil {
+			status = http.StatusInternalServerError
+			return
+		}
+
+		res.Header().Set("Content-Type", "application/xml")
+		res.Write(x)
+	default:
+
+		//
+		// Load our template resource.
+		//
+		tmpl, err := getResource("data/node.template")
+		if err != nil {
+			fmt.Fprint(res, err.Error())
+			return
+		}
+
+		funcMap := template.FuncMap{
+
+			"incr": func(d int) string {
+
+				//
+				// Return the incremented string.
+				//
+				s := fmt.Sprintf("%d", (d + 1))
+				return s
+			},
+
+			"date": func(epoch int64) string {
+				return time.Unix(epoch, 0).Format("2006-01-02 15:04:05")
+			},
+		}
+
+		//
+		//  Load our template, from the resource.
+		//
+		src := string(tmpl)
+		t := template.Must(template.New("tmpl").Funcs(funcMap).Parse(src))
+
+		//
+		// Execute the template into our buffer.
+		//
+		buf := &bytes.Buffer{}
+		err = t.Execute(buf, x)
+
+		//
+		// If there were errors, then show them.
+		if err != nil {
+			fmt.Fprint(res, err.Error())
+			return
+		}
+
+		//
+		// Otherwise write the result.
+		//
+		buf.WriteTo(res)
+	}
+}
+
+//
+// TimelineHandler is the handler for the HTTP end-point
+//
+//	 GET /timeline/$FQDN
+//
+// It shows each change of state a node has made, along with its
+// reliability figures.
+//
+// It will respond in either HTML, JSON, or XML depending on the
+// Accepts-header which is received.
+//
+func TimelineHandler(res http.ResponseWriter, req *http.Request) {
+	var (
+		status int
+		err    error
+	)
+	defer func() {
+		if nil != err {
+			http.Error(res, err.Error(), status)
+
+			// Don't spam stdout when running test-cases.
+			if flag.Lookup("test.v") == nil {
+				fmt.Printf("Error: %s\n", err.Error())
+			}
+		}
+	}()
+
+	//
+	// Get the node name we're going to show.
+	//
+	vars := mux.Vars(req)
+	fqdn := vars["fqdn"]
+
+	//
+	// Ensure we received a parameter.
+	//
+	if len(fqdn) < 1 {
+		status = http.StatusNotFound
+		err = errors.New("missing 'fqdn' parameter")
+		return
+	}
+
+	//
+	// Get the transitions.
+	//
+	transitions, err := getTransitions(fqdn)
+	if err != nil {
+		status = http.StatusInternalServerError
+		return
+	}
+
+	if len(transitions) < 1 {
+		status = http.StatusNotFound
+		err = errors.New("Failed to find state changes for " + fqdn)
+		return
+	}
+
+	//
+	// Get the reliability of all nodes, and find this one.
+	//
+	stats, err := getReliability(false)
+	if err != nil {
+		status = http.StatusInternalServerError
+		return
+	}
+
+	//
+	// The data we return to the caller.
+	//
+	type Timeline struct {
+		Fqdn        string
+		Reliability PuppetReliability
+		Transitions []PuppetTransition
+	}
+
+	var data Timeline
+	data.Fqdn = fqdn
+	data.Transitions = transitions
+	for _, s := range stats {
+		if s.Name == fqdn {
+			data.Reliability = s
+		}
+	}
+
+	//
+	// Annoying struct to allow us to populate our template
+	// with both the data and the URL prefix.
+	//
+	type Pagedata struct {
+		Timeline
+		Urlprefix string
+	}
+
+	var x Pagedata
+	x.Timeline = data
+	x.Urlprefix = templateArgs.urlprefix
+
+	//
+	// Accept either a "?accept=XXX" URL-parameter, or
+	// the Accept HEADER in the HTTP request
+	//
+	accept := req.FormValue("accept")
+	if len(accept) < 1 {
+		accept = req.Header.Get("Accept")
+	}
+
+	switch accept {
+	case "application/json":
+		js, err := json.Marshal(data)
+
+		if err != nil {
+			status = http.StatusInternalServerError
+			return
+		}
+		res.Header().Set("Content-Type", "application/json")
+		res.Write(js)
+
+	case "application/xml":
+		x, err := xml.MarshalIndent(data, "", "  ")
+		if err != nil {
+			status = http.StatusInternalServerError
+			return
+		}
+
+		res.Header().Set("Content-Type", "application/xml")
+		res.Write(x)
+	default:
+
+		//
+		// Load our template resource.
+		//
+		tmpl, err := getResource("data/timeline.template")
+		if err != nil {
+			fmt.Fprint(res, err.Error())
+			return
+		}
+
+		//
+		//  Load our template, from the resource.
+		//
+		src := string(tmpl)
+		t := template.Must(template.New("tmpl").Funcs(template.FuncMap{"duration": timeDuration}).Parse(src))
+
+		//
+		// Execute the template into our buffer.
+		//
+		buf := &bytes.Buffer{}
+		err = t.Execute(buf, x)
+
+		//
+		// If there were errors, then show them.
+		if err != nil {
+			fmt.Fprint(res, err.Error())
+			return
+		}
+
+		//
+		// Otherwise write the result.
+		//
+		buf.WriteTo(res)
+	}
+}
+
+//
+// ReliabilityHandler is the handler for the HTTP end-point
+//
+//	 GET /reliability
+//
+// It shows the time-to-recover, failure frequency, and current
+// failure streaks of each role and each node.
+//
+// It will respond in either HTML, JSON, or XML depending on the
+// Accepts-header which is received.
+//
+func ReliabilityHandler(res http.ResponseWriter, req *http.Request) {
+	var (
+		status int
+		err    error
+	)
+	defer func() {
+		if nil != err {
+			http.Error(res, err.Error(), status)
+
+			// Don't spam stdout when running test-cases.
+			if flag.Lookup("test.v") == nil {
+				fmt.Printf("Error: %s\n", err.Error())
+			}
+		}
+	}()
+
+	//
+	// Get the figures for both roles and nodes.
+	//
+	roles, err := getReliability(true)
+	if err != nil {
+		status = http.StatusInternalServerError
+		return
+	}
+	nodes, err := getReliability(false)
+	if err != nil {
+		status = http.StatusInternalServerError
+		return
+	}
+
+	//
+	// The data we return to the caller.
+	//
+	type Reliability struct {
+		Roles []PuppetReliability
+		Nodes []PuppetReliability
+	}
+
+	var data Reliability
+	data.Roles = roles
+	data.Nodes = nodes
+
+	type Pagedata struct {
+		Reliability
+		Urlprefix string
+	}
+
+	var x Pagedata
+	x.Reliability = data
+	x.Urlprefix = templateArgs.urlprefix
+
+	//
+	// Accept either a "?accept=XXX" URL-parameter, or
+	// the Accept HEADER in the HTTP request
+	//
+	accept := req.FormValue("accept")
+	if len(accept) < 1 {
+		accept = req.Header.Get("Accept")
+	}
+
+	switch accept {
+	case "application/json":
+		js, err := json.Marshal(data)
+
+		if err != nil {
+			status = http.StatusInternalServerError
+			return
+		}
+		res.Header().Set("Content-Type", "application/json")
+		res.Write(js)
+
+	case "application/xml":
+		x, err := xml.MarshalIndent(data, "", "  ")
+		if err != nil {
+			status = http.StatusInternalServerError
+			return
+		}
+
+		res.Header().Set("Content-Type", "application/xml")
+		res.Write(x)
+	default:
+
+		//
+		// Load our template resource.
+		//
+		tmpl, err := getResource("data/reliability.template")
+		if err != nil {
+			fmt.Fprint(res, err.Error())
+			return
+		}
+
+		//
+		//  Load our template, from the resource.
+		//
+		src := string(tmpl)
+		t := template.Must(template.New("tmpl").Funcs(template.FuncMap{"duration": timeDuration}).Parse(src))
+
+		//
+		// Execute the template into our buffer.
+		//
+		buf := &bytes.Buffer{}
+		err = t.Execute(buf, x)
+
+		//
+		// If there were errors, then show them.
+		if err != nil {
+			fmt.Fprint(res, err.Error())
+			return
+		}
+
+		//
+		// Otherwise write the result.
+		//
+		buf.WriteTo(res)
+	}
+}
+
+//
+// IdempotencyHandler is the handler for the HTTP end-point
+//
+//	 GET /idempotency
+//
+// It lists the resources which are changed by most puppet-runs, which
+// suggests that they're not idempotent, ranked by the number of nodes
+// affected.  The `days` and `threshold` parameters control the window
+// examined, and the percentage of runs which must have changed.
+//
+// It will respond in either HTML, JSON, or XML depending on the
+// Accepts-header which is received.
+//
+func IdempotencyHandler(res http.ResponseWriter, req *http.Request) {
+	var (
+		status int
+		err    error
+	)
+	defer func() {
+		if nil != err {
+			http.Error(res, err.Error(), status)
+
+			// Don't spam stdout when running test-cases.
+			if flag.Lookup("test.v") == nil {
+				fmt.Printf("Error: %s\n", err.Error())
+			}
+		}
+	}()
+
+	//
+	// The data we return to the caller.
+	//
+	type Idempotency struct {
+		Days      int
+		Threshold int
+		Nodes     []PuppetNonIdempotent
+		Roles     []PuppetNonIdempotent
+	}
+
+	var data Idempotency
+	data.Days = 7
+	data.Threshold = 80
+
+	//
+	// Allow the defaults to be changed.
+	//
+	if len(req.FormValue("days")) > 0 {
+		data.Days, err = strconv.Atoi(req.FormValue("days"))
+		if err != nil || data.Days < 1 {
+			status = http.StatusInternalServerError
+			err = errors.New("the 'days' parameter must be a positive number")
+			return
+		}
+	}
+	if len(req.FormValue("threshold")) > 0 {
+		data.Threshold, err = strconv.Atoi(req.FormValue("threshold"))
+		if err != nil || data.Threshold < 0 || data.Threshold > 100 {
+			status = http.StatusInternalServerError
+			err = errors.New("the 'threshold' parameter must be a percentage")
+			return
+		}
+	}
+
+	data.Nodes, err = getNonIdempotent(data.Days, data.Threshold, false)
+	if err != nil {
+		status = http.StatusInternalServerError
+		return
+	}
+	data.Roles, err = getNonIdempotent(data.Days, data.Threshold, true)
+	if err != nil {
+		status = http.StatusInternalServerError
+		return
+	}
+
+	type Pagedata struct {
+		Idempotency
+		Urlprefix string
+	}
+
+	var x Pagedata
+	x.Idempotency = data
+	x.Urlprefix = templateArgs.urlprefix
+
+	//
+	// Accept either a "?accept=XXX" URL-parameter, or
+	// the Accept HEADER in the HTTP request
+	//
+	accept := req.FormValue("accept")
+	if len(accept) < 1 {
+		accept = req.Header.Get("Accept")
+	}
+
+	switch accept {
+	case "application/json":
+		js, err := json.Marshal(data)
+
+		if err != nil {
+			status = http.StatusInternalServerError
+			return
+		}
+		res.Header().Set("Content-Type", "application/json")
+		res.Write(js)
+
+	case "application/xml":
+		x, err := xml.MarshalIndent(data, "", "  ")
+		if err != nil {
+			status = http.StatusInternalServerError
+			return
+		}
+
+		res.Header().Set("Content-Type", "application/xml")
+		res.Write(x)
+	default:
+
+		//
+		// Load our template resource.
+		//
+		tmpl, err := getResource("data/idempotency.template")
+		if err != nil {
+			fmt.Fprint(res, err.Error())
+			return
+		}
+
+		funcMap := template.FuncMap{
+			"percent": func(f float64) string {
+				return fmt.Sprintf("%.0f%%", f)
+			},
+		}
+
+		//
+		//  Load our template, from the resource.
+		//
+		src := string(tmpl)
+		t := template.Must(template.New("tmpl").Funcs(funcMap).Parse(src))
+
+		//
+		// Execute the template into our buffer.
+		//
+		buf := &bytes.Buffer{}
+		err = t.Execute(buf, x)
+
+		//
+		// If there were errors, then show them.
+		if err != nil {
+			fmt.Fprint(res, err.Error())
+			return
+		}
+
+		//
+		// Otherwise write the result.
+		//
+		buf.WriteTo(res)
+	}
+}
+
+//
+// RolloutHandler is the handler for the HTTP end-point
+//
+//	 GET /rollout
+//
+// It shows how each git branch is rolling out across our nodes: the
+// nodes on each build, the percentage on the newest, the failure rate of
+// each build, and the nodes which are stuck on old builds.  The `days`
+// and `threshold` parameters control the number of days of runs
+// examined, and how long after a build is made a node is stuck without
+// it.
 //
 // It will respond in either HTML, JSON, or XML depending on the
 // Accepts-header which is received.
 //
-func RadiatorView(res http.ResponseWriter, req *http.Request) {
-
+func RolloutHandler(res http.ResponseWriter, req *http.Request) {
 	var (
 		status int
 		err    error
@@ -176,48 +1957,62 @@ func RadiatorView(res http.ResponseWriter, req *http.Request) {
 	defer func() {
 		if nil != err {
 			http.Error(res, err.Error(), status)
+
+			// Don't spam stdout when running test-cases.
+			if flag.Lookup("test.v") == nil {
+				fmt.Printf("Error: %s\n", err.Error())
+			}
 		}
 	}()
 
-	// anonymous struct
-	type Pagedata struct {
-		States    []PuppetState
-		Urlprefix string
+	//
+	// The data we return to the caller.
+	//
+	type Rollout struct {
+		Days      int
+		Threshold string
+		Branches  []PuppetRollout
 	}
 
+	var data Rollout
+	data.Days = 7
+	data.Threshold = "2h"
+
 	//
-	// Get the state of the nodes.
+	// Allow the defaults to be changed.
 	//
-	data, err := getStates()
+	if len(req.FormValue("days")) > 0 {
+		data.Days, err = strconv.Atoi(req.FormValue("days"))
+		if err != nil || data.Days < 1 {
+			status = http.StatusInternalServerError
+			err = errors.New("the 'days' parameter must be a positive number")
+			return
+		}
+	}
+	if len(req.FormValue("threshold")) > 0 {
+		data.Threshold = req.FormValue("threshold")
+	}
+	threshold, err := parsePeriod(data.Threshold)
 	if err != nil {
 		status = http.StatusInternalServerError
 		return
 	}
 
-	//
-	// Sum up our known-nodes.
-	//
-	total := 0
-	for i := range data {
-		total += data[i].Count
+	data.Branches, err = getRollout(data.Days, threshold)
+	if err != nil {
+		status = http.StatusInternalServerError
+		return
 	}
 
-	//
-	// Add in the total count of nodes.
-	//
-	var tmp PuppetState
-	tmp.State = "Total"
-	tmp.Count = total
-	tmp.Percentage = 0
-	data = append(data, tmp)
+	type Pagedata struct {
+		Rollout
+		Urlprefix string
+	}
 
-	// genereic template args
 	var x Pagedata
-	x.States = data
+	x.Rollout = data
 	x.Urlprefix = templateArgs.urlprefix
 
-	//
-	// What kind of reply should we send?
 	//
 	// Accept either a "?accept=XXX" URL-parameter, or
 	// the Accept HEADER in the HTTP request
@@ -248,20 +2043,30 @@ func RadiatorView(res http.ResponseWriter, req *http.Request) {
 		res.Header().Set("Content-Type", "application/xml")
 		res.Write(x)
 	default:
+
 		//
 		// Load our template resource.
 		//
-		tmpl, err := getResource("data/radiator.template")
+		tmpl, err := getResource("data/rollout.template")
 		if err != nil {
 			fmt.Fprint(res, err.Error())
 			return
 		}
 
+		funcMap := template.FuncMap{
+			"percent": func(f float64) string {
+				return fmt.Sprintf("%.0f%%", f)
+			},
+			"date": func(epoch int64) string {
+				return time.Unix(epoch, 0).Format("2006-01-02 15:04:05")
+			},
+		}
+
 		//
 		//  Load our template, from the resource.
 		//
 		src := string(tmpl)
-		t := template.Must(template.New("tmpl").Parse(src))
+		t := template.Must(template.New("tmpl").Funcs(funcMap).Parse(src))
 
 		//
 		// Execute the template into our buffer.
@@ -284,16 +2089,19 @@ func RadiatorView(res http.ResponseWriter, req *http.Request) {
 }
 
 //
-// ReportSubmissionHandler is the handler for the HTTP end-point:
+// StatsHandler is the handler for the HTTP end-point
 //
-//	POST /upload
+//	 GET /stats
 //
-// The input is read, and parsed as Yaml, and assuming that succeeds
-// then the data is written beneath ./reports/$hostname/$timestamp
-// and a summary-record is inserted into our SQLite database.
+// It compares the success, failure, and change rates of runs, along with
+// their runtime and the number of nodes, grouped by role, by git branch,
+// and by environment.  The `days` parameter controls the number of days
+// of runs examined.
 //
+// It will respond in either HTML, JSON, or XML depending on the
+// Accepts-header which is received.
 //
-func ReportSubmissionHandler(res http.ResponseWriter, req *http.Request) {
+func StatsHandler(res http.ResponseWriter, req *http.Request) {
 	var (
 		status int
 		err    error
@@ -310,91 +2118,143 @@ func ReportSubmissionHandler(res http.ResponseWriter, req *http.Request) {
 	}()
 
 	//
-	// Ensure this was a POST-request
+	// The data we return to the caller.
 	//
-	if req.Method != "POST" {
-		err = errors.New("must be called via HTTP-POST")
-		status = http.StatusInternalServerError
-		return
+	type Stats struct {
+		Days         int
+		Roles        []PuppetStats
+		Branches     []PuppetStats
+		Environments []PuppetStats
 	}
 
+	var data Stats
+	data.Days = 7
+
 	//
-	// Read the body of the request.
+	// Allow the default to be changed.
 	//
-	content, err := ioutil.ReadAll(req.Body)
+	if len(req.FormValue("days")) > 0 {
+		data.Days, err = strconv.Atoi(req.FormValue("days"))
+		if err != nil || data.Days < 1 {
+			status = http.StatusInternalServerError
+			err = errors.New("the 'days' parameter must be a positive number")
+			return
+		}
+	}
+
+	data.Roles, err = getStats(data.Days, "role")
 	if err != nil {
 		status = http.StatusInternalServerError
 		return
 	}
-
-	//
-	// Parse the YAML into something we can work with.
-	//
-	report, err := ParsePuppetReport(content)
+	data.Branches, err = getStats(data.Days, "branch")
+	if err != nil {
+		status = http.StatusInternalServerError
+		return
+	}
+	data.Environments, err = getStats(data.Days, "environment")
 	if err != nil {
 		status = http.StatusInternalServerError
 		return
 	}
 
+	type Pagedata struct {
+		Stats
+		Urlprefix string
+	}
+
+	var x Pagedata
+	x.Stats = data
+	x.Urlprefix = templateArgs.urlprefix
+
 	//
-	// Create a report directory for this host, unless it already exists.
+	// Accept either a "?accept=XXX" URL-parameter, or
+	// the Accept HEADER in the HTTP request
 	//
-	dir := filepath.Join(ReportPrefix, report.Fqdn)
-	if !Exists(dir) {
-		err = os.MkdirAll(dir, 0755)
+	accept := req.FormValue("accept")
+	if len(accept) < 1 {
+		accept = req.Header.Get("Accept")
+	}
+
+	switch accept {
+	case "application/json":
+		js, err := json.Marshal(data)
+
 		if err != nil {
 			status = http.StatusInternalServerError
 			return
 		}
-	}
+		res.Header().Set("Content-Type", "application/json")
+		res.Write(js)
 
-	//
-	// Does this report already exist?  This shouldn't happen
-	// in a usual setup, but will happen if you're repeatedly
-	// importing reports manually from a puppet-server.
-	//
-	// (Which is something you might do when testing the dashboard.)
-	//
-	path := filepath.Join(dir, report.Hash)
+	case "application/xml":
+		x, err := xml.MarshalIndent(data, "", "  ")
+		if err != nil {
+			status = http.StatusInternalServerError
+			return
+		}
 
-	if Exists(path) {
-		fmt.Fprintf(res, "Ignoring duplicate submission")
-		return
-	}
+		res.Header().Set("Content-Type", "application/xml")
+		res.Write(x)
+	default:
 
-	//
-	// Create the new report-file, on-disk.
-	//
-	err = ioutil.WriteFile(path, content, 0644)
-	if err != nil {
-		status = http.StatusInternalServerError
-		return
-	}
+		//
+		// Load our template resource.
+		//
+		tmpl, err := getResource("data/stats.template")
+		if err != nil {
+			fmt.Fprint(res, err.Error())
+			return
+		}
 
-	//
-	// Record that report in our SQLite database
-	//
-	relativePath := filepath.Join(report.Fqdn, report.Hash)
+		funcMap := template.FuncMap{
+			"percent": func(f float64) string {
+				return fmt.Sprintf("%.0f%%", f)
+			},
+			"seconds": func(f float64) string {
+				return fmt.Sprintf("%.1fs", f)
+			},
+		}
 
-	addDB(report, relativePath)
+		//
+		//  Load our template, from the resource.
+		//
+		src := string(tmpl)
+		t := template.Must(template.New("tmpl").Funcs(funcMap).Parse(src))
 
-	//
-	// Show something to the caller.
-	//
-	out := fmt.Sprintf("{\"host\":\"%s\"}", report.Fqdn)
-	fmt.Fprint(res, string(out))
+		//
+		// Execute the template into our buffer.
+		//
+		buf := &bytes.Buffer{}
+		err = t.Execute(buf, x)
 
+		//
+		// If there were errors, then show them.
+		if err != nil {
+			fmt.Fprint(res, err.Error())
+			return
+		}
+
+		//
+		// Otherwise write the result.
+		//
+		buf.WriteTo(res)
+	}
 }
 
 //
-// SearchHandler is the handler for the HTTP end-point:
+// SlowestHandler is the handler for the HTTP end-point
 //
-//	POST /search
+//	 GET /slowest
 //
-// We perform a search for nodes matching a given pattern.  The comparison
-// is a regular substring-match, rather than a regular expression.
+// It lists the nodes, and roles, with the longest usual runtimes, along
+// with those nodes whose most recent run was unusually slow.  The `days`
+// parameter controls the number of days of runs examined.
 //
-func SearchHandler(res http.ResponseWriter, req *http.Request) {
+// It will respond in either HTML, JSON, or XML depending on the
+// Accepts-header which is received.
+//
+func SlowestHandler(res http.ResponseWriter, req *http.Request) {
 	var (
 		status int
 		err    error
@@ -411,107 +2271,132 @@ func SearchHandler(res http.ResponseWriter, req *http.Request) {
 	}()
 
 	//
-	// Ensure this was a POST-request
+	// The data we return to the caller.
 	//
-	if req.Method != "POST" {
-		err = errors.New("must be called via HTTP-POST")
-		status = http.StatusInternalServerError
-		return
+	type Slowest struct {
+		Days   int
+		Factor float64
+		Nodes  []PuppetRuntime
+		Roles  []PuppetRuntime
 	}
 
-	//
-	// Get the term from the form.
-	//
-	req.ParseForm()
-	term := req.FormValue("term")
+	var data Slowest
+	data.Days = BaselineDays
+	data.Factor = SlowFactor
 
-	//
-	// Ensure we have a term.
-	//
-	if len(term) < 1 {
-		err = errors.New("missing search term")
-		status = http.StatusInternalServerError
-		return
+	if len(req.FormValue("days")) > 0 {
+		data.Days, err = strconv.Atoi(req.FormValue("days"))
+		if err != nil || data.Days < 1 {
+			status = http.StatusInternalServerError
+			err = errors.New("the 'days' parameter must be a positive number")
+			return
+		}
 	}
 
-	//
-	// Annoying struct to allow us to populate our template
-	// with both the matching nodes, and the term used for the search
-	//
-	type Pagedata struct {
-		Nodes     []PuppetRuns
-		Term      string
-		Urlprefix string
+	data.Nodes, err = getRuntimeBaselines(data.Days, false)
+	if err != nil {
+		status = http.StatusInternalServerError
+		return
 	}
-
-	//
-	// Get all known nodes.
-	//
-	NodeList, err := getIndexNodes()
+	data.Roles, err = getRuntimeBaselines(data.Days, true)
 	if err != nil {
 		status = http.StatusInternalServerError
 		return
 	}
 
-	//
-	// Populate this structure with the search-term
-	//
+	type Pagedata struct {
+		Slowest
+		Urlprefix string
+	}
+
 	var x Pagedata
-	x.Term = term
+	x.Slowest = data
 	x.Urlprefix = templateArgs.urlprefix
 
 	//
-	// Add in any nodes which match our term.
+	// Accept either a "?accept=XXX" URL-parameter, or
+	// the Accept HEADER in the HTTP request
 	//
-	for _, o := range NodeList {
-		if strings.Contains(o.Fqdn, term) {
-			x.Nodes = append(x.Nodes, o)
-		}
+	accept := req.FormValue("accept")
+	if len(accept) < 1 {
+		accept = req.Header.Get("Accept")
 	}
 
-	//
-	// Load our template source.
-	//
-	tmpl, err := getResource("data/results.template")
-	if err != nil {
-		fmt.Fprint(res, err.Error())
-		return
-	}
+	switch accept {
+	case "application/json":
+		js, err := json.Marshal(data)
 
-	//
-	//  Load our template, from the resource.
-	//
-	src := string(tmpl)
-	t := template.Must(template.New("tmpl").Parse(src))
+		if err != nil {
+			status = http.StatusInternalServerError
+			return
+		}
+		res.Header().Set("Content-Type", "application/json")
+		res.Write(js)
 
-	//
-	// Execute the template into our buffer.
-	//
-	buf := &bytes.Buffer{}
-	err = t.Execute(buf, x)
+	case "application/xml":
+		x, err := xml.MarshalIndent(data, "", "  ")
+		if err != nil {
+			status = http.StatusInternalServerError
+			return
+		}
 
-	//
-	// If there were errors, then show them.
-	if err != nil {
-		fmt.Fprint(res, err.Error())
-		return
-	}
+		res.Header().Set("Content-Type", "application/xml")
+		res.Write(x)
+	default:
 
-	//
-	// Otherwise write the result.
-	//
-	buf.WriteTo(res)
+		//
+		// Load our template resource.
+		//
+		tmpl, err := getResource("data/slowest.template")
+		if err != nil {
+			fmt.Fprint(res, err.Error())
+			return
+		}
+
+		funcMap := template.FuncMap{
+			"seconds": func(f float64) string {
+				return fmt.Sprintf("%.2f", f)
+			},
+		}
+
+		//
+		//  Load our template, from the resource.
+		//
+		src := string(tmpl)
+		t := template.Must(template.New("tmpl").Funcs(funcMap).Parse(src))
+
+		//
+		// Execute the template into our buffer.
+		//
+		buf := &bytes.Buffer{}
+		err = t.Execute(buf, x)
+
+		//
+		// If there were errors, then show them.
+		if err != nil {
+			fmt.Fprint(res, err.Error())
+			return
+		}
+
+		//
+		// Otherwise write the result.
+		//
+		buf.WriteTo(res)
+	}
 }
 
 //
-// ReportHandler is the handler for the HTTP end-point
+// AlertsHandler is the handler for the HTTP end-point
 //
-//	 GET /report/NN
+//	 GET /alerts
+//
+// It lists the alerts which are pending, or firing, along with those
+// resolved in the past day.
 //
 // It will respond in either HTML, JSON, or XML depending on the
 // Accepts-header which is received.
 //
-func ReportHandler(res http.ResponseWriter, req *http.Request) {
+func AlertsHandler(res http.ResponseWriter, req *http.Request) {
 	var (
 		status int
 		err    error
@@ -528,56 +2413,37 @@ func ReportHandler(res http.ResponseWriter, req *http.Request) {
 	}()
 
 	//
-	// Get the node name we're going to show.
-	//
-	vars := mux.Vars(req)
-	id := vars["id"]
-
-	//
-	// Ensure we received a parameter.
+	// The data we return to the caller.
 	//
-	if len(id) < 1 {
-		status = http.StatusNotFound
-		err = errors.New("missing 'id' parameter")
-		return
+	type Alerts struct {
+		Rules    int
+		Active   []PuppetAlert
+		Resolved []PuppetAlert
 	}
 
-	//
-	// If the ID is non-numeric we're in trouble.
-	//
-	reg, _ := regexp.Compile("^([0-9]+)$")
-	if !reg.MatchString(id) {
-		status = http.StatusInternalServerError
-		err = errors.New("the report ID must be numeric")
-		return
-	}
+	var data Alerts
+	data.Rules = len(AlertRules)
 
-	//
-	// Get the content.
-	//
-	content, err := getYAML(ReportPrefix, id)
+	alerts, err := getAlerts(time.Now().Unix() - 24*60*60)
 	if err != nil {
 		status = http.StatusInternalServerError
 		return
 	}
+	for _, alert := range alerts {
+		if alert.State == "resolved" {
+			data.Resolved = append(data.Resolved, alert)
+		} else {
+			data.Active = append(data.Active, alert)
+		}
+	}
 
-	// need generic struct
 	type Pagedata struct {
-		Report    PuppetReport
+		Alerts
 		Urlprefix string
 	}
 
-	//
-	// Parse it
-	//
-	report, err := ParsePuppetReport(content)
-	if err != nil {
-		status = http.StatusInternalServerError
-		return
-	}
-
 	var x Pagedata
-	x.Report = report
+	x.Alerts = data
 	x.Urlprefix = templateArgs.urlprefix
 
 	//
@@ -591,7 +2457,7 @@ func ReportHandler(res http.ResponseWriter, req *http.Request) {
 
 	switch accept {
 	case "application/json":
-		js, err := json.Marshal(report)
+		js, err := json.Marshal(data)
 
 		if err != nil {
 			status = http.StatusInternalServerError
@@ -601,7 +2467,7 @@ func ReportHandler(res http.ResponseWriter, req *http.Request) {
 		res.Write(js)
 
 	case "application/xml":
-		x, err := xml.MarshalIndent(report, "", "  ")
+		x, err := xml.MarshalIndent(data, "", "  ")
 		if err != nil {
 			status = http.StatusInternalServerError
 			return
@@ -614,30 +2480,15 @@ func ReportHandler(res http.ResponseWriter, req *http.Request) {
 		//
 		// Load our template resource.
 		//
-		tmpl, err := getResource("data/report.template")
+		tmpl, err := getResource("data/alerts.template")
 		if err != nil {
 			fmt.Fprint(res, err.Error())
 			return
 		}
 
-		//
-		// Helper to allow a float to be truncated
-		// to two/three digits.
-		//
 		funcMap := template.FuncMap{
-
-			"truncate": func(s string) string {
-
-				//
-				// Parse as a float.
-				//
-				f, _ := strconv.ParseFloat(s, 64)
-
-				//
-				// Output to a truncated string
-				//
-				s = fmt.Sprintf("%.2f", f)
-				return s
+			"date": func(epoch int64) string {
+				return time.Unix(epoch, 0).Format("2006-01-02 15:04:05")
 			},
 		}
 
@@ -668,14 +2519,16 @@ func ReportHandler(res http.ResponseWriter, req *http.Request) {
 }
 
 //
-// NodeHandler is the handler for the HTTP end-point
+// MaintenanceHandler is the handler for the HTTP end-point
 //
-//	 GET /node/$FQDN
+//	 GET /maintenance
+//
+// It lists our maintenance windows, along with a form to add more.
 //
 // It will respond in either HTML, JSON, or XML depending on the
 // Accepts-header which is received.
 //
-func NodeHandler(res http.ResponseWriter, req *http.Request) {
+func MaintenanceHandler(res http.ResponseWriter, req *http.Request) {
 	var (
 		status int
 		err    error
@@ -687,62 +2540,23 @@ func NodeHandler(res http.ResponseWriter, req *http.Request) {
 			// Don't spam stdout when running test-cases.
 			if flag.Lookup("test.v") == nil {
 				fmt.Printf("Error: %s\n", err.Error())
-			}
-		}
-	}()
-
-	//
-	// Get the node name we're going to show.
-	//
-	vars := mux.Vars(req)
-	fqdn := vars["fqdn"]
-
-	//
-	// Ensure we received a parameter.
-	//
-	if len(fqdn) < 1 {
-		status = http.StatusNotFound
-		err = errors.New("missing 'fqdn' parameter")
-		return
-	}
-
-	//
-	// Get the reports
-	//
-	reports, err := getReports(fqdn)
-
-	//
-	// Ensure that something was present.
-	//
-	if (reports == nil) || (len(reports) < 1) {
-		status = http.StatusNotFound
-		return
-	}
+			}
+		}
+	}()
 
-	//
-	// Handle error(s)
-	//
+	windows, err := getMaintenance()
 	if err != nil {
 		status = http.StatusInternalServerError
 		return
 	}
 
-	//
-	// Annoying struct to allow us to populate our template
-	// with both the reports and the fqdn of the host.
-	//
 	type Pagedata struct {
-		Fqdn      string
-		Nodes     []PuppetReportSummary
+		Windows   []PuppetMaintenance
 		Urlprefix string
 	}
 
-	//
-	// Populate this structure.
-	//
 	var x Pagedata
-	x.Nodes = reports
-	x.Fqdn = fqdn
+	x.Windows = windows
 	x.Urlprefix = templateArgs.urlprefix
 
 	//
@@ -756,7 +2570,7 @@ func NodeHandler(res http.ResponseWriter, req *http.Request) {
 
 	switch accept {
 	case "application/json":
-		js, err := json.Marshal(reports)
+		js, err := json.Marshal(windows)
 
 		if err != nil {
 			status = http.StatusInternalServerError
@@ -766,7 +2580,7 @@ func NodeHandler(res http.ResponseWriter, req *http.Request) {
 		res.Write(js)
 
 	case "application/xml":
-		x, err := xml.MarshalIndent(reports, "", "  ")
+		x, err := xml.MarshalIndent(windows, "", "  ")
 		if err != nil {
 			status = http.StatusInternalServerError
 			return
@@ -779,29 +2593,17 @@ func NodeHandler(res http.ResponseWriter, req *http.Request) {
 		//
 		// Load our template resource.
 		//
-		tmpl, err := getResource("data/node.template")
+		tmpl, err := getResource("data/maintenance.template")
 		if err != nil {
 			fmt.Fprint(res, err.Error())
 			return
 		}
 
-		funcMap := template.FuncMap{
-
-			"incr": func(d int) string {
-
-				//
-				// Return the incremented string.
-				//
-				s := fmt.Sprintf("%d", (d + 1))
-				return s
-			},
-		}
-
 		//
 		//  Load our template, from the resource.
 		//
 		src := string(tmpl)
-		t := template.Must(template.New("tmpl").Funcs(funcMap).Parse(src))
+		t := template.Must(template.New("tmpl").Parse(src))
 
 		//
 		// Execute the template into our buffer.
@@ -823,6 +2625,126 @@ func NodeHandler(res http.ResponseWriter, req *http.Request) {
 	}
 }
 
+//
+// MaintenanceAddHandler is the handler for the HTTP end-point
+//
+//	 POST /maintenance
+//
+// It adds a maintenance window, covering the nodes which match the `fqdn`
+// pattern, or have the given `role`.  One-off windows are given by their
+// `start` and `end`, while recurring windows have a cron-style `schedule`
+// and a `duration` such as "4h".
+//
+func MaintenanceAddHandler(res http.ResponseWriter, req *http.Request) {
+	var (
+		status int
+		err    error
+	)
+	defer func() {
+		if nil != err {
+			http.Error(res, err.Error(), status)
+
+			// Don't spam stdout when running test-cases.
+			if flag.Lookup("test.v") == nil {
+				fmt.Printf("Error: %s\n", err.Error())
+			}
+		}
+	}()
+
+	req.ParseForm()
+
+	var window PuppetMaintenance
+	window.Name = req.FormValue("name")
+	window.Fqdn = req.FormValue("fqdn")
+	window.Role = req.FormValue("role")
+	window.Schedule = req.FormValue("schedule")
+	window.Reason = req.FormValue("reason")
+
+	status = http.StatusBadRequest
+
+	if len(window.Schedule) > 0 {
+		var duration time.Duration
+		duration, err = parsePeriod(req.FormValue("duration"))
+		if err != nil {
+			return
+		}
+		window.Duration = int64(duration.Seconds())
+	} else {
+		window.StartsAt, err = parseFormTime(req.FormValue("start"))
+		if err != nil {
+			return
+		}
+		window.EndsAt, err = parseFormTime(req.FormValue("end"))
+		if err != nil {
+			return
+		}
+	}
+
+	err = validateMaintenance(window)
+	if err != nil {
+		return
+	}
+
+	err = addMaintenance(&window)
+	if err != nil {
+		status = http.StatusInternalServerError
+		return
+	}
+
+	accept := req.FormValue("accept")
+	if len(accept) < 1 {
+		accept = req.Header.Get("Accept")
+	}
+
+	if accept == "application/json" {
+		js, _ := json.Marshal(window)
+		res.Header().Set("Content-Type", "application/json")
+		res.Write(js)
+		return
+	}
+	http.Redirect(res, req, templateArgs.urlprefix+"/maintenance", http.StatusFound)
+}
+
+//
+// MaintenanceDeleteHandler is the handler for the HTTP end-point
+//
+//	 POST /maintenance/delete
+//
+// It removes the maintenance window with the given `id`.
+//
+func MaintenanceDeleteHandler(res http.ResponseWriter, req *http.Request) {
+	var (
+		status int
+		err    error
+	)
+	defer func() {
+		if nil != err {
+			http.Error(res, err.Error(), status)
+
+			// Don't spam stdout when running test-cases.
+			if flag.Lookup("test.v") == nil {
+				fmt.Printf("Error: %s\n", err.Error())
+			}
+		}
+	}()
+
+	req.ParseForm()
+
+	id, err := strconv.ParseInt(req.FormValue("id"), 10, 64)
+	if err != nil {
+		status = http.StatusBadRequest
+		err = errors.New("the 'id' parameter must be a number")
+		return
+	}
+
+	err = deleteMaintenance(id)
+	if err != nil {
+		status = http.StatusInternalServerError
+		return
+	}
+	http.Redirect(res, req, templateArgs.urlprefix+"/maintenance", http.StatusFound)
+}
+
 //
 // IconHandler is the handler for the HTTP end-point
 //
@@ -910,6 +2832,49 @@ func JavascriptPath(res http.ResponseWriter, req *http.Request) {
 // Accepts-header which is received.
 //
 func IndexHandler(res http.ResponseWriter, req *http.Request) {
+	serveIndex(res, req, "", "")
+}
+
+//
+// SliceHandler is the handler for the HTTP end-points
+//
+//	 GET /role/$role
+//	 GET /branch/$branch
+//
+// It shows the same view as our index, restricted to the nodes with the
+// given role, or on the given branch, along with their own history.
+//
+func SliceHandler(res http.ResponseWriter, req *http.Request) {
+	dimension, name := sliceVars(req)
+	serveIndex(res, req, dimension, name)
+}
+
+//
+// Find the role, or branch, a request is restricted to.
+//
+func sliceVars(req *http.Request) (string, string) {
+	vars := mux.Vars(req)
+	if role, ok := vars["role"]; ok {
+		return "role", role
+	}
+	return "branch", vars["branch"]
+}
+
+//
+// The path of the pages for the given role, or branch.
+//
+func sliceBase(dimension string, name string) string {
+	if len(dimension) < 1 {
+		return ""
+	}
+	return "/" + dimension + "/" + url.PathEscape(name)
+}
+
+//
+// Show our index, either of all nodes, or of those with the given role,
+// or on the given branch.
+//
+func serveIndex(res http.ResponseWriter, req *http.Request, dimension string, name string) {
 	var (
 		status int
 		err    error
@@ -930,24 +2895,62 @@ func IndexHandler(res http.ResponseWriter, req *http.Request) {
 	// with both the nodes in the list, and the graph-data
 	//
 	type Pagedata struct {
-		Graph     []PuppetHistory
-		Nodes     []PuppetRuns
-		Urlprefix string
+		Graph       []PuppetRollup
+		Markers     []ChartMarker
+		Nodes       []PuppetRuns
+		Resolution  HistoryResolution
+		Resolutions []HistoryResolution
+		Range       string
+		CountNodes  bool
+		Zoomed      bool
+		Dimension   string
+		Name        string
+		Base        string
+		Urlprefix   string
 	}
 
 	//
-	// Get the nodes to show on our front-page
+	// Work out the window of history to graph.
 	//
-	NodeList, err := getIndexNodes()
+	resolution, from, to, err := historyWindow(req.FormValue("resolution"), req.FormValue("range"), req.FormValue("from"), req.FormValue("to"))
+	if err != nil {
+		status = http.StatusBadRequest
+		return
+	}
+
+	//
+	// Get the nodes to show.
+	//
+	var NodeList []PuppetRuns
+	if len(dimension) > 0 {
+		NodeList, err = getSliceNodes(dimension, name)
+	} else {
+		NodeList, err = getIndexNodes()
+	}
+	if err != nil {
+		status = http.StatusInternalServerError
+		return
+	}
+
+	//
+	// Get the graph-data.
+	//
+	graphs, err := getRollups(resolution, dimension, name, from, to)
 	if err != nil {
 		status = http.StatusInternalServerError
 		return
 	}
+	if len(dimension) > 0 && len(NodeList) < 1 && len(graphs) < 1 {
+		status = http.StatusNotFound
+		err = fmt.Errorf("no nodes were found with %s '%s'", dimension, name)
+		return
+	}
+	graphs = fillRollups(graphs, resolution, from, to)
 
 	//
-	// Get the graph-data
+	// Get the events to draw upon the graph.
 	//
-	graphs, err := getHistory()
+	events, err := getEvents(from, "")
 	if err != nil {
 		status = http.StatusInternalServerError
 		return
@@ -958,7 +2961,19 @@ func IndexHandler(res http.ResponseWriter, req *http.Request) {
 	//
 	var x Pagedata
 	x.Graph = graphs
+	x.Markers = historyMarkers(events, graphs, resolution)
 	x.Nodes = NodeList
+	x.Resolution = resolution
+	x.Resolutions = HistoryResolutions
+	x.Range = req.FormValue("range")
+	if len(x.Range) < 1 {
+		x.Range = resolution.Range
+	}
+	x.CountNodes = req.FormValue("count") == "nodes"
+	x.Zoomed = len(req.FormValue("from")) > 0
+	x.Dimension = dimension
+	x.Name = name
+	x.Base = sliceBase(dimension, name)
 	x.Urlprefix = templateArgs.urlprefix
 
 	//
@@ -1038,6 +3053,33 @@ func serve(settings serveCmd) {
 	//
 	ReportPrefix = settings.prefix
 
+	//
+	// Setup our flap-detection
+	//
+	FlapWindow = settings.flapWindow
+	FlapThreshold = settings.flapThreshold
+	SuppressFlapping = settings.suppressFlapping
+
+	//
+	// Setup our runtime baselines
+	//
+	BaselineDays = settings.baselineDays
+	SlowFactor = settings.slowFactor
+
+	//
+	// Limit the series we'll export to Prometheus
+	//
+	MetricsMaxSeries = settings.metricsMaxSeries
+
+	//
+	// Ingest the reports written into our spool-directory, if any, now
+	// the settings they're recorded with are in place.
+	//
+	if settings.watchDir != "" {
+		s := &spool{path: settings.watchDir, pattern: settings.watchPattern}
+		go s.run(settings.watchInterval, nil)
+	}
+
 	//
 	// Create a new router and our route-mappings.
 	//
@@ -1048,6 +3090,24 @@ func serve(settings serveCmd) {
 	//
 	router.HandleFunc("/api/state/{state}/", APIState).Methods("GET")
 	router.HandleFunc("/api/state/{state}", APIState).Methods("GET")
+	router.HandleFunc("/api/flapping/", APIFlapping).Methods("GET")
+	router.HandleFunc("/api/flapping", APIFlapping).Methods("GET")
+	router.HandleFunc("/api/v1/events/", APIEvents).Methods("GET")
+	router.HandleFunc("/api/v1/events", APIEvents).Methods("GET")
+	router.HandleFunc("/api/v1/events/", APIAddEvent).Methods("POST")
+	router.HandleFunc("/api/v1/events", APIAddEvent).Methods("POST")
+	router.HandleFunc("/api/v1/history/", APIHistory).Methods("GET")
+	router.HandleFunc("/api/v1/history", APIHistory).Methods("GET")
+
+	//
+	// Prometheus metrics
+	//
+	router.HandleFunc("/metrics", MetricsHandler).Methods("GET")
+
+	//
+	// Health-checks
+	//
+	router.HandleFunc("/health", HealthHandler).Methods("GET")
 
 	//
 	//
@@ -1055,6 +3115,18 @@ func serve(settings serveCmd) {
 	router.HandleFunc("/radiator/", RadiatorView).Methods("GET")
 	router.HandleFunc("/radiator", RadiatorView).Methods("GET")
 
+	//
+	// Show the nodes with a given role, or on a given branch.
+	//
+	router.HandleFunc("/role/{role}/radiator/", SliceRadiatorView).Methods("GET")
+	router.HandleFunc("/role/{role}/radiator", SliceRadiatorView).Methods("GET")
+	router.HandleFunc("/role/{role}/", SliceHandler).Methods("GET")
+	router.HandleFunc("/role/{role}", SliceHandler).Methods("GET")
+	router.HandleFunc("/branch/{branch:.+}/radiator/", SliceRadiatorView).Methods("GET")
+	router.HandleFunc("/branch/{branch:.+}/radiator", SliceRadiatorView).Methods("GET")
+	router.HandleFunc("/branch/{branch:.+}/", SliceHandler).Methods("GET")
+	router.HandleFunc("/branch/{branch:.+}", SliceHandler).Methods("GET")
+
 	//
 	// Upload a new report.
 	//
@@ -1067,12 +3139,71 @@ func serve(settings serveCmd) {
 	router.HandleFunc("/search/", SearchHandler).Methods("POST")
 	router.HandleFunc("/search", SearchHandler).Methods("POST")
 
+	//
+	// Acknowledge failing nodes.
+	//
+	router.HandleFunc("/acknowledge/", AckHandler).Methods("POST")
+	router.HandleFunc("/acknowledge", AckHandler).Methods("POST")
+	router.HandleFunc("/unacknowledge/", UnackHandler).Methods("POST")
+	router.HandleFunc("/unacknowledge", UnackHandler).Methods("POST")
+
 	//
 	// Show the recent state of a node.
 	//
 	router.HandleFunc("/node/{fqdn}/", NodeHandler).Methods("GET")
 	router.HandleFunc("/node/{fqdn}", NodeHandler).Methods("GET")
 
+	//
+	// Show the state-changes of a node.
+	//
+	router.HandleFunc("/timeline/{fqdn}/", TimelineHandler).Methods("GET")
+	router.HandleFunc("/timeline/{fqdn}", TimelineHandler).Methods("GET")
+
+	//
+	// Show the reliability of roles and nodes.
+	//
+	router.HandleFunc("/reliability/", ReliabilityHandler).Methods("GET")
+	router.HandleFunc("/reliability", ReliabilityHandler).Methods("GET")
+
+	//
+	// Show resources which change on every run.
+	//
+	router.HandleFunc("/idempotency/", IdempotencyHandler).Methods("GET")
+	router.HandleFunc("/idempotency", IdempotencyHandler).Methods("GET")
+
+	//
+	// Show the nodes which take the longest to run.
+	//
+	router.HandleFunc("/slowest/", SlowestHandler).Methods("GET")
+	router.HandleFunc("/slowest", SlowestHandler).Methods("GET")
+
+	//
+	// Follow the rollout of each branch's builds.
+	//
+	router.HandleFunc("/rollout/", RolloutHandler).Methods("GET")
+	router.HandleFunc("/rollout", RolloutHandler).Methods("GET")
+
+	//
+	// Compare roles, branches, and environments.
+	//
+	router.HandleFunc("/stats/", StatsHandler).Methods("GET")
+	router.HandleFunc("/stats", StatsHandler).Methods("GET")
+
+	//
+	// Show the alerts raised by our rules.
+	//
+	router.HandleFunc("/alerts/", AlertsHandler).Methods("GET")
+	router.HandleFunc("/alerts", AlertsHandler).Methods("GET")
+
+	//
+	// Manage maintenance windows.
+	//
+	router.HandleFunc("/maintenance/", MaintenanceHandler).Methods("GET")
+	router.HandleFunc("/maintenance", MaintenanceHandler).Methods("GET")
+	router.HandleFunc("/maintenance/", MaintenanceAddHandler).Methods("POST")
+	router.HandleFunc("/maintenance", MaintenanceAddHandler).Methods("POST")
+	router.HandleFunc("/maintenance/delete", MaintenanceDeleteHandler).Methods("POST")
+
 	//
 	// Show "everything" about a given run.
 	//
@@ -1131,15 +3262,49 @@ func serve(settings serveCmd) {
 // The options set by our command-line flags.
 //
 type serveCmd struct {
-	autoPrune    bool
-	bindHost     string
-	bindPort     int
-	readTimeout  int
-	writeTimeout int
-	dbFile       string
-	dbType       string
-	prefix       string
-	urlprefix    string
+	autoPrune        bool
+	digest           bool
+	baselineDays     int
+	bindHost         string
+	bindPort         int
+	emailInterval    time.Duration
+	flapThreshold    int
+	flapWindow       int
+	historyDaily     string
+	historyHourly    string
+	lateRuns         int
+	metricsInterval  time.Duration
+	metricsMaxSeries int
+	metricsPort      int
+	metricsRetries   int
+	metricsTimeout   time.Duration
+	readTimeout      int
+	rulesInterval    time.Duration
+	slowFactor       float64
+	suppressFlapping bool
+	smtpPort         int
+	writeTimeout     int
+	dbFile           string
+	dbType           string
+	email            string
+	emailFrom        string
+	metricsDatabase  string
+	metricsGroups    string
+	metricsHost      string
+	metricsOutput    string
+	metricsPrefix    string
+	prefix           string
+	rules            string
+	smtpHost         string
+	smtpPassword     string
+	smtpUser         string
+	urlprefix        string
+	watchDir         string
+	watchInterval    time.Duration
+	watchPattern     string
+	webhookStates    string
+	webhookTemplate  string
+	webhooks         string
 }
 
 type templateOptions struct {
@@ -1167,11 +3332,45 @@ func (p *serveCmd) SetFlags(f *flag.FlagSet) {
 	f.IntVar(&p.readTimeout, "read-timeout", 5, "Timeout from when the connection is accepted to when the request body is fully read")
 	f.IntVar(&p.writeTimeout, "write-timeout", 10, "Timeout from the end of the request header read to the end of the response write")
 	f.BoolVar(&p.autoPrune, "auto-prune", false, "Prune reports automatically, once per week.")
+	f.IntVar(&p.flapWindow, "flap-window", 10, "The number of recent runs examined when looking for flapping nodes.")
+	f.IntVar(&p.flapThreshold, "flap-threshold", 50, "The percentage of those runs which must switch between failed and working for a node to be flapping.")
+	f.BoolVar(&p.suppressFlapping, "suppress-flapping", true, "Leave flapping nodes out of pushed metrics, alerts, and notifications.")
+	f.IntVar(&p.baselineDays, "baseline-days", 7, "The number of days of runs used to work out the usual runtime of a node.")
+	f.Float64Var(&p.slowFactor, "slow-factor", 2.0, "Flag runs which take this many times longer than the usual runtime.")
+	f.StringVar(&p.historyHourly, "history-hourly", "7d", "How long to keep the hourly history of our nodes.")
+	f.StringVar(&p.historyDaily, "history-daily", "90d", "How long to keep the daily history of our nodes.")
+	f.IntVar(&p.lateRuns, "late-runs", 3, "Mark nodes as late once they've missed this many runs, 0 to disable.")
+	f.IntVar(&p.metricsMaxSeries, "metrics-max-series", 1000, "The most series to export for any labelled metric via /metrics.")
+	f.StringVar(&p.metricsHost, "metrics-host", "", "Push metrics to this host periodically, if set.")
+	f.IntVar(&p.metricsPort, "metrics-port", 0, "The port to push metrics to, defaults to the usual port of the output.")
+	f.DurationVar(&p.metricsInterval, "metrics-interval", time.Minute, "How often to push metrics.")
+	f.StringVar(&p.metricsOutput, "metrics-output", "carbon", "Where to push metrics: carbon, statsd, influxdb, or influxdb-udp.")
+	f.StringVar(&p.metricsPrefix, "metrics-prefix", "puppet", "The prefix to use when pushing metrics.")
+	f.StringVar(&p.metricsGroups, "metrics-groups", "", "A comma-separated list of extra metrics to push, as with the metrics command.")
+	f.StringVar(&p.metricsDatabase, "metrics-database", "puppet", "The InfluxDB database to push to, when using HTTP.")
+	f.IntVar(&p.metricsRetries, "metrics-retries", 2, "How many times to retry a failed metrics push.")
+	f.DurationVar(&p.metricsTimeout, "metrics-timeout", 10*time.Second, "The timeout for each attempt to push metrics.")
+	f.StringVar(&p.webhooks, "webhook", "", "A comma-separated list of URLs to POST to when nodes change state.")
+	f.StringVar(&p.webhookStates, "webhook-states", "failed,late,orphaned", "Notify webhooks when nodes enter, or leave, any of these states.")
+	f.StringVar(&p.webhookTemplate, "webhook-template", "", "A file containing the template used to render webhook payloads.")
+	f.StringVar(&p.email, "email", "", "A comma-separated list of addresses to mail when nodes start failing.")
+	f.StringVar(&p.emailFrom, "email-from", "puppet-summary@localhost", "The sender of the mails we send.")
+	f.DurationVar(&p.emailInterval, "email-interval", 5*time.Minute, "How often to send a mail of the nodes which have started failing.")
+	f.BoolVar(&p.digest, "digest", true, "Send a daily digest to the email recipients.")
+	f.StringVar(&p.smtpHost, "smtp-host", "localhost", "The SMTP server to send mail via.")
+	f.IntVar(&p.smtpPort, "smtp-port", 25, "The port of the SMTP server.")
+	f.StringVar(&p.smtpUser, "smtp-user", "", "The username to authenticate to the SMTP server with, if any.")
+	f.StringVar(&p.smtpPassword, "smtp-password", "", "The password to authenticate to the SMTP server with.")
+	f.StringVar(&p.rules, "rules", "", "A YAML file containing the alert-rules to evaluate.")
+	f.DurationVar(&p.rulesInterval, "rules-interval", time.Minute, "How often to evaluate the alert-rules.")
 	f.StringVar(&p.bindHost, "host", "127.0.0.1", "The IP to listen upon.")
 	f.StringVar(&p.dbType, "db-type", "sqlite3", "The SQLite database to use.")
 	f.StringVar(&p.dbFile, "db-file", "ps.db", "The SQLite database to use or DSN for mysql (`db_user:db_password@tcp(db_hostname:db_port)/db_name`)")
 	f.StringVar(&p.prefix, "prefix", "./reports/", "The prefix to the local YAML hierarchy.")
 	f.StringVar(&p.urlprefix, "urlprefix", "", "The URL prefix for serving behind a proxy.")
+	f.StringVar(&p.watchDir, "watch-dir", "", "Ingest the reports written into this spool-directory, if set.")
+	f.StringVar(&p.watchPattern, "watch-pattern", "*.yaml", "Ingest the files in the spool-directory whose names match this pattern.")
+	f.DurationVar(&p.watchInterval, "watch-interval", 10*time.Second, "How often to scan the spool-directory, in case any reports were missed.")
 }
 
 //
@@ -1185,6 +3384,15 @@ func (p *serveCmd) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{})
 	//
 	SetupDB(p.dbType, p.dbFile)
 
+	//
+	// Set how long our history is kept.
+	//
+	err := setHistoryRetention(p.historyHourly, p.historyDaily)
+	if err != nil {
+		fmt.Printf("Error setting the retention of history: %s\n", err.Error())
+		return subcommands.ExitFailure
+	}
+
 	//
 	// Create a cron scheduler
 	//
@@ -1215,6 +3423,109 @@ func (p *serveCmd) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{})
 		updateOrphans()
 	})
 
+	//
+	//  Push metrics, if we've been given somewhere to push them.
+	//
+	if p.metricsHost != "" {
+		groups, err := parseGroups(p.metricsGroups)
+		if err != nil {
+			fmt.Printf("Error: %s\n", err.Error())
+			return subcommands.ExitFailure
+		}
+		if _, ok := outputFormats[p.metricsOutput]; !ok {
+			fmt.Printf("Error: unknown metrics-output '%s'\n", p.metricsOutput)
+			return subcommands.ExitFailure
+		}
+
+		opts := OutputOptions{Database: p.metricsDatabase, Retries: p.metricsRetries, Timeout: p.metricsTimeout}
+
+		pusher = &metricsPusher{output: p.metricsOutput,
+			host:     p.metricsHost,
+			port:     p.metricsPort,
+			opts:     opts,
+			prefix:   p.metricsPrefix,
+			groups:   groups,
+			suppress: p.suppressFlapping}
+
+		c.AddFunc(fmt.Sprintf("@every %s", p.metricsInterval), pusher.push)
+	}
+
+	//
+	//  Setup our webhooks, and deliver any notifications we've queued.
+	//
+	if p.webhooks != "" {
+		for _, url := range strings.Split(p.webhooks, ",") {
+			if strings.TrimSpace(url) != "" {
+				Webhooks = append(Webhooks, strings.TrimSpace(url))
+			}
+		}
+
+		WebhookStates = make(map[string]bool)
+		for _, state := range strings.Split(p.webhookStates, ",") {
+			WebhookStates[strings.TrimSpace(state)] = true
+		}
+
+		if p.webhookTemplate != "" {
+			content, err := ioutil.ReadFile(p.webhookTemplate)
+			if err != nil {
+				fmt.Printf("Error reading webhook template: %s\n", err.Error())
+				return subcommands.ExitFailure
+			}
+			WebhookTemplate, err = parseWebhookTemplate(string(content))
+			if err != nil {
+				fmt.Printf("Error parsing webhook template: %s\n", err.Error())
+				return subcommands.ExitFailure
+			}
+		}
+	}
+	c.AddFunc("@every 30s", func() {
+		deliverNotifications()
+	})
+
+	//
+	//  Mail failures in batches, and send the daily digest, if we've
+	//  been given anybody to mail.
+	//
+	if p.email != "" {
+		for _, addr := range strings.Split(p.email, ",") {
+			if strings.TrimSpace(addr) != "" {
+				EmailTo = append(EmailTo, strings.TrimSpace(addr))
+			}
+		}
+		EmailFrom = p.emailFrom
+		SMTPHost = p.smtpHost
+		SMTPPort = p.smtpPort
+		SMTPUser = p.smtpUser
+		SMTPPassword = p.smtpPassword
+
+		c.AddFunc(fmt.Sprintf("@every %s", p.emailInterval), deliverEmails)
+		if p.digest {
+			c.AddFunc("@daily", sendDigest)
+		}
+	}
+
+	//
+	//  Evaluate our alert-rules, if we have any.
+	//
+	if p.rules != "" {
+		var err error
+		AlertRules, err = loadRules(p.rules)
+		if err != nil {
+			fmt.Printf("Error loading alert-rules: %s\n", err.Error())
+			return subcommands.ExitFailure
+		}
+		c.AddFunc(fmt.Sprintf("@every %s", p.rulesInterval), evaluateRules)
+	}
+
+	//
+	//  Every five minutes look for hosts which have missed runs, and
+	//  expire old acknowledgements.
+	//
+	c.AddFunc("@every 5m", func() {
+		updateLate(p.lateRuns)
+		expireAcks()
+	})
+
 	//
 	//  Every day clean unpinned orphan hosts.
 	//
@@ -1224,11 +3535,12 @@ func (p *serveCmd) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{})
 	})
 
 	//
-	//  Every day purge history over 14 days.
+	//  Every day purge history older than its retention.
 	//
 	c.AddFunc("@daily", func() {
 		fmt.Printf("Purging history\n")
 		pruneHistory()
+		pruneRollups()
 	})
 
 	//
@@ -1236,7 +3548,6 @@ func (p *serveCmd) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{})
 	//
 	c.Start()
 
-
 	//
 	// Start the server
 	//
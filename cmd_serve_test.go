@@ -15,6 +15,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 	"unicode"
 
 	"github.com/gorilla/mux"
@@ -223,6 +224,7 @@ func TestKnownAPIState(t *testing.T) {
 		{"changed", "[\"foo.example.com\"]"},
 		{"unchanged", "[]"},
 		{"failed", "[\"bar.example.com\"]"},
+		{"late", "[]"},
 		{"orphaned", "[]"}}
 
 	//
@@ -985,3 +987,977 @@ func TestRadiatorView(t *testing.T) {
 	os.RemoveAll(path)
 
 }
+
+//
+// Test the views of the nodes with a given role, or on a given branch.
+//
+func TestSliceView(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	var n PuppetReport
+	n.Fqdn = "web1.example.com"
+	n.State = "failed"
+	n.Runtime = "1.0"
+	n.Role = "web"
+	n.Branch = "feature/foo"
+	addDB(n, "")
+
+	n.Fqdn = "db1.example.com"
+	n.State = "unchanged"
+	n.Role = "db"
+	n.Branch = "production"
+	addDB(n, "")
+
+	router := mux.NewRouter()
+	router.HandleFunc("/role/{role}/radiator/", SliceRadiatorView).Methods("GET")
+	router.HandleFunc("/role/{role}/", SliceHandler).Methods("GET")
+	router.HandleFunc("/role/{role}", SliceHandler).Methods("GET")
+	router.HandleFunc("/branch/{branch:.+}/radiator/", SliceRadiatorView).Methods("GET")
+	router.HandleFunc("/branch/{branch:.+}", SliceHandler).Methods("GET")
+
+	type TestCase struct {
+		URL      string
+		Type     string
+		Status   int
+		Response string
+	}
+
+	tests := []TestCase{
+		{"/role/web", "text/html", http.StatusOK, "<h1>Role: web"},
+		{"/role/web", "text/html", http.StatusOK, "href=\"/role/web/radiator/\""},
+		{"/role/web/", "application/json", http.StatusOK, "\"Fqdn\":\"web1.example.com\""},
+		{"/branch/feature/foo", "text/html", http.StatusOK, "<h1>Branch: feature/foo"},
+		{"/branch/feature/foo", "text/html", http.StatusOK, "<td>web1.example.com</td>"},
+		{"/branch/feature%2Ffoo/radiator/", "text/html", http.StatusOK, "url=/branch/feature%2Ffoo/radiator/"},
+		{"/role/db/radiator/", "application/json", http.StatusOK, "{\"State\":\"unchanged\",\"Count\":1,\"Percentage\":100}"},
+		{"/role/steve", "text/html", http.StatusNotFound, "no nodes were found with role 'steve'"},
+		{"/branch/steve/radiator/", "text/html", http.StatusNotFound, "no nodes were found with branch 'steve'"}}
+
+	for _, test := range tests {
+
+		req, err := http.NewRequest("GET", test.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Add("Accept", test.Type)
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		if status := rr.Code; status != test.Status {
+			t.Errorf("Unexpected status-code for %s: %v", test.URL, status)
+		}
+		if !strings.Contains(rr.Body.String(), test.Response) {
+			t.Errorf("Unexpected body for %s: '%s'", test.URL, rr.Body.String())
+		}
+	}
+
+	//
+	// The web node isn't shown upon the page of the db role.
+	//
+	req, _ := http.NewRequest("GET", "/role/db", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if strings.Contains(rr.Body.String(), "web1.example.com") {
+		t.Errorf("Unexpected node upon the page of the db role")
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test that our timeline-view returns content that seems reasonable,
+// for both known and unknown nodes.
+//
+func TestTimelineView(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	// Add some data.
+	addFakeNodes()
+
+	//
+	// We'll make one test for each supported content-type
+	//
+	type TestCase struct {
+		Type     string
+		Response string
+	}
+
+	//
+	// The tests
+	//
+	tests := []TestCase{
+		{"text/html", "Mean time to recover"},
+		{"application/json", "\"To\":\"unchanged\","},
+		{"application/xml", "<Timeline>"}}
+
+	//
+	// Create a router.
+	//
+	router := mux.NewRouter()
+	router.HandleFunc("/timeline/{fqdn}/", TimelineHandler).Methods("GET")
+	router.HandleFunc("/timeline/{fqdn}", TimelineHandler).Methods("GET")
+
+	//
+	// Run each one.
+	//
+	for _, test := range tests {
+
+		//
+		// Make the request, with the appropriate Accept: header
+		//
+		req, err := http.NewRequest("GET", "/timeline/foo.example.com", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Add("Accept", test.Type)
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		if status := rr.Code; status != http.StatusOK {
+			t.Errorf("Unexpected status-code: %v", status)
+		}
+		if !strings.Contains(rr.Body.String(), test.Response) {
+			t.Fatalf("Unexpected body: '%s'", rr.Body.String())
+		}
+	}
+
+	//
+	// An unknown node should be missing.
+	//
+	req, err := http.NewRequest("GET", "/timeline/missing.example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusNotFound {
+		t.Errorf("Unexpected status-code: %v", status)
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test that our reliability-view returns content that seems reasonable.
+//
+func TestReliabilityView(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	// Add some data.
+	addFakeNodes()
+
+	type TestCase struct {
+		Type     string
+		Response string
+	}
+
+	tests := []TestCase{
+		{"text/html", "Mean time between failures"},
+		{"application/json", "\"Name\":\"bar.example.com\","},
+		{"application/xml", "<Reliability>"}}
+
+	for _, test := range tests {
+
+		req, err := http.NewRequest("GET", "/reliability/", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Add("Accept", test.Type)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(ReliabilityHandler)
+		handler.ServeHTTP(rr, req)
+
+		if status := rr.Code; status != http.StatusOK {
+			t.Errorf("Unexpected status-code: %v", status)
+		}
+		if !strings.Contains(rr.Body.String(), test.Response) {
+			t.Fatalf("Unexpected body: '%s'", rr.Body.String())
+		}
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test that flapping nodes are listed by the API.
+//
+func TestAPIFlapping(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	// Add a node which fails every other run, and some which don't.
+	addFakeNodes()
+
+	addFakeRuns("flaky.example.com", "failed", "unchanged", "failed", "unchanged", "failed")
+
+	type TestCase struct {
+		Type     string
+		Response string
+	}
+
+	tests := []TestCase{
+		{"text/plain", "flaky.example.com\n"},
+		{"application/json", "[\"flaky.example.com\"]"},
+		{"application/xml", "<string>flaky.example.com</string>"}}
+
+	for _, test := range tests {
+
+		req, err := http.NewRequest("GET", "/api/flapping", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Add("Accept", test.Type)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(APIFlapping)
+		handler.ServeHTTP(rr, req)
+
+		if status := rr.Code; status != http.StatusOK {
+			t.Errorf("Unexpected status-code: %v", status)
+		}
+		if rr.Body.String() != test.Response {
+			t.Fatalf("Unexpected body: '%s'", rr.Body.String())
+		}
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test that our idempotency-view returns content that seems reasonable,
+// and rejects bogus parameters.
+//
+func TestIdempotencyView(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	// Add some data.
+	addFakeNodes()
+
+	type TestCase struct {
+		URL      string
+		Type     string
+		Status   int
+		Response string
+	}
+
+	tests := []TestCase{
+		{"/idempotency/", "text/html", http.StatusOK, "Non-Idempotent Resources"},
+		{"/idempotency/?days=3", "application/json", http.StatusOK, "\"Days\":3,"},
+		{"/idempotency/", "application/xml", http.StatusOK, "<Threshold>80</Threshold>"},
+		{"/idempotency/?days=steve", "text/html", http.StatusInternalServerError, "positive number"},
+		{"/idempotency/?threshold=101", "text/html", http.StatusInternalServerError, "percentage"}}
+
+	for _, test := range tests {
+
+		req, err := http.NewRequest("GET", test.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Add("Accept", test.Type)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(IdempotencyHandler)
+		handler.ServeHTTP(rr, req)
+
+		if status := rr.Code; status != test.Status {
+			t.Errorf("Unexpected status-code: %v", status)
+		}
+		if !strings.Contains(rr.Body.String(), test.Response) {
+			t.Fatalf("Unexpected body: '%s'", rr.Body.String())
+		}
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test that our slowest-view returns content that seems reasonable.
+//
+func TestSlowestView(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	// Add some data.
+	addFakeNodes()
+
+	type TestCase struct {
+		URL      string
+		Type     string
+		Status   int
+		Response string
+	}
+
+	tests := []TestCase{
+		{"/slowest/", "text/html", http.StatusOK, "95th Percentile"},
+		{"/slowest/", "application/json", http.StatusOK, "\"Name\":\"foo.example.com\""},
+		{"/slowest/?days=2", "application/xml", http.StatusOK, "<Days>2</Days>"},
+		{"/slowest/?days=-1", "text/html", http.StatusInternalServerError, "positive number"}}
+
+	for _, test := range tests {
+
+		req, err := http.NewRequest("GET", test.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Add("Accept", test.Type)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(SlowestHandler)
+		handler.ServeHTTP(rr, req)
+
+		if status := rr.Code; status != test.Status {
+			t.Errorf("Unexpected status-code: %v", status)
+		}
+		if !strings.Contains(rr.Body.String(), test.Response) {
+			t.Fatalf("Unexpected body: '%s'", rr.Body.String())
+		}
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test that our rollout-view returns content that seems reasonable.
+//
+func TestRolloutView(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	var n PuppetReport
+	n.Fqdn = "web1.example.com"
+	n.State = "unchanged"
+	n.Runtime = "1.0"
+	n.Branch = "production"
+	n.BuildTime = time.Now().Unix() - 60*60
+	addDB(n, "")
+
+	type TestCase struct {
+		URL      string
+		Type     string
+		Status   int
+		Response string
+	}
+
+	tests := []TestCase{
+		{"/rollout/", "text/html", http.StatusOK, "100% of 1 nodes are running the newest build"},
+		{"/rollout/?days=3", "application/json", http.StatusOK, "\"Days\":3,"},
+		{"/rollout/", "application/xml", http.StatusOK, "<Branch>production</Branch>"},
+		{"/rollout/?days=steve", "text/html", http.StatusInternalServerError, "positive number"},
+		{"/rollout/?threshold=steve", "text/html", http.StatusInternalServerError, "invalid period"}}
+
+	for _, test := range tests {
+
+		req, err := http.NewRequest("GET", test.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Add("Accept", test.Type)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(RolloutHandler)
+		handler.ServeHTTP(rr, req)
+
+		if status := rr.Code; status != test.Status {
+			t.Errorf("Unexpected status-code: %v", status)
+		}
+		if !strings.Contains(rr.Body.String(), test.Response) {
+			t.Fatalf("Unexpected body: '%s'", rr.Body.String())
+		}
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test that our stats-view returns content that seems reasonable.
+//
+func TestStatsView(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	var n PuppetReport
+	n.Fqdn = "web1.example.com"
+	n.State = "failed"
+	n.Runtime = "1.5"
+	n.Role = "web"
+	n.Branch = "production"
+	addDB(n, "")
+
+	type TestCase struct {
+		URL      string
+		Type     string
+		Status   int
+		Response string
+	}
+
+	tests := []TestCase{
+		{"/stats/", "text/html", http.StatusOK, "<td>web</td>"},
+		{"/stats/", "text/html", http.StatusOK, "<td>1.5s</td>"},
+		{"/stats/?days=3", "application/json", http.StatusOK, "\"Days\":3,"},
+		{"/stats/", "application/json", http.StatusOK, "\"Branches\":[{\"Name\":\"production\",\"Nodes\":1,\"Runs\":1,\"Failed\":1,"},
+		{"/stats/", "application/xml", http.StatusOK, "<FailureRate>100</FailureRate>"},
+		{"/stats/?days=steve", "text/html", http.StatusInternalServerError, "positive number"}}
+
+	for _, test := range tests {
+
+		req, err := http.NewRequest("GET", test.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Add("Accept", test.Type)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(StatsHandler)
+		handler.ServeHTTP(rr, req)
+
+		if status := rr.Code; status != test.Status {
+			t.Errorf("Unexpected status-code: %v", status)
+		}
+		if !strings.Contains(rr.Body.String(), test.Response) {
+			t.Fatalf("Unexpected body: '%s'", rr.Body.String())
+		}
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test acknowledging a failing node.
+//
+func TestAckView(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	addFakeRuns("failed.example.com", "failed")
+	addFakeRuns("ok.example.com", "unchanged")
+
+	post := func(handler http.HandlerFunc, form url.Values, accept string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest("POST", "/acknowledge", strings.NewReader(form.Encode()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Accept", accept)
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	//
+	// Only failing nodes may be acknowledged.
+	//
+	rr := post(AckHandler, url.Values{"fqdn": {"ok.example.com"}}, "application/json")
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Unexpected status-code: %v", rr.Code)
+	}
+	rr = post(AckHandler, url.Values{"fqdn": {"failed.example.com"}, "expire": {"soon"}}, "application/json")
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Unexpected status-code: %v", rr.Code)
+	}
+
+	rr = post(AckHandler, url.Values{"fqdn": {"failed.example.com"}, "reason": {"TICKET-1"}, "user": {"steve"}, "expire": {"2d"}}, "application/json")
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), "\"Reason\":\"TICKET-1\"") {
+		t.Fatalf("Unexpected response: %v %s", rr.Code, rr.Body.String())
+	}
+
+	node, _ := getNodeAck("failed.example.com")
+	if node.Ack == nil || node.Ack.User != "steve" || node.Ack.ExpiresAt < time.Now().Unix()+47*60*60 {
+		t.Fatalf("Unexpected acknowledgement: %v", node.Ack)
+	}
+
+	//
+	// The node's page shows who acknowledged it.
+	//
+	req, _ := http.NewRequest("GET", "/node/failed.example.com", nil)
+	rr = httptest.NewRecorder()
+	router := mux.NewRouter()
+	router.HandleFunc("/node/{fqdn}", NodeHandler)
+	router.ServeHTTP(rr, req)
+	if !strings.Contains(rr.Body.String(), "acknowledged by steve: TICKET-1") {
+		t.Errorf("Unexpected body: %s", rr.Body.String())
+	}
+
+	//
+	// The index lists it separately.
+	//
+	req, _ = http.NewRequest("GET", "/", nil)
+	rr = httptest.NewRecorder()
+	http.HandlerFunc(IndexHandler).ServeHTTP(rr, req)
+	if !strings.Contains(rr.Body.String(), "<td>TICKET-1</td>") {
+		t.Errorf("Unexpected body: %s", rr.Body.String())
+	}
+
+	//
+	// The browser is sent back to the node.
+	//
+	rr = post(UnackHandler, url.Values{"id": {strconv.FormatInt(node.Ack.ID, 10)}, "fqdn": {"failed.example.com"}}, "text/html")
+	if rr.Code != http.StatusFound || rr.Header().Get("Location") != "/node/failed.example.com" {
+		t.Errorf("Unexpected response: %v %s", rr.Code, rr.Header().Get("Location"))
+	}
+	node, _ = getNodeAck("failed.example.com")
+	if node.Ack != nil {
+		t.Errorf("Acknowledgement wasn't removed")
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test adding, listing, and removing maintenance windows.
+//
+func TestMaintenanceView(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	post := func(handler http.HandlerFunc, form url.Values, accept string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest("POST", "/maintenance", strings.NewReader(form.Encode()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Accept", accept)
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	//
+	// Broken windows are rejected.
+	//
+	tests := []url.Values{
+		{"name": {"patching"}},
+		{"name": {"patching"}, "role": {"db"}, "start": {"tomorrow"}},
+		{"name": {"patching"}, "role": {"db"}, "schedule": {"0 2 * * *"}, "duration": {"steve"}},
+		{"name": {"patching"}, "role": {"db"}, "start": {"2017-07-29 03:00"}, "end": {"2017-07-29 02:00"}},
+	}
+	for _, form := range tests {
+		rr := post(MaintenanceAddHandler, form, "application/json")
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("Unexpected status-code for %v: %v", form, rr.Code)
+		}
+	}
+
+	rr := post(MaintenanceAddHandler, url.Values{"name": {"patching"}, "role": {"db"}, "schedule": {"0 2 * * *"}, "duration": {"2h"}}, "application/json")
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), "\"Duration\":7200") {
+		t.Fatalf("Unexpected response: %v %s", rr.Code, rr.Body.String())
+	}
+	rr = post(MaintenanceAddHandler, url.Values{"name": {"rebuild"}, "fqdn": {"web*"}, "start": {"2017-07-29 02:00"}, "end": {"2017-07-29T04:00"}}, "text/html")
+	if rr.Code != http.StatusFound || rr.Header().Get("Location") != "/maintenance" {
+		t.Errorf("Unexpected response: %v %s", rr.Code, rr.Header().Get("Location"))
+	}
+
+	//
+	// Both are listed.
+	//
+	req, _ := http.NewRequest("GET", "/maintenance", nil)
+	rr = httptest.NewRecorder()
+	http.HandlerFunc(MaintenanceHandler).ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Errorf("Unexpected status-code: %v", rr.Code)
+	}
+	for _, expected := range []string{"patching", "0 2 * * *, for 2h0m0s", "2017-07-29 02:00 until 2017-07-29 04:00"} {
+		if !strings.Contains(rr.Body.String(), expected) {
+			t.Errorf("Body didn't contain '%s'", expected)
+		}
+	}
+
+	windows, _ := getMaintenance()
+	if len(windows) != 2 {
+		t.Fatalf("Unexpected windows: %v", windows)
+	}
+
+	//
+	// Remove one.
+	//
+	rr = post(MaintenanceDeleteHandler, url.Values{"id": {strconv.FormatInt(windows[0].ID, 10)}}, "text/html")
+	if rr.Code != http.StatusFound {
+		t.Errorf("Unexpected status-code: %v", rr.Code)
+	}
+	windows, _ = getMaintenance()
+	if len(windows) != 1 {
+		t.Errorf("Unexpected windows: %v", windows)
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test recording events, and that they're drawn upon our graphs.
+//
+func TestEventsAPI(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	addFakeRuns("web1.example.com", "unchanged", "changed")
+
+	post := func(body string, contentType string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest("POST", "/api/v1/events", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", contentType)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(APIAddEvent).ServeHTTP(rr, req)
+		return rr
+	}
+
+	//
+	// Broken events are rejected.
+	//
+	for _, body := range []string{"{", "{\"tags\": [\"deploy\"]}"} {
+		rr := post(body, "application/json")
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("Unexpected status-code for %s: %v", body, rr.Code)
+		}
+	}
+	rr := post("text=deployed&at=yesterday", "application/x-www-form-urlencoded")
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Unexpected status-code: %v", rr.Code)
+	}
+
+	rr = post("{\"text\": \"deployed build 1234\", \"tags\": [\"production\"]}", "application/json")
+	if rr.Code != http.StatusCreated || !strings.Contains(rr.Body.String(), "\"Tags\":[\"production\"]") {
+		t.Fatalf("Unexpected response: %v %s", rr.Code, rr.Body.String())
+	}
+	rr = post("text=rebooted&tags=ops", "application/x-www-form-urlencoded")
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Unexpected response: %v %s", rr.Code, rr.Body.String())
+	}
+
+	//
+	// List them, by tag.
+	//
+	req, _ := http.NewRequest("GET", "/api/v1/events?tag=ops", nil)
+	rr = httptest.NewRecorder()
+	http.HandlerFunc(APIEvents).ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), "rebooted") || strings.Contains(rr.Body.String(), "1234") {
+		t.Errorf("Unexpected response: %v %s", rr.Code, rr.Body.String())
+	}
+
+	req, _ = http.NewRequest("GET", "/api/v1/events?since=steve", nil)
+	rr = httptest.NewRecorder()
+	http.HandlerFunc(APIEvents).ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Unexpected status-code: %v", rr.Code)
+	}
+
+	//
+	// Today's events are drawn upon the index's graph.
+	//
+	req, _ = http.NewRequest("GET", "/", nil)
+	rr = httptest.NewRecorder()
+	http.HandlerFunc(IndexHandler).ServeHTTP(rr, req)
+	if !strings.Contains(rr.Body.String(), "text: \"deployed build 1234\"") {
+		t.Errorf("Unexpected body: %s", rr.Body.String())
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test the rollups of our history may be retrieved, and graphed.
+//
+func TestHistoryAPI(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	addFakeRuns("web1.example.com", "unchanged", "unchanged", "failed")
+	addFakeRuns("web2.example.com", "unchanged")
+
+	type TestCase struct {
+		URL      string
+		Status   int
+		Response string
+	}
+
+	tests := []TestCase{
+		{"/api/v1/history", http.StatusOK, "\"Unchanged\":3,\"FailedNodes\":1,\"ChangedNodes\":0,\"UnchangedNodes\":2"},
+		{"/api/v1/history?resolution=hour&range=2h", http.StatusOK, "\"Failed\":1"},
+		{"/api/v1/history?resolution=week", http.StatusBadRequest, "unknown resolution"},
+		{"/api/v1/history?range=steve", http.StatusBadRequest, "invalid"},
+		{"/api/v1/history?group=fqdn", http.StatusBadRequest, "unknown group"},
+		{"/api/v1/history?name=web", http.StatusBadRequest, "a name may only be given along with a group"},
+		{"/api/v1/history?group=role&name=web", http.StatusOK, "[]"},
+		{"/api/v1/history?from=100&to=200", http.StatusOK, "[]"},
+		{"/api/v1/history?from=200&to=100", http.StatusBadRequest, "the window must end after it starts"},
+	}
+
+	for _, tst := range tests {
+		req, err := http.NewRequest("GET", tst.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(APIHistory).ServeHTTP(rr, req)
+
+		if rr.Code != tst.Status {
+			t.Errorf("Unexpected status-code for %s: %v", tst.URL, rr.Code)
+		}
+		if !strings.Contains(rr.Body.String(), tst.Response) {
+			t.Errorf("Unexpected body for %s: %s", tst.URL, rr.Body.String())
+		}
+	}
+
+	//
+	// The index may graph the number of distinct nodes, hourly.
+	//
+	req, _ := http.NewRequest("GET", "/?resolution=hour&count=nodes", nil)
+	rr := httptest.NewRecorder()
+	http.HandlerFunc(IndexHandler).ServeHTTP(rr, req)
+	label := strings.Replace(time.Now().UTC().Format("2006/01/02 15:00"), "/", "\\/", -1)
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), label) || !strings.Contains(rr.Body.String(), "\"2\",") {
+		t.Errorf("Unexpected body: %s", rr.Body.String())
+	}
+
+	req, _ = http.NewRequest("GET", "/?resolution=week", nil)
+	rr = httptest.NewRecorder()
+	http.HandlerFunc(IndexHandler).ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Unexpected status-code: %v", rr.Code)
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test the alerts page.
+//
+func TestAlertsView(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	saveAlert(&PuppetAlert{Rule: "web-failing", State: "firing", Value: 10, Message: "10.00% of nodes are failed", StartedAt: time.Now().Unix(), FiredAt: time.Now().Unix()})
+	saveAlert(&PuppetAlert{Rule: "no-reports", State: "resolved", StartedAt: 100, FiredAt: 100, ResolvedAt: time.Now().Unix()})
+
+	type TestCase struct {
+		URL      string
+		Type     string
+		Status   int
+		Response string
+	}
+
+	tests := []TestCase{
+		{"/alerts/", "text/html", http.StatusOK, "10.00% of nodes are failed"},
+		{"/alerts/", "application/json", http.StatusOK, "\"Resolved\":[{\"ID\":2,\"Rule\":\"no-reports\""},
+		{"/alerts/", "application/xml", http.StatusOK, "<Rule>web-failing</Rule>"}}
+
+	for _, test := range tests {
+
+		req, err := http.NewRequest("GET", test.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Add("Accept", test.Type)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(AlertsHandler)
+		handler.ServeHTTP(rr, req)
+
+		if status := rr.Code; status != test.Status {
+			t.Errorf("Unexpected status-code: %v", status)
+		}
+		if !strings.Contains(rr.Body.String(), test.Response) {
+			t.Fatalf("Unexpected body: '%s'", rr.Body.String())
+		}
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test the Prometheus end-point.
+//
+func TestMetricsView(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	// Add some data.
+	addFakeNodes()
+
+	req, err := http.NewRequest("GET", "/metrics", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(MetricsHandler)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("Unexpected status-code: %v", status)
+	}
+	if !strings.HasPrefix(rr.Header().Get("Content-Type"), "text/plain") {
+		t.Errorf("Unexpected content-type: %s", rr.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(rr.Body.String(), "puppet_node_last_report_age_seconds{fqdn=\"foo.example.com\"}") {
+		t.Fatalf("Unexpected body: '%s'", rr.Body.String())
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test the health end-point.
+//
+func TestHealthView(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	req, err := http.NewRequest("GET", "/health", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(HealthHandler)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("Unexpected status-code: %v", status)
+	}
+	if !strings.Contains(rr.Body.String(), "\"Status\":\"ok\"") {
+		t.Fatalf("Unexpected body: '%s'", rr.Body.String())
+	}
+
+	//
+	// A failed metrics-push leaves us degraded, but still healthy.
+	//
+	pusher = &metricsPusher{}
+	pusher.status.LastError = "connection refused"
+	defer func() { pusher = nil }()
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("Unexpected status-code: %v", status)
+	}
+	if !strings.Contains(rr.Body.String(), "\"Status\":\"degraded\"") {
+		t.Fatalf("Unexpected body: '%s'", rr.Body.String())
+	}
+
+	//
+	// Without a database we're unhealthy.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusServiceUnavailable {
+		t.Errorf("Unexpected status-code: %v", status)
+	}
+}
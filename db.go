@@ -5,10 +5,13 @@
 package main
 
 import (
+	"crypto/sha1"
 	"database/sql"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"os"
 	"path/filepath"
 	"sort"
@@ -26,23 +29,59 @@ import (
 var db *sql.DB
 var db_type string
 
+//
+// FlapWindow is the number of recent reports we examine when deciding
+// whether a node is flapping between failed and working states.
+//
+var FlapWindow = 10
+
+//
+// FlapThreshold is the percentage of those reports which must change
+// between failed and working for the node to be considered flapping.
+//
+var FlapThreshold = 50
+
+//
+// SuppressFlapping is set if nodes which are flapping should be left
+// out of our alerts, and notifications.
+//
+var SuppressFlapping = true
+
+//
+// BaselineDays is the number of days of runs used to work out the
+// usual runtime of a node.
+//
+var BaselineDays = 7
+
+//
+// SlowFactor is how many times longer than its usual runtime a run
+// must take to be flagged as slow.
+//
+var SlowFactor = 2.0
+
 //
 // PuppetRuns is the structure which is used to list a summary of puppet
 // runs on the front-page.
 //
 type PuppetRuns struct {
-	Fqdn       string
-	State      string
-	At         string
-	Epoch      string
-	Ago        string
-	Runtime    string
-	Branch     string
-	Role       string
-	BuiltAt    string
-	BuiltAgo   string
-	BuiltEpoch string
-	Pinned     string
+	Fqdn        string
+	State       string
+	At          string
+	Epoch       string
+	Ago         string
+	Runtime     string
+	Branch      string
+	Environment string
+	Role        string
+	BuiltAt     string
+	BuiltAgo    string
+	BuiltEpoch  string
+	Pinned      string
+	FlapScore   int
+	Flapping    bool
+	Fingerprint string
+	Ack         *PuppetAck
+	Maintenance string
 }
 
 //
@@ -64,6 +103,7 @@ type PuppetReportSummary struct {
 	Changed   int
 	Total     int
 	YamlFile  string
+	Slow      bool
 }
 
 //
@@ -77,6 +117,204 @@ type PuppetHistory struct {
 	Unchanged string
 }
 
+//
+// PuppetTransition records a node moving from one state to another,
+// as seen when a report was received.
+//
+type PuppetTransition struct {
+	Fqdn  string
+	Role  string
+	From  string
+	To    string
+	At    string
+	Ago   string
+	Epoch string
+}
+
+//
+// PuppetNotification is a notification waiting to be delivered.
+//
+type PuppetNotification struct {
+	ID        int64
+	Target    string
+	Payload   string
+	Attempts  int
+	LastError string
+}
+
+//
+// PuppetAck acknowledges that a node, or every node failing with the
+// same fingerprint, is failing.  An ExpiresAt of zero never expires.
+//
+type PuppetAck struct {
+	ID          int64
+	Fqdn        string
+	Fingerprint string
+	Reason      string
+	User        string
+	CreatedAt   int64
+	ExpiresAt   int64
+}
+
+//
+// PuppetMaintenance is a window during which failures are expected, for
+// the nodes matching an FQDN pattern, or with a given role.
+//
+// One-off windows run from StartsAt until EndsAt, while recurring windows
+// start according to their cron-style Schedule and last for Duration
+// seconds.
+//
+type PuppetMaintenance struct {
+	ID       int64
+	Name     string
+	Fqdn     string
+	Role     string
+	Schedule string
+	StartsAt int64
+	EndsAt   int64
+	Duration int64
+	Reason   string
+}
+
+//
+// PuppetEvent is an annotation, recorded by a person or by CI, such as
+// a deployment.  Events are drawn upon our graphs so that changes in
+// the state of our nodes may be matched up with their cause.
+//
+type PuppetEvent struct {
+	ID   int64
+	Text string
+	Tags []string
+	At   int64
+}
+
+//
+// PuppetAlert is raised by an alert-rule.  An alert is "pending" until
+// its rule has been breached for long enough, then "firing" until it
+// is "resolved".
+//
+type PuppetAlert struct {
+	ID         int64
+	Rule       string
+	State      string
+	Value      float64
+	Message    string
+	StartedAt  int64
+	FiredAt    int64
+	ResolvedAt int64
+}
+
+//
+// PuppetReliability holds the reliability figures for a single node,
+// or for all the nodes with a given role.  Times are in seconds.
+//
+type PuppetReliability struct {
+	Name          string
+	Nodes         int
+	Failures      int
+	MTTR          int64
+	MTBF          int64
+	Streak        int
+	StreakSeconds int64
+}
+
+//
+// PuppetStats summarises the runs of the nodes with a given role, on a
+// given git branch, or in a given environment.
+//
+type PuppetStats struct {
+	Name        string
+	Nodes       int
+	Runs        int
+	Failed      int
+	Changed     int
+	SuccessRate float64
+	FailureRate float64
+	ChangeRate  float64
+	Runtime     float64
+}
+
+//
+// PuppetBuild describes one build of a git branch: the nodes currently
+// running it, and how often runs of it failed.
+//
+type PuppetBuild struct {
+	BuiltAt     int64
+	Nodes       int
+	Percentage  float64
+	Runs        int
+	Failed      int
+	FailureRate float64
+}
+
+//
+// PuppetRollout follows the rollout of a git branch across our nodes.
+//
+// Builds are sorted newest first, and nodes are Stuck if they're not
+// running the newest build although it was made some time ago.
+//
+type PuppetRollout struct {
+	Branch  string
+	Nodes   int
+	Newest  int64
+	Current float64
+	Builds  []PuppetBuild
+	Stuck   []PuppetRuns
+}
+
+//
+// PuppetNonIdempotent describes a resource which is changed by most
+// runs of puppet, either across a number of nodes, or across the nodes
+// with a given role.
+//
+type PuppetNonIdempotent struct {
+	Role       string
+	Type       string
+	Name       string
+	File       string
+	Line       string
+	Nodes      int
+	Percentage float64
+	Fqdns      []string
+}
+
+//
+// PuppetRuntime holds the runtime baseline of a node, or of all the
+// nodes with a given role.  Times are in seconds.
+//
+type PuppetRuntime struct {
+	Name   string
+	Runs   int
+	Median float64
+	P95    float64
+	Latest float64
+	Slow   bool
+}
+
+//
+// PuppetActivity summarises the reports received over a period, and is
+// used for the submission of metrics.
+//
+type PuppetActivity struct {
+	Reports  int
+	Failed   int64
+	Changed  int64
+	Runtimes []float64
+}
+
+//
+// PuppetReportPoint holds the parts of a report which are used when
+// rebuilding historical metrics.
+//
+type PuppetReportPoint struct {
+	Fqdn    string
+	State   string
+	Runtime float64
+	Failed  int64
+	Changed int64
+	At      int64
+}
+
 //
 // PuppetState is used to return the number of nodes in a given state,
 // and is used for the submission of metrics.
@@ -173,6 +411,8 @@ func SetupDB(db_type_in string, path string) error {
 	          last_seen   integer(4),
 	          runtime     integer,
 	          pinned      integer,
+	          run_interval integer DEFAULT 0,
+	          flap_score  integer DEFAULT 0,
 	          UNIQUE(fqdn)
 	        )	        
 			`
@@ -216,6 +456,200 @@ func SetupDB(db_type_in string, path string) error {
 			return err
 		}
 
+		sqlStmt = `
+			CREATE TABLE IF NOT EXISTS rollups (
+	          id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	          resolution  integer,
+	          bucket      integer(4),
+	          dimension   text DEFAULT '',
+	          name        text DEFAULT '',
+	          state       text,
+	          reports     integer DEFAULT 0,
+	          nodes       integer DEFAULT 0,
+	          UNIQUE(resolution, bucket, dimension, name, state)
+	        )
+			`
+		//
+		// Create the table, if missing.
+		//
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			return err
+		}
+
+		sqlStmt = `
+			CREATE TABLE IF NOT EXISTS rollup_nodes (
+	          id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	          resolution  integer,
+	          bucket      integer(4),
+	          fqdn        text,
+	          state       text,
+	          UNIQUE(resolution, bucket, fqdn, state)
+	        )
+			`
+		//
+		// Create the table, if missing.
+		//
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			return err
+		}
+
+		sqlStmt = `
+			CREATE TABLE IF NOT EXISTS state_transitions (
+	          id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	          host_id     INTEGER,
+	          fqdn        text,
+	          role        text,
+	          from_state  text,
+	          to_state    text,
+	          changed_at  integer(4)
+	        )
+			`
+		//
+		// Create the table, if missing.
+		//
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			return err
+		}
+
+		sqlStmt = `
+			CREATE TABLE IF NOT EXISTS notifications (
+	          id           INTEGER PRIMARY KEY AUTOINCREMENT,
+	          channel      text,
+	          target       text,
+	          payload      text,
+	          attempts     integer DEFAULT 0,
+	          next_attempt integer(4),
+	          created_at   integer(4),
+	          last_error   text
+	        )
+			`
+		//
+		// Create the table, if missing.
+		//
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			return err
+		}
+
+		sqlStmt = `
+			CREATE TABLE IF NOT EXISTS alerts (
+	          id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	          rule        text,
+	          state       text,
+	          value       real,
+	          message     text,
+	          started_at  integer(4),
+	          fired_at    integer(4) DEFAULT 0,
+	          resolved_at integer(4) DEFAULT 0
+	        )
+			`
+		//
+		// Create the table, if missing.
+		//
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			return err
+		}
+
+		sqlStmt = `
+			CREATE TABLE IF NOT EXISTS acknowledgements (
+	          id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	          fqdn        text DEFAULT '',
+	          fingerprint text DEFAULT '',
+	          reason      text,
+	          acked_by    text,
+	          created_at  integer(4),
+	          expires_at  integer(4) DEFAULT 0
+	        )
+			`
+		//
+		// Create the table, if missing.
+		//
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			return err
+		}
+
+		sqlStmt = `
+			CREATE TABLE IF NOT EXISTS maintenance_windows (
+	          id         INTEGER PRIMARY KEY AUTOINCREMENT,
+	          name       text,
+	          fqdn       text DEFAULT '',
+	          role       text DEFAULT '',
+	          schedule   text DEFAULT '',
+	          starts_at  integer(4) DEFAULT 0,
+	          ends_at    integer(4) DEFAULT 0,
+	          duration   integer(4) DEFAULT 0,
+	          reason     text,
+	          created_at integer(4)
+	        )
+			`
+		//
+		// Create the table, if missing.
+		//
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			return err
+		}
+
+		sqlStmt = `
+			CREATE TABLE IF NOT EXISTS events (
+	          id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	          text        text,
+	          tags        text DEFAULT '',
+	          happened_at integer(4)
+	        )
+			`
+		//
+		// Create the table, if missing.
+		//
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			return err
+		}
+
+		sqlStmt = `
+			CREATE TABLE IF NOT EXISTS changed_resources (
+	          id            INTEGER PRIMARY KEY AUTOINCREMENT,
+	          report_id     INTEGER,
+	          fqdn          text,
+	          role          text,
+	          resource_type text,
+	          title         text,
+	          file          text,
+	          line          text,
+	          executed_at   integer(4)
+	        )
+			`
+		//
+		// Create the table, if missing.
+		//
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			return err
+		}
+
+		sqlStmt = `
+			CREATE TABLE IF NOT EXISTS resource_times (
+	          id            INTEGER PRIMARY KEY AUTOINCREMENT,
+	          report_id     INTEGER,
+	          fqdn          text,
+	          resource_type text,
+	          seconds       real,
+	          executed_at   integer(4)
+	        )
+			`
+		//
+		// Create the table, if missing.
+		//
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			return err
+		}
+
 	} else if strings.Compare(db_type_in, "mysql") == 0 {
 		sqlStmt = `
 			CREATE TABLE IF NOT EXISTS reports (
@@ -234,7 +668,9 @@ func SetupDB(db_type_in string, path string) error {
 			  failed int(11) DEFAULT NULL,
 			  changed int(11) DEFAULT NULL,
 			  PRIMARY KEY (id),
-			  KEY fqdn (fqdn)
+			  KEY fqdn (fqdn),
+			  KEY yaml_file (yaml_file),
+			  KEY executed_at (executed_at, id)
 			) ENGINE=InnoDB DEFAULT CHARSET=utf8
 			`
 		//
@@ -258,6 +694,8 @@ func SetupDB(db_type_in string, path string) error {
 			  last_seen int(4) DEFAULT NULL,
 			  runtime int(11) DEFAULT NULL,
 			  pinned tinyint DEFAULT 0,
+			  run_interval int(11) DEFAULT 0,
+			  flap_score int(11) DEFAULT 0,
 			  PRIMARY KEY (host_id),
 			  UNIQUE KEY fqdn (fqdn)
 			) ENGINE=InnoDB DEFAULT CHARSET=utf8
@@ -293,419 +731,2576 @@ func SetupDB(db_type_in string, path string) error {
 			return err
 		}
 
-	} else {
-		return errors.New("Invalid db type, sqlite3 or mysql supported")
-	}
-
-
-
-	return nil
-}
-
-//
-// Add an entry to the database.
-//
-// The entry contains most of the interesting data from the parsed YAML.
-//
-// But note that it doesn't contain changed resources, etc.
-//
-//
-func addDB(data PuppetReport, path string) error {
-
-	//
-	// Ensure we have a DB-handle
-	//
-	if db == nil {
-		return errors.New("SetupDB not called")
-	}
+		sqlStmt = `
+			CREATE TABLE IF NOT EXISTS rollups (
+			  id         int(11) unsigned NOT NULL AUTO_INCREMENT,
+			  resolution int(11) DEFAULT NULL,
+			  bucket     int(4) DEFAULT NULL,
+			  dimension  varchar(32) DEFAULT '',
+			  name       varchar(190) DEFAULT '',
+			  state      varchar(32) DEFAULT NULL,
+			  reports    int(11) DEFAULT 0,
+			  nodes      int(11) DEFAULT 0,
+			  PRIMARY KEY (id),
+			  UNIQUE KEY rollup (resolution, bucket, dimension, name, state)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8
+			`
+		//
+		// Create the table, if missing.
+		//
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			return err
+		}
 
-	host_id, err := getHostId(data.Fqdn)
-	if err != nil {
-		return err
-	}
+		sqlStmt = `
+			CREATE TABLE IF NOT EXISTS rollup_nodes (
+			  id         int(11) unsigned NOT NULL AUTO_INCREMENT,
+			  resolution int(11) DEFAULT NULL,
+			  bucket     int(4) DEFAULT NULL,
+			  fqdn       varchar(190) DEFAULT NULL,
+			  state      varchar(32) DEFAULT NULL,
+			  PRIMARY KEY (id),
+			  UNIQUE KEY rollup_node (resolution, bucket, fqdn, state)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8
+			`
+		//
+		// Create the table, if missing.
+		//
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			return err
+		}
 
-	if host_id == 0 {
-		err = nil
-		host_id, err = createHost(data.Fqdn)
+		sqlStmt = `
+			CREATE TABLE IF NOT EXISTS state_transitions (
+			  id         int(11) unsigned NOT NULL AUTO_INCREMENT,
+			  host_id    int(6) unsigned NOT NULL,
+			  fqdn       varchar(255) DEFAULT NULL,
+			  role       varchar(255) DEFAULT NULL,
+			  from_state varchar(255) DEFAULT NULL,
+			  to_state   varchar(255) DEFAULT NULL,
+			  changed_at int(4) DEFAULT NULL,
+			  PRIMARY KEY (id),
+			  KEY fqdn (fqdn),
+			  KEY changed_at (fqdn, changed_at)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8
+			`
+		//
+		// Create the table, if missing.
+		//
+		_, err = db.Exec(sqlStmt)
 		if err != nil {
 			return err
 		}
-	}
 
-	at := time.Now().Unix()
+		sqlStmt = `
+			CREATE TABLE IF NOT EXISTS notifications (
+			  id           int(11) unsigned NOT NULL AUTO_INCREMENT,
+			  channel      varchar(32) DEFAULT NULL,
+			  target       varchar(1024) DEFAULT NULL,
+			  payload      text,
+			  attempts     int(6) DEFAULT 0,
+			  next_attempt int(4) DEFAULT NULL,
+			  created_at   int(4) DEFAULT NULL,
+			  last_error   text,
+			  PRIMARY KEY (id),
+			  KEY next_attempt (next_attempt)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8
+			`
+		//
+		// Create the table, if missing.
+		//
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			return err
+		}
+
+		sqlStmt = `
+			CREATE TABLE IF NOT EXISTS alerts (
+			  id          int(11) unsigned NOT NULL AUTO_INCREMENT,
+			  rule        varchar(255) DEFAULT NULL,
+			  state       varchar(20) DEFAULT NULL,
+			  value       double DEFAULT NULL,
+			  message     text,
+			  started_at  int(4) DEFAULT NULL,
+			  fired_at    int(4) DEFAULT 0,
+			  resolved_at int(4) DEFAULT 0,
+			  PRIMARY KEY (id),
+			  KEY state (state)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8
+			`
+		//
+		// Create the table, if missing.
+		//
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			return err
+		}
+
+		sqlStmt = `
+			CREATE TABLE IF NOT EXISTS acknowledgements (
+			  id          int(11) unsigned NOT NULL AUTO_INCREMENT,
+			  fqdn        varchar(255) DEFAULT '',
+			  fingerprint varchar(40) DEFAULT '',
+			  reason      text,
+			  acked_by    varchar(255) DEFAULT NULL,
+			  created_at  int(4) DEFAULT NULL,
+			  expires_at  int(4) DEFAULT 0,
+			  PRIMARY KEY (id),
+			  KEY fqdn (fqdn)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8
+			`
+		//
+		// Create the table, if missing.
+		//
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			return err
+		}
+
+		sqlStmt = `
+			CREATE TABLE IF NOT EXISTS maintenance_windows (
+			  id         int(11) unsigned NOT NULL AUTO_INCREMENT,
+			  name       varchar(255) DEFAULT NULL,
+			  fqdn       varchar(255) DEFAULT '',
+			  role       varchar(255) DEFAULT '',
+			  schedule   varchar(255) DEFAULT '',
+			  starts_at  int(4) DEFAULT 0,
+			  ends_at    int(4) DEFAULT 0,
+			  duration   int(4) DEFAULT 0,
+			  reason     text,
+			  created_at int(4) DEFAULT NULL,
+			  PRIMARY KEY (id)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8
+			`
+		//
+		// Create the table, if missing.
+		//
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			return err
+		}
+
+		sqlStmt = `
+			CREATE TABLE IF NOT EXISTS events (
+			  id          int(11) unsigned NOT NULL AUTO_INCREMENT,
+			  text        text,
+			  tags        varchar(255) DEFAULT '',
+			  happened_at int(4) DEFAULT NULL,
+			  PRIMARY KEY (id)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8
+			`
+		//
+		// Create the table, if missing.
+		//
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			return err
+		}
+
+		sqlStmt = `
+			CREATE TABLE IF NOT EXISTS changed_resources (
+			  id            int(11) unsigned NOT NULL AUTO_INCREMENT,
+			  report_id     int(11) unsigned NOT NULL,
+			  fqdn          varchar(255) DEFAULT NULL,
+			  role          varchar(255) DEFAULT NULL,
+			  resource_type varchar(255) DEFAULT NULL,
+			  title         varchar(1024) DEFAULT NULL,
+			  file          varchar(1024) DEFAULT NULL,
+			  line          varchar(32) DEFAULT NULL,
+			  executed_at   int(4) DEFAULT NULL,
+			  PRIMARY KEY (id),
+			  KEY fqdn (fqdn),
+			  KEY executed_at (executed_at),
+			  KEY report_id (report_id)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8
+			`
+		//
+		// Create the table, if missing.
+		//
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			return err
+		}
+
+		sqlStmt = `
+			CREATE TABLE IF NOT EXISTS resource_times (
+			  id            int(11) unsigned NOT NULL AUTO_INCREMENT,
+			  report_id     int(11) unsigned NOT NULL,
+			  fqdn          varchar(255) DEFAULT NULL,
+			  resource_type varchar(255) DEFAULT NULL,
+			  seconds       double DEFAULT NULL,
+			  executed_at   int(4) DEFAULT NULL,
+			  PRIMARY KEY (id),
+			  KEY fqdn (fqdn),
+			  KEY executed_at (executed_at),
+			  KEY report_id (report_id)
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8
+			`
+		//
+		// Create the table, if missing.
+		//
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			return err
+		}
+
+	} else {
+		return errors.New("Invalid db type, sqlite3 or mysql supported")
+	}
+
+	//
+	// Databases created by older releases won't have the columns
+	// we've added since, so add them if they're missing.
+	//
+	err = addColumn("hosts", "run_interval", "integer DEFAULT 0")
+	if err != nil {
+		return err
+	}
+	err = addColumn("hosts", "flap_score", "integer DEFAULT 0")
+	if err != nil {
+		return err
+	}
+	err = addColumn("hosts", "environment", "varchar(255) DEFAULT ''")
+	if err != nil {
+		return err
+	}
+	err = addColumn("hosts", "fingerprint", "varchar(40) DEFAULT ''")
+	if err != nil {
+		return err
+	}
+	err = addColumn("reports", "environment", "varchar(255) DEFAULT ''")
+	if err != nil {
+		return err
+	}
+
+	//
+	// The same goes for indexes, which SQLite can't declare within
+	// the tables themselves.
+	//
+	err = addIndex("reports", "yaml_file", "yaml_file")
+	if err != nil {
+		return err
+	}
+	err = addIndex("reports", "executed_at", "executed_at, id")
+	if err != nil {
+		return err
+	}
+	err = addIndex("changed_resources", "report_id", "report_id")
+	if err != nil {
+		return err
+	}
+	err = addIndex("resource_times", "report_id", "report_id")
+	if err != nil {
+		return err
+	}
+	err = addIndex("state_transitions", "changed_at", "fqdn, changed_at")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+//
+// Add a column to an existing table, unless it is already present.
+//
+func addColumn(table string, column string, definition string) error {
+
+	_, err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	if err != nil && strings.Contains(strings.ToLower(err.Error()), "duplicate column") {
+		return nil
+	}
+	return err
+}
+
+//
+// Add an index to an existing table, unless it is already present.
+//
+// SQLite index names are shared by all tables, so they're prefixed
+// with the name of their table.
+//
+func addIndex(table string, name string, columns string) error {
+
+	if db_type == "sqlite3" {
+		_, err := db.Exec(fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s_%s ON %s (%s)", table, name, table, columns))
+		return err
+	}
+
+	_, err := db.Exec(fmt.Sprintf("CREATE INDEX %s ON %s (%s)", name, table, columns))
+	if err != nil && strings.Contains(strings.ToLower(err.Error()), "duplicate key name") {
+		return nil
+	}
+	return err
+}
+
+//
+// Add an entry to the database.
+//
+// The entry contains most of the interesting data from the parsed YAML.
+//
+// But note that it doesn't contain changed resources, etc.
+//
+//
+func addDB(data PuppetReport, path string) error {
+
+	//
+	// Ensure we have a DB-handle
+	//
+	if db == nil {
+		return errors.New("SetupDB not called")
+	}
+
+	host_id, err := getHostId(data.Fqdn)
+	if err != nil {
+		return err
+	}
+
+	if host_id == 0 {
+		err = nil
+		host_id, err = createHost(data.Fqdn)
+		if err != nil {
+			return err
+		}
+	}
+
+	//
+	// Find the state the host was in before this report, so that
+	// we can record any transition.
+	//
+	var previous string
+	row := db.QueryRow("SELECT state FROM hosts WHERE host_id = ?", host_id)
+	err = row.Scan(&previous)
+	if err != nil {
+		return err
+	}
+
+	at := time.Now().Unix()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	
+	_, err = insertReport(tx, data, host_id, path, at)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	//
+	// Failures are fingerprinted by their error messages, so that
+	// nodes failing in the same way may be acknowledged together.
+	//
+	fingerprint := ""
+	if data.State == "failed" {
+		fingerprint = failureFingerprint(data.ErrorMessages)
+	}
+
+	host_stmt, err := tx.Prepare("UPDATE hosts SET last_seen = ?, state = ?, runtime = ?, role = ?, branch = ? , build_time = ?, environment = ?, fingerprint = ? WHERE host_id = ?")
+	if err != nil {
+		return err
+	}
+	defer host_stmt.Close()
+
+	host_stmt.Exec(
+		at,
+		data.State,
+		data.Runtime,
+		data.Role,
+		data.Branch,
+		data.BuildTime,
+		data.Environment,
+		fingerprint,
+		host_id)
+
+	if previous != data.State {
+		transition_stmt, err := tx.Prepare("INSERT INTO state_transitions(host_id, fqdn, role, from_state, to_state, changed_at) VALUES(?,?,?,?,?,?)")
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		defer transition_stmt.Close()
+
+		_, err = transition_stmt.Exec(host_id, data.Fqdn, data.Role, previous, data.State, at)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	tx.Commit()
+
+	//
+	// Update the flapping-score before notifying anybody of this
+	// report, so that they may ignore nodes which are flapping.
+	//
+	updateFlapping(data.Fqdn, host_id)
+
+	if previous != data.State {
+		notifyTransition(newTransition(data.Fqdn, data.Role, previous, data.State, at))
+	}
+
+	//
+	// Once a node recovers it is no longer acknowledged.
+	//
+	if data.State != "failed" {
+		db.Exec("DELETE FROM acknowledgements WHERE fqdn = ?", data.Fqdn)
+	}
+
+	updateHistory(at, data.State)
+	updateRollups(data.Fqdn, data.Role, data.Environment, data.Branch, data.State, at)
+
+	updateInterval(host_id)
+
+	return nil
+}
+
+//
+// Insert a report, within the given transaction, along with the resources
+// it changed and the time spent upon each type of resource.
+//
+func insertReport(tx *sql.Tx, data PuppetReport, host_id int, path string, at int64) (int64, error) {
+
+	report, err := tx.Exec("INSERT INTO reports(fqdn,host_id,state,yaml_file,executed_at,runtime, failed, changed, total, skipped, role, branch, build_time, environment) values(?,?,?,?,?,?,?,?,?,?,?,?,?,?)",
+		data.Fqdn,
+		host_id,
+		data.State,
+		path,
+		at,
+		data.Runtime,
+		data.Failed,
+		data.Changed,
+		data.Total,
+		data.Skipped,
+		data.Role,
+		data.Branch,
+		data.BuildTime,
+		data.Environment)
+	if err != nil {
+		return 0, err
+	}
+
+	report_id, err := report.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return report_id, insertResources(tx, report_id, data, at)
+}
+
+//
+// Record the resources which were changed by a report, so that we can
+// spot those which change on every run, along with the time spent upon
+// each type of resource.
+//
+func insertResources(tx *sql.Tx, report_id int64, data PuppetReport, at int64) error {
+
+	if len(data.ResourcesChanged) < 1 && len(data.TypeTimes) < 1 {
+		return nil
+	}
+
+	resource_stmt, err := tx.Prepare("INSERT INTO changed_resources(report_id, fqdn, role, resource_type, title, file, line, executed_at) VALUES(?,?,?,?,?,?,?,?)")
+	if err != nil {
+		return err
+	}
+	defer resource_stmt.Close()
+
+	for _, r := range data.ResourcesChanged {
+		resource_stmt.Exec(report_id, data.Fqdn, data.Role, r.Type, r.Name, r.File, r.Line, at)
+	}
+
+	time_stmt, err := tx.Prepare("INSERT INTO resource_times(report_id, fqdn, resource_type, seconds, executed_at) VALUES(?,?,?,?,?)")
+	if err != nil {
+		return err
+	}
+	defer time_stmt.Close()
+
+	for _, t := range data.TypeTimes {
+		time_stmt.Exec(report_id, data.Fqdn, t.Type, t.Seconds, at)
+	}
+	return nil
+}
+
+//
+// Update the flapping-score of the given host, from its recent reports.
+//
+func updateFlapping(fqdn string, host_id int) error {
+
+	reports, err := getReports(fqdn)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec("UPDATE hosts SET flap_score = ? WHERE host_id = ?", flapScore(reports, FlapWindow), host_id)
+	return err
+}
+
+//
+// Is the given host flapping between failed and working states?
+//
+func isFlapping(fqdn string) bool {
+
+	score := 0
+	err := db.QueryRow("SELECT flap_score FROM hosts WHERE fqdn = ?", fqdn).Scan(&score)
+	if err != nil {
+		return false
+	}
+	return score > 0 && score >= FlapThreshold
+}
+
+//
+// Given a list of reports, most recent first, return the percentage
+// of the most recent `window` runs which moved between failed and
+// working states.
+//
+// A node which fails every other run will score 100, and a node which
+// stays failed, or stays working, will score zero.
+//
+func flapScore(reports []PuppetReportSummary, window int) int {
+
+	if len(reports) > window {
+		reports = reports[:window]
+	}
+	if len(reports) < 3 {
+		return 0
+	}
+
+	flips := 0
+	for i := 1; i < len(reports); i++ {
+		if (reports[i-1].State == "failed") != (reports[i].State == "failed") {
+			flips++
+		}
+	}
+
+	return (flips * 100) / (len(reports) - 1)
+}
+
+//
+// Update the run-interval we expect from the given host.
+//
+// The interval is the median of the gaps between the most recent
+// reports the host submitted, which copes with the odd manual run
+// better than an average would.
+//
+func updateInterval(host_id int) error {
+
+	//
+	// Ensure we have a DB-handle
+	//
+	if db == nil {
+		return errors.New("SetupDB not called")
+	}
+
+	rows, err := db.Query("SELECT executed_at FROM reports WHERE host_id = ? ORDER BY executed_at DESC LIMIT 21", host_id)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var times []int64
+	for rows.Next() {
+		var at int64
+		err = rows.Scan(&at)
+		if err != nil {
+			return err
+		}
+		times = append(times, at)
+	}
+	err = rows.Err()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec("UPDATE hosts SET run_interval = ? WHERE host_id = ?", medianInterval(times), host_id)
+	return err
+}
+
+//
+// Given a list of times, in descending order, return the median gap
+// between them.
+//
+// We need at least three runs before we'll guess, otherwise we
+// return zero to mean "unknown".
+//
+func medianInterval(times []int64) int64 {
+
+	if len(times) < 3 {
+		return 0
+	}
+
+	var gaps []int64
+	for i := 1; i < len(times); i++ {
+		gaps = append(gaps, times[i-1]-times[i])
+	}
+	sort.Slice(gaps, func(a, b int) bool { return gaps[a] < gaps[b] })
+
+	return gaps[len(gaps)/2]
+}
+
+//
+// Get host id.
+//
+func getHostId(fqdn string) (int, error) {
+
+	//
+	// Ensure we have a DB-handle
+	//
+	if db == nil {
+		return 0, errors.New("SetupDB not called")
+	}
+
+	var host_id int
+	row := db.QueryRow("SELECT host_id FROM hosts WHERE fqdn = ?", fqdn)
+	err := row.Scan(&host_id)
+
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return host_id, err
+}
+
+//
+// insert host.
+//
+func createHost(fqdn string) (int, error) {
+
+	//
+	// Ensure we have a DB-handle
+	//
+	if db == nil {
+		return 0, errors.New("SetupDB not called")
+	}
+
+	_, err := db.Exec("INSERT INTO hosts(fqdn, state, last_seen, runtime, pinned, role, branch, build_time) VALUES (?, '', 0, 0, 0, '', '', 0)", fqdn)
+	if err != nil {
+		return 0, err
+	}
+
+	return getHostId(fqdn)
+}
+
+//
+// OrphanSeconds is the threshold which marks the difference between
+// "current" and "orphaned".
+//
+// Here we set it to 3.5 days, which should be long enough to cover
+// any hosts that were powered-off over a weekend.  (Friday + Saturday
+// + Sunday + slack).
+//
+var OrphanSeconds = int64(3.5 * (24 * 60 * 60))
+
+//
+// update orphan hosts.
+//
+func updateOrphans() {
+
+	//
+	// Ensure we have a DB-handle
+	//
+	if db == nil {
+		return
+	}
+
+	moveHosts("orphaned", "state != 'orphaned' AND last_seen < ?", time.Now().Unix() - OrphanSeconds)
+}
+
+//
+// update late hosts.
+//
+// A host is late if it has missed `runs` of its expected puppet-runs,
+// based upon the interval we've learned from its previous reports.
+//
+// Orphaned hosts are left alone, as are hosts we've not yet seen
+// enough reports from to know how often they run.
+//
+func updateLate(runs int) {
+
+	//
+	// Ensure we have a DB-handle
+	//
+	if db == nil {
+		return
+	}
+
+	if runs < 1 {
+		return
+	}
+
+	moveHosts("late", "run_interval > 0 AND state IN ('changed', 'unchanged', 'failed') AND last_seen + ( run_interval * ? ) < ?", runs, time.Now().Unix())
+}
+
+//
+// Move the hosts matching the given condition into a new state, and
+// record the transition each has made.
+//
+func moveHosts(state string, condition string, args ...interface{}) error {
+
+	rows, err := db.Query("SELECT host_id, fqdn, role, state FROM hosts WHERE "+condition, args...)
+	if err != nil {
+		return err
+	}
+
+	type moved struct {
+		id    int
+		fqdn  string
+		role  sql.NullString
+		state string
+	}
+
+	var hosts []moved
+	for rows.Next() {
+		var tmp moved
+		err = rows.Scan(&tmp.id, &tmp.fqdn, &tmp.role, &tmp.state)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		hosts = append(hosts, tmp)
+	}
+	rows.Close()
+
+	now := time.Now().Unix()
+	for _, h := range hosts {
+
+		//
+		// Only record the move if the host hasn't reported since
+		// we looked at it.
+		//
+		res, err := db.Exec("UPDATE hosts SET state=? WHERE host_id=? AND state=?", state, h.id, h.state)
+		if err != nil {
+			return err
+		}
+		count, _ := res.RowsAffected()
+		if count < 1 {
+			continue
+		}
+
+		_, err = db.Exec("INSERT INTO state_transitions(host_id, fqdn, role, from_state, to_state, changed_at) VALUES(?,?,?,?,?,?)", h.id, h.fqdn, h.role.String, h.state, state, now)
+		if err != nil {
+			return err
+		}
+
+		notifyTransition(newTransition(h.fqdn, h.role.String, h.state, state, now))
+	}
+	return nil
+}
+
+//
+// Create a transition from the given state, to another, at a time.
+//
+func newTransition(fqdn string, role string, from string, to string, at int64) PuppetTransition {
+	return PuppetTransition{Fqdn: fqdn,
+		Role:  role,
+		From:  from,
+		To:    to,
+		Epoch: strconv.FormatInt(at, 10),
+		Ago:   timeRelative(strconv.FormatInt(at, 10)),
+		At:    time.Unix(at, 0).Format("2006-01-02 15:04:05")}
+}
+
+//
+// Purge Orphan hosts.
+//
+func purgeOrphans(days int) {
+
+	//
+	// Ensure we have a DB-handle
+	//
+	if db == nil {
+		return
+	}
+
+	//
+	// The threshold which marks the difference between
+	// "current" and "orphaned"
+	//
+	// Here we set it to 4.5 days, which should be long
+	// enough to cover any hosts that were powered-off over
+	// a weekend.  (Friday + Saturday + Sunday + slack).
+	//
+	threshold := days * (24 * 60 * 60)
+
+	_, err := db.Exec("DELETE FROM hosts WHERE last_seen < ? AND pinned = 0", time.Now().Unix() - int64(threshold))
+	if err != nil {
+		return 
+	}
+}
+
+//
+// Prune history.
+//
+func pruneHistory() {
+
+	//
+	// Ensure we have a DB-handle
+	//
+	if db == nil {
+		return
+	}
+
+	//
+	// Keep as many days as our daily rollups.
+	//
+	daily, _ := historyResolution("day")
+	history := int(daily.Retention.Hours() / 24)
+
+	var count int
+	row := db.QueryRow("SELECT COUNT(*) FROM history")
+	row.Scan(&count)
+
+	if count <= history {
+		return
+	}
+
+	purge := (count - history)
+
+
+
+	sql_select := "SELECT id FROM history ORDER BY date LIMIT ?"
+
+	//
+	// Get the data.
+	//
+	stmt, err := db.Prepare(sql_select)
+	if err != nil {
+		return
+	}
+
+	rows, err := stmt.Query(purge)
+	if err != nil {
+		return
+	}
+	defer stmt.Close()
+	defer rows.Close()
+
+	ids := ""
+
+	//
+	// For each row in the result-set
+	//
+	for rows.Next() {
+		var id int
+
+		err := rows.Scan(&id)
+		if err == nil {
+			if strings.Compare(ids, "") == 0 {
+				ids = strconv.Itoa(id)
+			} else {
+				ids = ids + ", " + strconv.Itoa(id)
+			}
+		}
+	}
+
+	sql_statment := "DELETE FROM history WHERE id IN (" + ids + ")"
+	_, err = db.Exec(sql_statment)
+
+	if err != nil {
+		fmt.Printf("Error purging history: %s\n", err)		
+	}
+		
+}
+
+//
+// update orphan hosts.
+//
+func updateHistory(date int64, state string) error {
+
+	//
+	// Ensure we have a DB-handle
+	//
+	if db == nil {
+		return errors.New("SetupDB not called")
+	}
+
+
+	sql_lookup := ""
+	if strings.Compare(db_type, "sqlite3") == 0 {
+		sql_lookup = "SELECT id FROM history WHERE date = strftime('%Y/%m/%d', DATE(?, 'unixepoch'))"
+	} else if strings.Compare(db_type, "mysql") == 0 {
+		sql_lookup = "SELECT id FROM history WHERE date = from_unixtime(?, '%Y/%m/%d')"
+	}
+
+	id := 0
+
+	row := db.QueryRow(sql_lookup, date)
+	err := row.Scan(&id)
+
+	sql_insert := ""
+	if err == sql.ErrNoRows {
+		if strings.Compare(db_type, "sqlite3") == 0 {
+			sql_insert = "INSERT INTO history(date, failed, changed, unchanged) VALUES (strftime('%Y/%m/%d', DATE(?, 'unixepoch')), 0, 0, 0)"
+		} else if strings.Compare(db_type, "mysql") == 0 {
+			sql_insert = "INSERT INTO history(date, failed, changed, unchanged) VALUES (from_unixtime(?, '%Y/%m/%d'), 0, 0, 0)"
+		}
+		_, err := db.Exec(sql_insert, date)
+		if err != nil {
+			fmt.Printf("there - %s", err)
+		}
+
+		row := db.QueryRow(sql_lookup, date)
+		err = row.Scan(&id)
+
+		if err == sql.ErrNoRows {
+			fmt.Printf("here - %s", err)
+		}
+	}
+
+
+	failed := 0
+	changed := 0
+	unchanged := 0
+
+	switch {
+		case strings.Compare(state, "failed") == 0: 
+			failed = 1
+		case strings.Compare(state, "changed") == 0: 
+			changed = 1
+		case strings.Compare(state, "unchanged") == 0: 
+			unchanged = 1
+	}
+
+	sql_update := "UPDATE history SET failed = failed + ?, changed = changed + ?, unchanged = unchanged + ? WHERE id = ?"
+
+	_, err = db.Exec(sql_update, failed, changed, unchanged, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+//
+// Count a report in the rollups of our history: in the bucket of each
+// resolution it was executed within, for all nodes, and for the nodes
+// with its role, environment, and branch.
+//
+func updateRollups(fqdn string, role string, environment string, branch string, state string, at int64) error {
+
+	//
+	// Ensure we have a DB-handle
+	//
+	if db == nil {
+		return errors.New("SetupDB not called")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	err = rollupReport(tx, fqdn, role, environment, branch, state, at, 0)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+//
+// Count a report in the rollups of our history, within the given
+// transaction.  Buckets which began before `after` are left alone.
+//
+func rollupReport(tx *sql.Tx, fqdn string, role string, environment string, branch string, state string, at int64, after int64) error {
+
+	sql_node := "INSERT OR IGNORE INTO rollup_nodes(resolution, bucket, fqdn, state) VALUES(?,?,?,?)"
+	if strings.Compare(db_type, "mysql") == 0 {
+		sql_node = "INSERT IGNORE INTO rollup_nodes(resolution, bucket, fqdn, state) VALUES(?,?,?,?)"
+	}
+
+	slices := map[string]string{"": "", "role": role, "environment": environment, "branch": branch}
+
+	for _, r := range HistoryResolutions {
+		bucket := r.bucket(at)
+		if bucket < after {
+			continue
+		}
+
+		//
+		// Is this the first time the node has reported this state
+		// within the bucket?
+		//
+		nodes := 0
+		result, err := tx.Exec(sql_node, r.Seconds, bucket, fqdn, state)
+		if err != nil {
+			return err
+		}
+		if count, _ := result.RowsAffected(); count > 0 {
+			nodes = 1
+		}
+
+		for dimension, name := range slices {
+			if len(dimension) > 0 && len(name) < 1 {
+				continue
+			}
+
+			result, err = tx.Exec("UPDATE rollups SET reports = reports + 1, nodes = nodes + ? WHERE resolution = ? AND bucket = ? AND dimension = ? AND name = ? AND state = ?",
+				nodes, r.Seconds, bucket, dimension, name, state)
+			if err != nil {
+				return err
+			}
+			if count, _ := result.RowsAffected(); count > 0 {
+				continue
+			}
+
+			_, err = tx.Exec("INSERT INTO rollups(resolution, bucket, dimension, name, state, reports, nodes) VALUES(?,?,?,?,?,1,?)",
+				r.Seconds, bucket, dimension, name, state, nodes)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+//
+// Get the rollups of our history, at the given resolution, between the
+// given times.
+//
+// If a `dimension` is given then only the rollups of the nodes with the
+// given `name` in that dimension are returned, or, if no name is given,
+// those of every name.
+//
+func getRollups(r HistoryResolution, dimension string, name string, from int64, to int64) ([]PuppetRollup, error) {
+
+	//
+	// Ensure we have a DB-handle
+	//
+	if db == nil {
+		return nil, errors.New("SetupDB not called")
+	}
+
+	sql_select := "SELECT name, bucket, state, reports, nodes FROM rollups WHERE resolution = ? AND dimension = ? AND bucket >= ? AND bucket < ?"
+	args := []interface{}{r.Seconds, dimension, r.bucket(from), to}
+	if len(dimension) < 1 || len(name) > 0 {
+		sql_select += " AND name = ?"
+		args = append(args, name)
+	}
+	sql_select += " ORDER BY name, bucket"
+
+	rows, err := db.Query(sql_select, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []PuppetRollup
+	for rows.Next() {
+		var n string
+		var bucket int64
+		var state string
+		var reports, nodes int
+
+		err = rows.Scan(&n, &bucket, &state, &reports, &nodes)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(res) < 1 || res[len(res)-1].Name != n || res[len(res)-1].Bucket != bucket {
+			res = append(res, PuppetRollup{Name: n, Bucket: bucket, Date: r.label(bucket)})
+		}
+		x := &res[len(res)-1]
+
+		switch state {
+		case "failed":
+			x.Failed, x.FailedNodes = reports, nodes
+		case "changed":
+			x.Changed, x.ChangedNodes = reports, nodes
+		case "unchanged":
+			x.Unchanged, x.UnchangedNodes = reports, nodes
+		}
+	}
+	err = rows.Err()
+	return res, err
+}
+
+//
+// Remove the rollups of our history which are older than the retention
+// of their resolution.
+//
+func pruneRollups() error {
+
+	//
+	// Ensure we have a DB-handle
+	//
+	if db == nil {
+		return errors.New("SetupDB not called")
+	}
+
+	now := time.Now().Unix()
+	for _, r := range HistoryResolutions {
+		before := now - int64(r.Retention.Seconds())
+
+		_, err := db.Exec("DELETE FROM rollups WHERE resolution = ? AND bucket < ?", r.Seconds, before)
+		if err != nil {
+			return err
+		}
+		_, err = db.Exec("DELETE FROM rollup_nodes WHERE resolution = ? AND bucket < ?", r.Seconds, before)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//
+// Get the nodes with a given role, or on a given branch, from those
+// shown upon our index.
+//
+func getSliceNodes(dimension string, name string) ([]PuppetRuns, error) {
+
+	nodes, err := getIndexNodes()
+	if err != nil {
+		return nil, err
+	}
+
+	var res []PuppetRuns
+	for _, n := range nodes {
+		if (dimension == "role" && n.Role == name) || (dimension == "branch" && n.Branch == name) {
+			res = append(res, n)
+		}
+	}
+	return res, nil
+}
+
+//
+// Have we recorded the report stored in the given file?
+//
+func reportStored(yaml_file string) (bool, error) {
+
+	//
+	// Ensure we have a DB-handle
+	//
+	if db == nil {
+		return false, errors.New("SetupDB not called")
+	}
+
+	var count int
+	row := db.QueryRow("SELECT COUNT(*) FROM reports WHERE yaml_file = ?", yaml_file)
+	err := row.Scan(&count)
+	return count > 0, err
+}
+
+//
+// Count the number of reports we have.
+//
+func countReports() (int, error) {
+
+	//
+	// Ensure we have a DB-handle
+	//
+	if db == nil {
+		return 0, errors.New("SetupDB not called")
+	}
+
+	var count int
+	row := db.QueryRow("SELECT COUNT(*) FROM reports")
+	err := row.Scan(&count)
+	return count, err
+}
+
+//
+// Count the number of reports we have reaped.
+//
+func countUnchangedAndReapedReports() (int, error) {
+
+	//
+	// Ensure we have a DB-handle
+	//
+	if db == nil {
+		return 0, errors.New("SetupDB not called")
+	}
+
+	var count int
+	row := db.QueryRow("SELECT COUNT(*) FROM reports WHERE yaml_file='pruned'")
+	err := row.Scan(&count)
+	return count, err
+}
+
+//
+// Return the contents of the YAML file which was associated
+// with the given report-ID.
+//
+func getYAML(prefix string, id string) ([]byte, error) {
+
+	//
+	// Ensure we have a DB-handle
+	//
+	if db == nil {
+		return nil, errors.New("SetupDB not called")
+	}
+
+	var path string
+	row := db.QueryRow("SELECT yaml_file FROM reports WHERE id=?", id)
+	err := row.Scan(&path)
+
+	switch {
+	case err == sql.ErrNoRows:
+	case err != nil:
+		return nil, errors.New("report not found")
+	default:
+	}
+
+	//
+	// Read the file content, first of all adding in the
+	// prefix.
+	//
+	// (Because our reports are stored as relative paths
+	// such as "$host/$time", rather than absolute paths
+	// such as "reports/$host/$time".)
+	//
+	if len(path) > 0 {
+		path = filepath.Join(prefix, path)
+		content, err := ioutil.ReadFile(path)
+		return content, err
+	}
+	return nil, errors.New("failed to find report with specified ID")
+}
+
+//
+// Get the data which is shown on our index page
+//
+//  * The node-name.
+//  * The status.
+//  * The last-seen time.
+//
+func getIndexNodes() ([]PuppetRuns, error) {
+
+	//
+	// Our return-result.
+	//
+	var NodeList []PuppetRuns
+
+	//
+	// Ensure we have a DB-handle
+	//
+	if db == nil {
+		return nil, errors.New("SetupDB not called")
+	}
+
+	sql := "SELECT fqdn, state, runtime, last_seen, branch, build_time, role, pinned, flap_score, environment, fingerprint FROM hosts;"
+	
+	//
+	// Select the status - for nodes seen in the past 24 hours.
+	//
+	rows, err := db.Query(sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	//
+	// For each row in the result-set
+	//
+	// Parse into a structure and add to the list.
+	//
+	for rows.Next() {
+		var tmp PuppetRuns
+		var at string
+		var builtAt string
+		var pinned int64
+
+		err := rows.Scan(&tmp.Fqdn, &tmp.State, &tmp.Runtime, &at, &tmp.Branch, &builtAt , &tmp.Role, &pinned, &tmp.FlapScore, &tmp.Environment, &tmp.Fingerprint)
+		if err != nil {
+			return nil, err
+		}
+
+		tmp.Flapping = tmp.FlapScore > 0 && tmp.FlapScore >= FlapThreshold
+
+		tmp.Pinned = "No"
+		if pinned == 1{
+			tmp.Pinned = "Yes"
+		}
+
+		//
+		// At this point `at` is a string containing seconds past
+		// the epoch.
+		//
+		// We want to parse that into a string `At` which will
+		// contain the literal time, and also the relative
+		// time "Ago"
+		//
+		tmp.BuiltEpoch = builtAt
+		tmp.Epoch = at
+		tmp.Ago = timeRelative(at)
+		if strings.Compare(builtAt, "0") == 0 {
+			tmp.BuiltAgo = "-"
+			tmp.BuiltAt = "-"
+		}else{
+			tmp.BuiltAgo = timeRelative(builtAt)
+			ib, _ := strconv.ParseInt(builtAt, 10, 64)
+			tmp.BuiltAt = time.Unix(ib, 0).Format("2006-01-02 15:04:05")
+		}
+
+		//
+		i, _ := strconv.ParseInt(at, 10, 64)
+		tmp.At = time.Unix(i, 0).Format("2006-01-02 15:04:05")
+
+		//
+		// Add the new record.
+		//
+		NodeList = append(NodeList, tmp)
+
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	err = applyAcks(NodeList)
+	if err != nil {
+		return nil, err
+	}
+
+	err = applyMaintenance(NodeList)
+	if err != nil {
+		return nil, err
+	}
+
+	return NodeList, nil
+}
+
+//
+// Return the state of our nodes.
+//
+func getStates() ([]PuppetState, error) {
+
+	//
+	// Get the nodes.
+	//
+	NodeList, err := getIndexNodes()
+	if err != nil {
+		return nil, err
+	}
+
+	return countStates(NodeList), nil
+}
+
+//
+// Count the number of the given nodes in each state.
+//
+func countStates(NodeList []PuppetRuns) []PuppetState {
+
+	//
+	// Create a map to hold state.
+	//
+	states := make(map[string]int)
+
+	//
+	// Each known-state will default to being empty.
+	//
+	states["changed"] = 0
+	states["unchanged"] = 0
+	states["failed"] = 0
+	states["late"] = 0
+	states["orphaned"] = 0
+	states["acknowledged"] = 0
+
+	//
+	// Count the nodes we encounter, such that we can
+	// create a %-figure for each distinct-state.
+	//
+	var total int
+
+	//
+	// Count the states.
+	//
+	for _, o := range NodeList {
+		if o.State == "failed" && o.Ack != nil {
+			states["acknowledged"]++
+		} else {
+			states[o.State]++
+		}
+		total++
+	}
+
+	//
+	// Our return-result
+	//
+	var data []PuppetState
+
+	//
+	// Get the distinct keys/states in a sorted order.
+	//
+	var keys []string
+	for name := range states {
+		keys = append(keys, name)
+	}
+	sort.Strings(keys)
+
+	//
+	// Now for each key ..
+	//
+	for _, name := range keys {
+
+		var tmp PuppetState
+		tmp.State = name
+		tmp.Count = states[name]
+		tmp.Percentage = 0
+
+		// Percentage has to be capped :)
+		if total != 0 {
+			c := float64(states[name])
+			tmp.Percentage = (c / float64(total)) * 100
+		}
+		data = append(data, tmp)
+	}
+
+	return data
+}
+
+//
+// Get the summary-details of the runs against a given host
+//
+func getReports(fqdn string) ([]PuppetReportSummary, error) {
+
+	//
+	// Ensure we have a DB-handle
+	//
+	if db == nil {
+		return nil, errors.New("SetupDB not called")
+	}
+
+	//
+	// Select the status.
+	//
+	stmt, err := db.Prepare("SELECT id, fqdn, state, executed_at, runtime, failed, changed, total, yaml_file, branch, build_time, role FROM reports WHERE fqdn=? ORDER by executed_at DESC, id DESC LIMIT 50")
+	if err != nil {
+		return nil, err
+	}
+	rows, err := stmt.Query(fqdn)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	defer rows.Close()
+
+	//
+	// We'll return a list of these hosts.
+	//
+	var NodeList []PuppetReportSummary
+
+	//
+	// For each row in the result-set
+	//
+	// Parse into a structure and add to the list.
+	//
+	for rows.Next() {
+		var tmp PuppetReportSummary
+		var at string
+		var builtAt string
+		err := rows.Scan(&tmp.ID, &tmp.Fqdn, &tmp.State, &at, &tmp.Runtime, &tmp.Failed, &tmp.Changed, &tmp.Total, &tmp.YamlFile, &tmp.Branch, &builtAt, &tmp.Role)
+		if err != nil {
+			return nil, err
+		}
+
+		//
+		// At this point `at` is a string containing seconds past
+		// the epoch.
+		//
+		// We want to parse that into a string `At` which will
+		// contain the literal time, and also the relative
+		// time "Ago"
+		//
+		tmp.Ago = timeRelative(at)
+		if strings.Compare(builtAt, "0") == 0 {
+			tmp.BuiltAgo = "-"
+			tmp.BuiltAt = "-"
+		}else{
+			tmp.BuiltAgo = timeRelative(builtAt)
+			ib, _ := strconv.ParseInt(builtAt, 10, 64)
+			tmp.BuiltAt = time.Unix(ib, 0).Format("2006-01-02 15:04:05")
+		}
+
+		i, _ := strconv.ParseInt(at, 10, 64)
+		tmp.At = time.Unix(i, 0).Format("2006-01-02 15:04:05")
+
+		// Add the result of this fetch to our list.
+		NodeList = append(NodeList, tmp)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(NodeList) < 1 {
+		return nil, errors.New("Failed to find reports for " + fqdn)
+
+	}
+	return NodeList, nil
+}
+
+//
+// Get data for our stacked bar-graph
+//
+func getHistory() ([]PuppetHistory, error) {
+
+	//
+	// Ensure we have a DB-handle
+	//
+	if db == nil {
+		return nil, errors.New("SetupDB not called")
+	}
+
+	//
+	// Our result.
+	//
+	var res []PuppetHistory
+
+	sql := "SELECT date, failed, changed, unchanged FROM history ORDER BY date"
+
+	//
+	// Get the data.
+	//
+	stmt, err := db.Prepare(sql)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := stmt.Query()
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+	defer rows.Close()
+
+	//
+	// For each row in the result-set
+	//
+	for rows.Next() {
+		var d string
+		var f int
+		var c int
+		var u int
+
+		err := rows.Scan(&d, &f, &c, &u)
+		if err != nil {
+			return nil, errors.New("failed to scan SQL")
+		}
+
+		var x PuppetHistory
+		x.Changed = strconv.Itoa(c)
+		x.Unchanged = strconv.Itoa(u)
+		x.Failed = strconv.Itoa(f)
+		x.Date = d
+
+		res = append(res, x)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return res, err
+
+}
+
+//
+// Get the state-transitions of the given host, most recent first.
+//
+func getTransitions(fqdn string) ([]PuppetTransition, error) {
+
+	//
+	// Ensure we have a DB-handle
+	//
+	if db == nil {
+		return nil, errors.New("SetupDB not called")
+	}
+
+	rows, err := db.Query("SELECT fqdn, role, from_state, to_state, changed_at FROM state_transitions WHERE fqdn = ? ORDER BY changed_at DESC, id DESC", fqdn)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []PuppetTransition
+
+	for rows.Next() {
+		var tmp PuppetTransition
+		var at string
+
+		err = rows.Scan(&tmp.Fqdn, &tmp.Role, &tmp.From, &tmp.To, &at)
+		if err != nil {
+			return nil, err
+		}
+
+		tmp.Epoch = at
+		tmp.Ago = timeRelative(at)
+		i, _ := strconv.ParseInt(at, 10, 64)
+		tmp.At = time.Unix(i, 0).Format("2006-01-02 15:04:05")
+
+		res = append(res, tmp)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+//
+// Get the reliability figures for each node, or for each role if
+// `byRole` is set.
+//
+// A failure starts when a node moves into the failed state, and it
+// is repaired when the node next reports as changed or unchanged.  The
+// MTTR is the mean time taken to repair, and the MTBF is the mean time
+// between the start of successive failures.
+//
+func getReliability(byRole bool) ([]PuppetReliability, error) {
+
+	//
+	// Get the nodes, which gives us their current state and role.
+	//
+	NodeList, err := getIndexNodes()
+	if err != nil {
+		return nil, err
+	}
+
+	//
+	// The running totals we keep for each node, or role.
+	//
+	type totals struct {
+		stats      PuppetReliability
+		repairs    int64
+		repairTime int64
+		gaps       int64
+		gapTime    int64
+	}
+	results := make(map[string]*totals)
+
+	role := make(map[string]string)
+	for _, n := range NodeList {
+		role[n.Fqdn] = n.Role
+
+		name := n.Fqdn
+		if byRole {
+			name = n.Role
+		}
+		if results[name] == nil {
+			results[name] = &totals{stats: PuppetReliability{Name: name}}
+		}
+		results[name].stats.Nodes++
+	}
+
+	//
+	// Failures which start within a maintenance window are expected,
+	// so they don't count against a node.
+	//
+	windows, err := getMaintenance()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query("SELECT fqdn, to_state, changed_at FROM state_transitions ORDER BY fqdn, changed_at, id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	//
+	// When the node we're looking at last started to fail, and when
+	// the current failure started, if it is still failing.
+	//
+	var current string
+	var lastFailure int64
+	var failing int64
+	var expected bool
+
+	now := time.Now().Unix()
+
+	for rows.Next() {
+		var fqdn string
+		var to string
+		var at int64
+
+		err = rows.Scan(&fqdn, &to, &at)
+		if err != nil {
+			return nil, err
+		}
+
+		name := fqdn
+		if byRole {
+			name = role[fqdn]
+		}
+
+		//
+		// Skip transitions for hosts we no longer know about.
+		//
+		t := results[name]
+		if t == nil {
+			continue
+		}
+
+		if fqdn != current {
+			current = fqdn
+			lastFailure = 0
+			failing = 0
+			expected = false
+		}
+
+		switch to {
+		case "failed":
+			if failing != 0 {
+				continue
+			}
+			failing = at
+			expected = maintenanceWindow(windows, fqdn, role[fqdn], at) != nil
+			if expected {
+				continue
+			}
+			t.stats.Failures++
+			if lastFailure != 0 {
+				t.gaps++
+				t.gapTime += at - lastFailure
+			}
+			lastFailure = at
+		case "changed", "unchanged":
+			if failing != 0 && expected {
+				failing = 0
+				continue
+			}
+			if failing != 0 {
+				t.repairs++
+				t.repairTime += at - failing
+				failing = 0
+			}
+		}
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	//
+	// Now work out the current failure-streak of any failing node.
+	//
+	for _, n := range NodeList {
+		if n.State != "failed" {
+			continue
+		}
+
+		name := n.Fqdn
+		if byRole {
+			name = n.Role
+		}
+
+		var streak int
+		row := db.QueryRow("SELECT COUNT(*) FROM reports WHERE fqdn = ? AND state = 'failed' AND executed_at > (SELECT COALESCE(MAX(executed_at), 0) FROM reports WHERE fqdn = ? AND state != 'failed')", n.Fqdn, n.Fqdn)
+		err = row.Scan(&streak)
+		if err != nil {
+			return nil, err
+		}
+
+		var since int64
+		row = db.QueryRow("SELECT COALESCE(MAX(changed_at), 0) FROM state_transitions WHERE fqdn = ? AND to_state = 'failed'", n.Fqdn)
+		err = row.Scan(&since)
+		if err != nil {
+			return nil, err
+		}
+
+		t := results[name]
+		if streak > t.stats.Streak {
+			t.stats.Streak = streak
+		}
+		if since != 0 && now-since > t.stats.StreakSeconds {
+			t.stats.StreakSeconds = now - since
+		}
+	}
+
+	//
+	// Build up the results, sorted by name.
+	//
+	var res []PuppetReliability
+	for _, t := range results {
+		if t.repairs > 0 {
+			t.stats.MTTR = t.repairTime / t.repairs
+		}
+		if t.gaps > 0 {
+			t.stats.MTBF = t.gapTime / t.gaps
+		}
+		res = append(res, t.stats)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+
+	return res, nil
+}
+
+//
+// Find the resources which change on most runs of a node, or of the
+// nodes with a given role if `byRole` is set.
+//
+// Only runs from the past `days` days are considered, and a resource
+// is included if it changed in more than `threshold` percent of them.
+// The results are ranked by the number of nodes affected.
+//
+func getNonIdempotent(days int, threshold int, byRole bool) ([]PuppetNonIdempotent, error) {
+
+	//
+	// Ensure we have a DB-handle
+	//
+	if db == nil {
+		return nil, errors.New("SetupDB not called")
+	}
+
+	since := time.Now().Unix() - int64(days*24*60*60)
+
+	//
+	// Count the runs of each node, and each role.
+	//
+	rows, err := db.Query("SELECT fqdn, role, COUNT(*) FROM reports WHERE executed_at > ? GROUP BY fqdn, role", since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	runs := make(map[string]int)
+	for rows.Next() {
+		var fqdn string
+		var role sql.NullString
+		var count int
+
+		err = rows.Scan(&fqdn, &role, &count)
+		if err != nil {
+			return nil, err
+		}
+
+		if byRole {
+			runs[role.String] += count
+		} else {
+			runs[fqdn] += count
+		}
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	//
+	// Now count the runs in which each resource changed.
+	//
+	changes, err := db.Query("SELECT fqdn, role, resource_type, title, file, line, COUNT(*) FROM changed_resources WHERE executed_at > ? GROUP BY fqdn, role, resource_type, title, file, line", since)
+	if err != nil {
+		return nil, err
+	}
+	defer changes.Close()
+
+	//
+	// Results are keyed by resource, or by role and resource.
+	//
+	type totals struct {
+		res     PuppetNonIdempotent
+		changed int
+		runs    int
+		nodes   map[string]bool
+	}
+	results := make(map[string]*totals)
+
+	for changes.Next() {
+		var fqdn string
+		var role sql.NullString
+		var r Resource
+		var count int
+
+		err = changes.Scan(&fqdn, &role, &r.Type, &r.Name, &r.File, &r.Line, &count)
+		if err != nil {
+			return nil, err
+		}
+
+		key := r.Type + "[" + r.Name + "]"
+		total := runs[fqdn]
+		if byRole {
+			key = role.String + "\x00" + key
+			total = runs[role.String]
+		} else if total < 1 || (count*100)/total <= threshold {
+			//
+			// When looking at nodes each node must pass
+			// the threshold by itself.
+			//
+			continue
+		}
+
+		t := results[key]
+		if t == nil {
+			t = &totals{res: PuppetNonIdempotent{Type: r.Type, Name: r.Name, File: r.File, Line: r.Line}, nodes: make(map[string]bool)}
+			if byRole {
+				t.res.Role = role.String
+				t.runs = total
+			}
+			results[key] = t
+		}
+
+		t.changed += count
+		if !byRole {
+			t.runs += total
+		}
+		if !t.nodes[fqdn] {
+			t.nodes[fqdn] = true
+			t.res.Fqdns = append(t.res.Fqdns, fqdn)
+		}
+	}
+	err = changes.Err()
+	if err != nil {
+		return nil, err
+	}
 
-	tx, err := db.Begin()
-	if err != nil {
-		return err
+	var res []PuppetNonIdempotent
+	for _, t := range results {
+		if t.runs < 1 {
+			continue
+		}
+		t.res.Nodes = len(t.nodes)
+		t.res.Percentage = (float64(t.changed) / float64(t.runs)) * 100
+
+		if byRole && int(t.res.Percentage) <= threshold {
+			continue
+		}
+
+		sort.Strings(t.res.Fqdns)
+		res = append(res, t.res)
 	}
-	
-	report_stmt, err := tx.Prepare("INSERT INTO reports(fqdn,host_id,state,yaml_file,executed_at,runtime, failed, changed, total, skipped, role, branch, build_time) values(?,?,?,?,?,?,?,?,?,?,?,?,?)")
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Nodes != res[j].Nodes {
+			return res[i].Nodes > res[j].Nodes
+		}
+		if res[i].Percentage != res[j].Percentage {
+			return res[i].Percentage > res[j].Percentage
+		}
+		return res[i].Role+res[i].Type+res[i].Name < res[j].Role+res[j].Type+res[j].Name
+	})
+
+	return res, nil
+}
+
+//
+// Get the runtime baselines of each node, or of each role if `byRole`
+// is set, from the runs made in the past `days` days.
+//
+// Nodes are flagged as slow if their most recent run took more than
+// SlowFactor times their median runtime.  The results are sorted with
+// the slowest first.
+//
+func getRuntimeBaselines(days int, byRole bool) ([]PuppetRuntime, error) {
+
+	//
+	// Ensure we have a DB-handle
+	//
+	if db == nil {
+		return nil, errors.New("SetupDB not called")
+	}
+
+	since := time.Now().Unix() - int64(days*24*60*60)
+
+	rows, err := db.Query("SELECT fqdn, role, runtime FROM reports WHERE executed_at > ? ORDER BY executed_at, id", since)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer report_stmt.Close()
+	defer rows.Close()
 
-	report_stmt.Exec(data.Fqdn,
-		host_id,
-		data.State,
-		path,
-		at,
-		data.Runtime,
-		data.Failed,
-		data.Changed,
-		data.Total,
-		data.Skipped,
-		data.Role,
-		data.Branch,
-		data.BuildTime)
+	runtimes := make(map[string][]float64)
+	latest := make(map[string]float64)
 
-	host_stmt, err := tx.Prepare("UPDATE hosts SET last_seen = ?, state = ?, runtime = ?, role = ?, branch = ? , build_time = ? WHERE host_id = ?")
+	for rows.Next() {
+		var fqdn string
+		var role sql.NullString
+		var runtime sql.NullFloat64
+
+		err = rows.Scan(&fqdn, &role, &runtime)
+		if err != nil {
+			return nil, err
+		}
+		if !runtime.Valid {
+			continue
+		}
+
+		name := fqdn
+		if byRole {
+			name = role.String
+		}
+		runtimes[name] = append(runtimes[name], runtime.Float64)
+		latest[name] = runtime.Float64
+	}
+	err = rows.Err()
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer host_stmt.Close()
 
-	host_stmt.Exec(
-		at,
-		data.State,
-		data.Runtime,
-		data.Role,
-		data.Branch,
-		data.BuildTime,
-		host_id)
-	tx.Commit()
+	var res []PuppetRuntime
+	for name, values := range runtimes {
+		var tmp PuppetRuntime
+		tmp.Name = name
+		tmp.Runs = len(values)
+		tmp.Median = percentile(values, 50)
+		tmp.P95 = percentile(values, 95)
+		if !byRole {
+			tmp.Latest = latest[name]
+			tmp.Slow = tmp.Latest > tmp.Median*SlowFactor
+		}
+		res = append(res, tmp)
+	}
 
-	updateHistory(at, data.State)
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Median != res[j].Median {
+			return res[i].Median > res[j].Median
+		}
+		return res[i].Name < res[j].Name
+	})
 
-	return nil
+	return res, nil
 }
 
 //
-// Get host id.
+// Get the runtime baseline of a single node, from the runs made in the
+// past `days` days.
 //
-func getHostId(fqdn string) (int, error) {
+func getNodeBaseline(fqdn string, days int) (PuppetRuntime, error) {
+
+	var res PuppetRuntime
+	res.Name = fqdn
 
 	//
 	// Ensure we have a DB-handle
 	//
 	if db == nil {
-		return 0, errors.New("SetupDB not called")
+		return res, errors.New("SetupDB not called")
 	}
 
-	var host_id int
-	row := db.QueryRow("SELECT host_id FROM hosts WHERE fqdn = ?", fqdn)
-	err := row.Scan(&host_id)
+	since := time.Now().Unix() - int64(days*24*60*60)
 
-	if err == sql.ErrNoRows {
-		return 0, nil
+	rows, err := db.Query("SELECT runtime FROM reports WHERE fqdn = ? AND executed_at > ? ORDER BY executed_at, id", fqdn, since)
+	if err != nil {
+		return res, err
 	}
-	return host_id, err
+	defer rows.Close()
+
+	var values []float64
+	for rows.Next() {
+		var runtime sql.NullFloat64
+
+		err = rows.Scan(&runtime)
+		if err != nil {
+			return res, err
+		}
+		if runtime.Valid {
+			values = append(values, runtime.Float64)
+		}
+	}
+	err = rows.Err()
+	if err != nil {
+		return res, err
+	}
+
+	if len(values) > 0 {
+		res.Runs = len(values)
+		res.Median = percentile(values, 50)
+		res.P95 = percentile(values, 95)
+		res.Latest = values[len(values)-1]
+		res.Slow = res.Latest > res.Median*SlowFactor
+	}
+	return res, nil
 }
 
 //
-// insert host.
+// Return the given percentile of the values, using the nearest-rank
+// method.
 //
-func createHost(fqdn string) (int, error) {
+func percentile(values []float64, p float64) float64 {
+
+	if len(values) < 1 {
+		return 0
+	}
+
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	rank := int(math.Ceil((p / 100) * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+//
+// Get the average time a node has spent upon each type of resource, in
+// the runs made in the past `days` days, slowest first.
+//
+func getNodeTypeTimes(fqdn string, days int) ([]ResourceTime, error) {
+
+	var res []ResourceTime
 
 	//
 	// Ensure we have a DB-handle
 	//
 	if db == nil {
-		return 0, errors.New("SetupDB not called")
+		return nil, errors.New("SetupDB not called")
 	}
 
-	_, err := db.Exec("INSERT INTO hosts(fqdn, state, last_seen, runtime, pinned, role, branch, build_time) VALUES (?, '', 0, 0, 0, '', '', 0)", fqdn)
+	since := time.Now().Unix() - int64(days*24*60*60)
+
+	rows, err := db.Query("SELECT resource_type, SUM(seconds), COUNT(DISTINCT report_id) FROM resource_times WHERE fqdn = ? AND executed_at > ? GROUP BY resource_type", fqdn, since)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
+	defer rows.Close()
 
-	return getHostId(fqdn)
+	total := 0.0
+	for rows.Next() {
+		var tmp ResourceTime
+		var runs int
+
+		err = rows.Scan(&tmp.Type, &tmp.Seconds, &runs)
+		if err != nil {
+			return nil, err
+		}
+		if runs > 0 {
+			tmp.Seconds = tmp.Seconds / float64(runs)
+		}
+		total += tmp.Seconds
+		res = append(res, tmp)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range res {
+		if total > 0 {
+			res[i].Percentage = (res[i].Seconds / total) * 100
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Seconds != res[j].Seconds {
+			return res[i].Seconds > res[j].Seconds
+		}
+		return res[i].Type < res[j].Type
+	})
+
+	return res, nil
 }
 
 //
-// update orphan hosts.
+// Summarise the reports which have been received since the given time.
 //
-func updateOrphans() {
+func getActivity(since int64) (PuppetActivity, error) {
+
+	var res PuppetActivity
 
 	//
 	// Ensure we have a DB-handle
 	//
 	if db == nil {
-		return
+		return res, errors.New("SetupDB not called")
 	}
 
+	rows, err := db.Query("SELECT runtime, failed, changed FROM reports WHERE executed_at > ?", since)
+	if err != nil {
+		return res, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var runtime sql.NullFloat64
+		var failed sql.NullInt64
+		var changed sql.NullInt64
+
+		err = rows.Scan(&runtime, &failed, &changed)
+		if err != nil {
+			return res, err
+		}
+
+		res.Reports++
+		res.Failed += failed.Int64
+		res.Changed += changed.Int64
+		if runtime.Valid {
+			res.Runtimes = append(res.Runtimes, runtime.Float64)
+		}
+	}
+	err = rows.Err()
+	return res, err
+}
+
+//
+// Pass each of the reports executed between the given times, inclusive,
+// to the given function, oldest first.
+//
+// These are used to rebuild metrics for times in the past, and are
+// read one at a time, rather than all at once.
+//
+func getReportPoints(since int64, until int64, fn func(PuppetReportPoint) error) error {
+
 	//
-	// The threshold which marks the difference between
-	// "current" and "orphaned"
-	//
-	// Here we set it to 4.5 days, which should be long
-	// enough to cover any hosts that were powered-off over
-	// a weekend.  (Friday + Saturday + Sunday + slack).
+	// Ensure we have a DB-handle
 	//
-	threshold := 3.5 * (24 * 60 * 60)
+	if db == nil {
+		return errors.New("SetupDB not called")
+	}
 
-	_, err := db.Exec("UPDATE hosts SET state = 'orphaned' WHERE last_seen < ?", time.Now().Unix() - int64(threshold))
+	rows, err := db.Query("SELECT fqdn, state, runtime, failed, changed, executed_at FROM reports WHERE executed_at >= ? AND executed_at <= ? ORDER BY executed_at, id", since, until)
 	if err != nil {
-		return 
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tmp PuppetReportPoint
+		var runtime sql.NullFloat64
+		var failed sql.NullInt64
+		var changed sql.NullInt64
+
+		err = rows.Scan(&tmp.Fqdn, &tmp.State, &runtime, &failed, &changed, &tmp.At)
+		if err != nil {
+			return err
+		}
+		tmp.Runtime = runtime.Float64
+		tmp.Failed = failed.Int64
+		tmp.Changed = changed.Int64
+
+		err = fn(tmp)
+		if err != nil {
+			return err
+		}
 	}
+	return rows.Err()
 }
 
 //
-// Purge Orphan hosts.
+// Get the time each node last reported before the given time, for the
+// nodes which reported before then.
 //
-func purgeOrphans(days int) {
+func getLastSeenBefore(before int64) (map[string]int64, error) {
 
 	//
 	// Ensure we have a DB-handle
 	//
 	if db == nil {
-		return
+		return nil, errors.New("SetupDB not called")
+	}
+
+	rows, err := db.Query("SELECT fqdn, MAX(executed_at) FROM reports WHERE executed_at < ? GROUP BY fqdn", before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make(map[string]int64)
+	for rows.Next() {
+		var fqdn string
+		var at int64
+		err = rows.Scan(&fqdn, &at)
+		if err != nil {
+			return nil, err
+		}
+		res[fqdn] = at
 	}
+	err = rows.Err()
+	return res, err
+}
+
+//
+// Queue a notification for delivery to the given target, via a channel
+// such as "webhook" or "email".
+//
+func addNotification(channel string, target string, payload string) error {
 
 	//
-	// The threshold which marks the difference between
-	// "current" and "orphaned"
+	// Ensure we have a DB-handle
 	//
-	// Here we set it to 4.5 days, which should be long
-	// enough to cover any hosts that were powered-off over
-	// a weekend.  (Friday + Saturday + Sunday + slack).
+	if db == nil {
+		return errors.New("SetupDB not called")
+	}
+
+	now := time.Now().Unix()
+	_, err := db.Exec("INSERT INTO notifications(channel, target, payload, attempts, next_attempt, created_at, last_error) VALUES(?,?,?,0,?,?,'')", channel, target, payload, now, now)
+	return err
+}
+
+//
+// Get the queued notifications, for the given channel, which are due
+// for delivery.
+//
+func getNotifications(channel string, now int64, limit int) ([]PuppetNotification, error) {
+
 	//
-	threshold := days * (24 * 60 * 60)
+	// Ensure we have a DB-handle
+	//
+	if db == nil {
+		return nil, errors.New("SetupDB not called")
+	}
 
-	_, err := db.Exec("DELETE FROM hosts WHERE last_seen < ? AND pinned = 0", time.Now().Unix() - int64(threshold))
+	rows, err := db.Query("SELECT id, target, payload, attempts, last_error FROM notifications WHERE channel = ? AND next_attempt <= ? ORDER BY id LIMIT ?", channel, now, limit)
 	if err != nil {
-		return 
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []PuppetNotification
+	for rows.Next() {
+		var tmp PuppetNotification
+		err = rows.Scan(&tmp.ID, &tmp.Target, &tmp.Payload, &tmp.Attempts, &tmp.LastError)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, tmp)
 	}
+	err = rows.Err()
+	return res, err
 }
 
 //
-// Prune history.
+// Remove a notification from the queue, once it has been delivered or
+// we've given up upon it.
 //
-func pruneHistory() {
+func deleteNotification(id int64) error {
 
 	//
 	// Ensure we have a DB-handle
 	//
 	if db == nil {
-		return
+		return errors.New("SetupDB not called")
 	}
 
-	history := 14
+	_, err := db.Exec("DELETE FROM notifications WHERE id=?", id)
+	return err
+}
 
-	var count int
-	row := db.QueryRow("SELECT COUNT(*) FROM history")
-	row.Scan(&count)
+//
+// Record a failed delivery, and when the next attempt should be made.
+//
+func retryNotification(id int64, attempts int, next int64, lastError string) error {
 
-	if count <= history {
-		return
+	//
+	// Ensure we have a DB-handle
+	//
+	if db == nil {
+		return errors.New("SetupDB not called")
 	}
 
-	purge := (count - history)
-
-
+	_, err := db.Exec("UPDATE notifications SET attempts=?, next_attempt=?, last_error=? WHERE id=?", attempts, next, lastError, id)
+	return err
+}
 
-	sql_select := "SELECT id FROM history ORDER BY date LIMIT ?"
+//
+// Get the transitions into the given state made since the given time.
+//
+func getRecentTransitions(state string, since int64) ([]PuppetTransition, error) {
 
 	//
-	// Get the data.
+	// Ensure we have a DB-handle
 	//
-	stmt, err := db.Prepare(sql_select)
-	if err != nil {
-		return
+	if db == nil {
+		return nil, errors.New("SetupDB not called")
 	}
 
-	rows, err := stmt.Query(purge)
+	rows, err := db.Query("SELECT fqdn, role, from_state, to_state, changed_at FROM state_transitions WHERE to_state = ? AND changed_at > ? ORDER BY changed_at, id", state, since)
 	if err != nil {
-		return
+		return nil, err
 	}
-	defer stmt.Close()
 	defer rows.Close()
 
-	ids := ""
-
-	//
-	// For each row in the result-set
-	//
+	var res []PuppetTransition
 	for rows.Next() {
-		var id int
+		var fqdn, from, to string
+		var role sql.NullString
+		var at int64
 
-		err := rows.Scan(&id)
-		if err == nil {
-			if strings.Compare(ids, "") == 0 {
-				ids = strconv.Itoa(id)
-			} else {
-				ids = ids + ", " + strconv.Itoa(id)
-			}
+		err = rows.Scan(&fqdn, &role, &from, &to, &at)
+		if err != nil {
+			return nil, err
 		}
+		res = append(res, newTransition(fqdn, role.String, from, to, at))
 	}
+	err = rows.Err()
+	return res, err
+}
 
-	sql_statment := "DELETE FROM history WHERE id IN (" + ids + ")"
-	_, err = db.Exec(sql_statment)
+//
+// Get the YAML files of the failed reports made since the given time,
+// newest first.
+//
+func getFailedReportFiles(since int64, limit int) ([]string, error) {
+
+	//
+	// Ensure we have a DB-handle
+	//
+	if db == nil {
+		return nil, errors.New("SetupDB not called")
+	}
 
+	rows, err := db.Query("SELECT yaml_file FROM reports WHERE state = 'failed' AND executed_at > ? AND yaml_file != 'pruned' ORDER BY executed_at DESC, id DESC LIMIT ?", since, limit)
 	if err != nil {
-		fmt.Printf("Error purging history: %s\n", err)		
+		return nil, err
 	}
-		
+	defer rows.Close()
+
+	var res []string
+	for rows.Next() {
+		var file string
+		err = rows.Scan(&file)
+		if err != nil {
+			return nil, err
+		}
+		if file != "" {
+			res = append(res, file)
+		}
+	}
+	err = rows.Err()
+	return res, err
 }
 
 //
-// update orphan hosts.
+// Get the alerts which are pending, or firing, along with those which
+// were resolved since the given time.  The most recent are first.
 //
-func updateHistory(date int64, state string) error {
+func getAlerts(resolvedSince int64) ([]PuppetAlert, error) {
 
 	//
 	// Ensure we have a DB-handle
 	//
 	if db == nil {
-		return errors.New("SetupDB not called")
+		return nil, errors.New("SetupDB not called")
 	}
 
-
-	sql_lookup := ""
-	if strings.Compare(db_type, "sqlite3") == 0 {
-		sql_lookup = "SELECT id FROM history WHERE date = strftime('%Y/%m/%d', DATE(?, 'unixepoch'))"
-	} else if strings.Compare(db_type, "mysql") == 0 {
-		sql_lookup = "SELECT id FROM history WHERE date = from_unixtime(?, '%Y/%m/%d')"
+	rows, err := db.Query("SELECT id, rule, state, value, message, started_at, fired_at, resolved_at FROM alerts WHERE state != 'resolved' OR resolved_at >= ? ORDER BY started_at DESC, id DESC", resolvedSince)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	id := 0
-
-	row := db.QueryRow(sql_lookup, date)
-	err := row.Scan(&id)
-
-	sql_insert := ""
-	if err == sql.ErrNoRows {
-		if strings.Compare(db_type, "sqlite3") == 0 {
-			sql_insert = "INSERT INTO history(date, failed, changed, unchanged) VALUES (strftime('%Y/%m/%d', DATE(?, 'unixepoch')), 0, 0, 0)"
-		} else if strings.Compare(db_type, "mysql") == 0 {
-			sql_insert = "INSERT INTO history(date, failed, changed, unchanged) VALUES (from_unixtime(?, '%Y/%m/%d'), 0, 0, 0)"
-		}
-		_, err := db.Exec(sql_insert, date)
+	var res []PuppetAlert
+	for rows.Next() {
+		var tmp PuppetAlert
+		err = rows.Scan(&tmp.ID, &tmp.Rule, &tmp.State, &tmp.Value, &tmp.Message, &tmp.StartedAt, &tmp.FiredAt, &tmp.ResolvedAt)
 		if err != nil {
-			fmt.Printf("there - %s", err)
-		}
-
-		row := db.QueryRow(sql_lookup, date)
-		err = row.Scan(&id)
-
-		if err == sql.ErrNoRows {
-			fmt.Printf("here - %s", err)
+			return nil, err
 		}
+		res = append(res, tmp)
 	}
+	err = rows.Err()
+	return res, err
+}
 
+//
+// Save an alert, creating it if it is new.
+//
+func saveAlert(alert *PuppetAlert) error {
 
-	failed := 0
-	changed := 0
-	unchanged := 0
-
-	switch {
-		case strings.Compare(state, "failed") == 0: 
-			failed = 1
-		case strings.Compare(state, "changed") == 0: 
-			changed = 1
-		case strings.Compare(state, "unchanged") == 0: 
-			unchanged = 1
+	//
+	// Ensure we have a DB-handle
+	//
+	if db == nil {
+		return errors.New("SetupDB not called")
 	}
 
-	sql_update := "UPDATE history SET failed = failed + ?, changed = changed + ?, unchanged = unchanged + ? WHERE id = ?"
+	if alert.ID != 0 {
+		_, err := db.Exec("UPDATE alerts SET state=?, value=?, message=?, fired_at=?, resolved_at=? WHERE id=?", alert.State, alert.Value, alert.Message, alert.FiredAt, alert.ResolvedAt, alert.ID)
+		return err
+	}
 
-	_, err = db.Exec(sql_update, failed, changed, unchanged, id)
+	result, err := db.Exec("INSERT INTO alerts(rule, state, value, message, started_at, fired_at, resolved_at) VALUES(?,?,?,?,?,?,?)", alert.Rule, alert.State, alert.Value, alert.Message, alert.StartedAt, alert.FiredAt, alert.ResolvedAt)
 	if err != nil {
 		return err
 	}
-
-	return nil
+	alert.ID, err = result.LastInsertId()
+	return err
 }
 
 //
-// Count the number of reports we have.
+// Remove an alert which never fired.
 //
-func countReports() (int, error) {
+func deleteAlert(id int64) error {
 
 	//
 	// Ensure we have a DB-handle
 	//
 	if db == nil {
-		return 0, errors.New("SetupDB not called")
+		return errors.New("SetupDB not called")
 	}
 
-	var count int
-	row := db.QueryRow("SELECT COUNT(*) FROM reports")
-	err := row.Scan(&count)
-	return count, err
+	_, err := db.Exec("DELETE FROM alerts WHERE id=?", id)
+	return err
 }
 
 //
-// Count the number of reports we have reaped.
+// Remove the alerts which were resolved before the given time.
 //
-func countUnchangedAndReapedReports() (int, error) {
+func pruneAlerts(before int64) error {
 
 	//
 	// Ensure we have a DB-handle
 	//
 	if db == nil {
-		return 0, errors.New("SetupDB not called")
+		return errors.New("SetupDB not called")
 	}
 
-	var count int
-	row := db.QueryRow("SELECT COUNT(*) FROM reports WHERE yaml_file='pruned'")
-	err := row.Scan(&count)
-	return count, err
+	_, err := db.Exec("DELETE FROM alerts WHERE state = 'resolved' AND resolved_at < ?", before)
+	return err
 }
 
 //
-// Return the contents of the YAML file which was associated
-// with the given report-ID.
+// Work out the fingerprint of a failure from its error messages.
 //
-func getYAML(prefix string, id string) ([]byte, error) {
+func failureFingerprint(messages []string) string {
+	if len(messages) < 1 {
+		return ""
+	}
+
+	sorted := append([]string{}, messages...)
+	sort.Strings(sorted)
+
+	sum := sha1.Sum([]byte(strings.Join(sorted, "\n")))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+//
+// Acknowledge a failing node, or fingerprint.
+//
+func addAck(ack *PuppetAck) error {
 
 	//
 	// Ensure we have a DB-handle
 	//
 	if db == nil {
-		return nil, errors.New("SetupDB not called")
+		return errors.New("SetupDB not called")
 	}
 
-	var path string
-	row := db.QueryRow("SELECT yaml_file FROM reports WHERE id=?", id)
-	err := row.Scan(&path)
-
-	switch {
-	case err == sql.ErrNoRows:
-	case err != nil:
-		return nil, errors.New("report not found")
-	default:
+	if (ack.Fqdn == "") == (ack.Fingerprint == "") {
+		return errors.New("an acknowledgement needs one of a node or a fingerprint")
 	}
 
-	//
-	// Read the file content, first of all adding in the
-	// prefix.
-	//
-	// (Because our reports are stored as relative paths
-	// such as "$host/$time", rather than absolute paths
-	// such as "reports/$host/$time".)
-	//
-	if len(path) > 0 {
-		path = filepath.Join(prefix, path)
-		content, err := ioutil.ReadFile(path)
-		return content, err
+	ack.CreatedAt = time.Now().Unix()
+	result, err := db.Exec("INSERT INTO acknowledgements(fqdn, fingerprint, reason, acked_by, created_at, expires_at) VALUES(?,?,?,?,?,?)", ack.Fqdn, ack.Fingerprint, ack.Reason, ack.User, ack.CreatedAt, ack.ExpiresAt)
+	if err != nil {
+		return err
 	}
-	return nil, errors.New("failed to find report with specified ID")
+	ack.ID, err = result.LastInsertId()
+	return err
 }
 
 //
-// Get the data which is shown on our index page
-//
-//  * The node-name.
-//  * The status.
-//  * The last-seen time.
+// Remove an acknowledgement.
 //
-func getIndexNodes() ([]PuppetRuns, error) {
+func deleteAck(id int64) error {
 
 	//
-	// Our return-result.
+	// Ensure we have a DB-handle
 	//
-	var NodeList []PuppetRuns
+	if db == nil {
+		return errors.New("SetupDB not called")
+	}
+
+	_, err := db.Exec("DELETE FROM acknowledgements WHERE id=?", id)
+	return err
+}
+
+//
+// Get the acknowledgements which haven't expired.
+//
+func getAcks() ([]PuppetAck, error) {
 
 	//
 	// Ensure we have a DB-handle
@@ -714,236 +3309,331 @@ func getIndexNodes() ([]PuppetRuns, error) {
 		return nil, errors.New("SetupDB not called")
 	}
 
-	sql := "SELECT fqdn, state, runtime, last_seen, branch, build_time, role, pinned FROM hosts;"
-	
-	//
-	// Select the status - for nodes seen in the past 24 hours.
-	//
-	rows, err := db.Query(sql)
+	rows, err := db.Query("SELECT id, fqdn, fingerprint, reason, acked_by, created_at, expires_at FROM acknowledgements WHERE expires_at = 0 OR expires_at > ? ORDER BY id", time.Now().Unix())
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	//
-	// For each row in the result-set
-	//
-	// Parse into a structure and add to the list.
-	//
+	var res []PuppetAck
 	for rows.Next() {
-		var tmp PuppetRuns
-		var at string
-		var builtAt string
-		var pinned int64
-
-		err := rows.Scan(&tmp.Fqdn, &tmp.State, &tmp.Runtime, &at, &tmp.Branch, &builtAt , &tmp.Role, &pinned)
+		var tmp PuppetAck
+		err = rows.Scan(&tmp.ID, &tmp.Fqdn, &tmp.Fingerprint, &tmp.Reason, &tmp.User, &tmp.CreatedAt, &tmp.ExpiresAt)
 		if err != nil {
 			return nil, err
 		}
+		res = append(res, tmp)
+	}
+	err = rows.Err()
+	return res, err
+}
 
-		tmp.Pinned = "No"
-		if pinned == 1{
-			tmp.Pinned = "Yes"
+//
+// Mark the failing nodes which have been acknowledged, either directly
+// or by their fingerprint.
+//
+func applyAcks(nodes []PuppetRuns) error {
+
+	acks, err := getAcks()
+	if err != nil {
+		return err
+	}
+
+	byFqdn := make(map[string]PuppetAck)
+	byFingerprint := make(map[string]PuppetAck)
+	for _, ack := range acks {
+		if ack.Fqdn != "" {
+			byFqdn[ack.Fqdn] = ack
+		} else {
+			byFingerprint[ack.Fingerprint] = ack
 		}
+	}
 
-		//
-		// At this point `at` is a string containing seconds past
-		// the epoch.
-		//
-		// We want to parse that into a string `At` which will
-		// contain the literal time, and also the relative
-		// time "Ago"
-		//
-		tmp.BuiltEpoch = builtAt
-		tmp.Epoch = at
-		tmp.Ago = timeRelative(at)
-		if strings.Compare(builtAt, "0") == 0 {
-			tmp.BuiltAgo = "-"
-			tmp.BuiltAt = "-"
-		}else{
-			tmp.BuiltAgo = timeRelative(builtAt)
-			ib, _ := strconv.ParseInt(builtAt, 10, 64)
-			tmp.BuiltAt = time.Unix(ib, 0).Format("2006-01-02 15:04:05")
+	for i := range nodes {
+		if nodes[i].State != "failed" {
+			continue
+		}
+		if ack, ok := byFqdn[nodes[i].Fqdn]; ok {
+			nodes[i].Ack = &ack
+		} else if ack, ok := byFingerprint[nodes[i].Fingerprint]; ok && nodes[i].Fingerprint != "" {
+			nodes[i].Ack = &ack
 		}
+	}
+	return nil
+}
 
-		//
-		i, _ := strconv.ParseInt(at, 10, 64)
-		tmp.At = time.Unix(i, 0).Format("2006-01-02 15:04:05")
+//
+// Get the state of the given node, along with the fingerprint of its
+// failure, whatever acknowledges it, and any maintenance window it is
+// within.  Unknown nodes have no state.
+//
+func getNodeAck(fqdn string) (PuppetRuns, error) {
 
-		//
-		// Add the new record.
-		//
-		NodeList = append(NodeList, tmp)
+	node := PuppetRuns{Fqdn: fqdn}
+
+	//
+	// Ensure we have a DB-handle
+	//
+	if db == nil {
+		return node, errors.New("SetupDB not called")
+	}
 
+	row := db.QueryRow("SELECT state, fingerprint, role FROM hosts WHERE fqdn = ?", fqdn)
+	err := row.Scan(&node.State, &node.Fingerprint, &node.Role)
+	if err == sql.ErrNoRows {
+		return node, nil
 	}
-	err = rows.Err()
 	if err != nil {
-		return nil, err
+		return node, err
 	}
 
-	return NodeList, nil
+	nodes := []PuppetRuns{node}
+	err = applyAcks(nodes)
+	if err != nil {
+		return node, err
+	}
+	err = applyMaintenance(nodes)
+	return nodes[0], err
 }
 
 //
-// Return the state of our nodes.
+// Remove the acknowledgements which have expired, along with those for
+// nodes, or fingerprints, which we no longer hold.
 //
-func getStates() ([]PuppetState, error) {
+// A failing node which goes late keeps its acknowledgement, which is
+// only cleared by a successful run.
+//
+func expireAcks() {
 
 	//
-	// Get the nodes.
+	// Ensure we have a DB-handle
 	//
-	NodeList, err := getIndexNodes()
-	if err != nil {
-		return nil, err
+	if db == nil {
+		return
 	}
 
-	//
-	// Create a map to hold state.
-	//
-	states := make(map[string]int)
+	db.Exec("DELETE FROM acknowledgements WHERE expires_at > 0 AND expires_at <= ?", time.Now().Unix())
+	db.Exec("DELETE FROM acknowledgements WHERE fqdn != '' AND fqdn NOT IN (SELECT fqdn FROM hosts)")
+	db.Exec("DELETE FROM acknowledgements WHERE fingerprint != '' AND fingerprint NOT IN (SELECT fingerprint FROM hosts)")
+}
 
-	//
-	// Each known-state will default to being empty.
-	//
-	states["changed"] = 0
-	states["unchanged"] = 0
-	states["failed"] = 0
-	states["orphaned"] = 0
+//
+// Add a maintenance window.
+//
+func addMaintenance(window *PuppetMaintenance) error {
 
 	//
-	// Count the nodes we encounter, such that we can
-	// create a %-figure for each distinct-state.
+	// Ensure we have a DB-handle
 	//
-	var total int
+	if db == nil {
+		return errors.New("SetupDB not called")
+	}
 
-	//
-	// Count the states.
-	//
-	for _, o := range NodeList {
-		states[o.State]++
-		total++
+	result, err := db.Exec("INSERT INTO maintenance_windows(name, fqdn, role, schedule, starts_at, ends_at, duration, reason, created_at) VALUES(?,?,?,?,?,?,?,?,?)",
+		window.Name, window.Fqdn, window.Role, window.Schedule, window.StartsAt, window.EndsAt, window.Duration, window.Reason, time.Now().Unix())
+	if err != nil {
+		return err
 	}
+	window.ID, err = result.LastInsertId()
+	return err
+}
 
-	//
-	// Our return-result
-	//
-	var data []PuppetState
+//
+// Remove a maintenance window.
+//
+func deleteMaintenance(id int64) error {
 
 	//
-	// Get the distinct keys/states in a sorted order.
+	// Ensure we have a DB-handle
 	//
-	var keys []string
-	for name := range states {
-		keys = append(keys, name)
+	if db == nil {
+		return errors.New("SetupDB not called")
 	}
-	sort.Strings(keys)
+
+	_, err := db.Exec("DELETE FROM maintenance_windows WHERE id=?", id)
+	return err
+}
+
+//
+// Get all our maintenance windows.
+//
+func getMaintenance() ([]PuppetMaintenance, error) {
 
 	//
-	// Now for each key ..
+	// Ensure we have a DB-handle
 	//
-	for _, name := range keys {
+	if db == nil {
+		return nil, errors.New("SetupDB not called")
+	}
 
-		var tmp PuppetState
-		tmp.State = name
-		tmp.Count = states[name]
-		tmp.Percentage = 0
+	rows, err := db.Query("SELECT id, name, fqdn, role, schedule, starts_at, ends_at, duration, reason FROM maintenance_windows ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-		// Percentage has to be capped :)
-		if total != 0 {
-			c := float64(states[name])
-			tmp.Percentage = (c / float64(total)) * 100
+	var res []PuppetMaintenance
+	for rows.Next() {
+		var tmp PuppetMaintenance
+		err = rows.Scan(&tmp.ID, &tmp.Name, &tmp.Fqdn, &tmp.Role, &tmp.Schedule, &tmp.StartsAt, &tmp.EndsAt, &tmp.Duration, &tmp.Reason)
+		if err != nil {
+			return nil, err
 		}
-		data = append(data, tmp)
+		res = append(res, tmp)
+	}
+	err = rows.Err()
+	return res, err
+}
+
+//
+// Mark the nodes which are currently within a maintenance window.
+//
+func applyMaintenance(nodes []PuppetRuns) error {
+
+	windows, err := getMaintenance()
+	if err != nil {
+		return err
 	}
 
-	return data, nil
+	now := time.Now().Unix()
+	for i := range nodes {
+		if w := maintenanceWindow(windows, nodes[i].Fqdn, nodes[i].Role, now); w != nil {
+			nodes[i].Maintenance = w.Name
+		}
+	}
+	return nil
 }
 
 //
-// Get the summary-details of the runs against a given host
+// Record an event.
 //
-func getReports(fqdn string) ([]PuppetReportSummary, error) {
+func addEvent(event *PuppetEvent) error {
 
 	//
 	// Ensure we have a DB-handle
 	//
 	if db == nil {
-		return nil, errors.New("SetupDB not called")
+		return errors.New("SetupDB not called")
+	}
+
+	result, err := db.Exec("INSERT INTO events(text, tags, happened_at) VALUES(?,?,?)",
+		event.Text, strings.Join(event.Tags, ","), event.At)
+	if err != nil {
+		return err
 	}
+	event.ID, err = result.LastInsertId()
+	return err
+}
+
+//
+// Get the events which happened at, or after, the given time, optionally
+// only those with the given tag.
+//
+func getEvents(since int64, tag string) ([]PuppetEvent, error) {
 
 	//
-	// Select the status.
+	// Ensure we have a DB-handle
 	//
-	stmt, err := db.Prepare("SELECT id, fqdn, state, executed_at, runtime, failed, changed, total, yaml_file, branch, build_time, role FROM reports WHERE fqdn=? ORDER by executed_at DESC LIMIT 50")
-	if err != nil {
-		return nil, err
+	if db == nil {
+		return nil, errors.New("SetupDB not called")
 	}
-	rows, err := stmt.Query(fqdn)
+
+	rows, err := db.Query("SELECT id, text, tags, happened_at FROM events WHERE happened_at >= ? ORDER BY happened_at, id", since)
 	if err != nil {
 		return nil, err
 	}
-	defer stmt.Close()
 	defer rows.Close()
 
-	//
-	// We'll return a list of these hosts.
-	//
-	var NodeList []PuppetReportSummary
-
-	//
-	// For each row in the result-set
-	//
-	// Parse into a structure and add to the list.
-	//
+	var res []PuppetEvent
 	for rows.Next() {
-		var tmp PuppetReportSummary
-		var at string
-		var builtAt string
-		err := rows.Scan(&tmp.ID, &tmp.Fqdn, &tmp.State, &at, &tmp.Runtime, &tmp.Failed, &tmp.Changed, &tmp.Total, &tmp.YamlFile, &tmp.Branch, &builtAt, &tmp.Role)
+		var tmp PuppetEvent
+		var tags string
+		err = rows.Scan(&tmp.ID, &tmp.Text, &tags, &tmp.At)
 		if err != nil {
 			return nil, err
 		}
+		if len(tags) > 0 {
+			tmp.Tags = strings.Split(tags, ",")
+		}
 
-		//
-		// At this point `at` is a string containing seconds past
-		// the epoch.
-		//
-		// We want to parse that into a string `At` which will
-		// contain the literal time, and also the relative
-		// time "Ago"
-		//
-		tmp.Ago = timeRelative(at)
-		if strings.Compare(builtAt, "0") == 0 {
-			tmp.BuiltAgo = "-"
-			tmp.BuiltAt = "-"
-		}else{
-			tmp.BuiltAgo = timeRelative(builtAt)
-			ib, _ := strconv.ParseInt(builtAt, 10, 64)
-			tmp.BuiltAt = time.Unix(ib, 0).Format("2006-01-02 15:04:05")
+		if len(tag) > 0 {
+			found := false
+			for _, t := range tmp.Tags {
+				if t == tag {
+					found = true
+				}
+			}
+			if !found {
+				continue
+			}
 		}
+		res = append(res, tmp)
+	}
+	err = rows.Err()
+	return res, err
+}
 
-		i, _ := strconv.ParseInt(at, 10, 64)
-		tmp.At = time.Unix(i, 0).Format("2006-01-02 15:04:05")
+//
+// Summarise the runs from the past `days` days, grouped by the given
+// column of the reports: "role", "branch", or "environment".
+//
+func getStats(days int, by string) ([]PuppetStats, error) {
 
-		// Add the result of this fetch to our list.
-		NodeList = append(NodeList, tmp)
+	//
+	// Ensure we have a DB-handle
+	//
+	if db == nil {
+		return nil, errors.New("SetupDB not called")
 	}
-	err = rows.Err()
+
+	switch by {
+	case "role", "branch", "environment":
+	default:
+		return nil, fmt.Errorf("cannot group statistics by '%s'", by)
+	}
+
+	since := time.Now().Unix() - int64(days*24*60*60)
+
+	//
+	// Older reports, and those without the value, are grouped
+	// together.
+	//
+	column := "COALESCE(" + by + ", '')"
+
+	rows, err := db.Query("SELECT "+column+", COUNT(DISTINCT fqdn), COUNT(*), SUM(CASE WHEN state='failed' THEN 1 ELSE 0 END), SUM(CASE WHEN state='changed' THEN 1 ELSE 0 END), AVG(runtime) FROM reports WHERE executed_at > ? GROUP BY "+column+" ORDER BY "+column, since)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	if len(NodeList) < 1 {
-		return nil, errors.New("Failed to find reports for " + fqdn)
+	var res []PuppetStats
+	for rows.Next() {
+		var tmp PuppetStats
+		var runtime sql.NullFloat64
+
+		err = rows.Scan(&tmp.Name, &tmp.Nodes, &tmp.Runs, &tmp.Failed, &tmp.Changed, &runtime)
+		if err != nil {
+			return nil, err
+		}
 
+		tmp.Runtime = runtime.Float64
+		tmp.FailureRate = float64(tmp.Failed*100) / float64(tmp.Runs)
+		tmp.ChangeRate = float64(tmp.Changed*100) / float64(tmp.Runs)
+		tmp.SuccessRate = 100 - tmp.FailureRate
+		res = append(res, tmp)
 	}
-	return NodeList, nil
+	err = rows.Err()
+	return res, err
 }
 
 //
-// Get data for our stacked bar-graph
+// Find how each git branch is rolling out across our nodes.
 //
-func getHistory() ([]PuppetHistory, error) {
+// Runs from the past `days` days are used to find the failure rate of
+// each build, while nodes are stuck if they're not running the newest
+// build of their branch `threshold` after it was made.
+//
+func getRollout(days int, threshold time.Duration) ([]PuppetRollout, error) {
 
 	//
 	// Ensure we have a DB-handle
@@ -952,57 +3642,117 @@ func getHistory() ([]PuppetHistory, error) {
 		return nil, errors.New("SetupDB not called")
 	}
 
-	//
-	// Our result.
-	//
-	var res []PuppetHistory
+	type totals struct {
+		rollout PuppetRollout
+		builds  map[int64]*PuppetBuild
+	}
+	branches := make(map[string]*totals)
 
-	sql := "SELECT date, failed, changed, unchanged FROM history ORDER BY date"
+	build := func(branch string, built int64) *PuppetBuild {
+		t := branches[branch]
+		if t == nil {
+			t = &totals{rollout: PuppetRollout{Branch: branch}, builds: make(map[int64]*PuppetBuild)}
+			branches[branch] = t
+		}
+		b := t.builds[built]
+		if b == nil {
+			b = &PuppetBuild{BuiltAt: built}
+			t.builds[built] = b
+		}
+		if built > t.rollout.Newest {
+			t.rollout.Newest = built
+		}
+		return b
+	}
 
 	//
-	// Get the data.
+	// Count the nodes on each build, ignoring those which have gone
+	// away, and those which don't report their build.
 	//
-	stmt, err := db.Prepare(sql)
+	nodes, err := getIndexNodes()
 	if err != nil {
 		return nil, err
 	}
 
-	rows, err := stmt.Query()
+	var current []PuppetRuns
+	for _, n := range nodes {
+		built, _ := strconv.ParseInt(n.BuiltEpoch, 10, 64)
+		if n.State == "orphaned" || n.Branch == "" || built == 0 {
+			continue
+		}
+		build(n.Branch, built).Nodes++
+		branches[n.Branch].rollout.Nodes++
+		current = append(current, n)
+	}
+
+	//
+	// Count the runs, and failures, of each build.
+	//
+	since := time.Now().Unix() - int64(days*24*60*60)
+	rows, err := db.Query("SELECT branch, build_time, COUNT(*), SUM(CASE WHEN state='failed' THEN 1 ELSE 0 END) FROM reports WHERE executed_at > ? AND branch != '' AND build_time > 0 GROUP BY branch, build_time", since)
 	if err != nil {
 		return nil, err
 	}
-	defer stmt.Close()
 	defer rows.Close()
 
-	//
-	// For each row in the result-set
-	//
 	for rows.Next() {
-		var d string
-		var f int
-		var c int
-		var u int
+		var branch string
+		var built int64
+		var runs, failed int
 
-		err := rows.Scan(&d, &f, &c, &u)
+		err = rows.Scan(&branch, &built, &runs, &failed)
 		if err != nil {
-			return nil, errors.New("failed to scan SQL")
+			return nil, err
 		}
 
-		var x PuppetHistory
-		x.Changed = strconv.Itoa(c)
-		x.Unchanged = strconv.Itoa(u)
-		x.Failed = strconv.Itoa(f)
-		x.Date = d
-
-		res = append(res, x)
+		b := build(branch, built)
+		b.Runs += runs
+		b.Failed += failed
 	}
 	err = rows.Err()
 	if err != nil {
 		return nil, err
 	}
 
-	return res, err
+	//
+	// Find the nodes which are stuck.
+	//
+	now := time.Now()
+	for _, n := range current {
+		t := branches[n.Branch]
+		built, _ := strconv.ParseInt(n.BuiltEpoch, 10, 64)
+		if built < t.rollout.Newest && now.Sub(time.Unix(t.rollout.Newest, 0)) > threshold {
+			t.rollout.Stuck = append(t.rollout.Stuck, n)
+		}
+	}
+
+	//
+	// Build up the results, sorted by branch.
+	//
+	var res []PuppetRollout
+	for _, t := range branches {
+		for _, b := range t.builds {
+			if t.rollout.Nodes > 0 {
+				b.Percentage = float64(b.Nodes*100) / float64(t.rollout.Nodes)
+			}
+			if b.Runs > 0 {
+				b.FailureRate = float64(b.Failed*100) / float64(b.Runs)
+			}
+			t.rollout.Builds = append(t.rollout.Builds, *b)
+		}
+		sort.Slice(t.rollout.Builds, func(i, j int) bool { return t.rollout.Builds[i].BuiltAt > t.rollout.Builds[j].BuiltAt })
+		sort.Slice(t.rollout.Stuck, func(i, j int) bool {
+			a, _ := strconv.ParseInt(t.rollout.Stuck[i].BuiltEpoch, 10, 64)
+			b, _ := strconv.ParseInt(t.rollout.Stuck[j].BuiltEpoch, 10, 64)
+			return a < b
+		})
+
+		t.rollout.Current = t.rollout.Builds[0].Percentage
+		res = append(res, t.rollout)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Branch < res[j].Branch })
 
+	return res, nil
 }
 
 //
@@ -1102,6 +3852,22 @@ func pruneReports(prefix string, days int, verbose bool) error {
 		return err
 	}
 
+	//
+	//  And the resources those reports changed
+	//
+	_, err = db.Exec("DELETE FROM changed_resources WHERE ( ( ? - executed_at ) > ? )", now, expire_time)
+	if err != nil {
+		return err
+	}
+
+	//
+	//  And the time those reports spent upon each resource-type
+	//
+	_, err = db.Exec("DELETE FROM resource_times WHERE ( ( ? - executed_at ) > ? )", now, expire_time)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -1257,6 +4023,16 @@ func pruneOrphaned(prefix string, verbose bool) error {
 				return err
 			}
 
+			_, err = db.Exec("DELETE FROM changed_resources WHERE fqdn=?", entry.Fqdn)
+			if err != nil {
+				return err
+			}
+
+			_, err = db.Exec("DELETE FROM resource_times WHERE fqdn=?", entry.Fqdn)
+			if err != nil {
+				return err
+			}
+
 		}
 
 	}
@@ -10,6 +10,7 @@ import (
 	"io/ioutil"
 	"os"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 )
@@ -31,7 +32,7 @@ func FakeDB() {
 	//
 	// Setup the tables.
 	//
-	SetupDB(p + "/db.sql")
+	SetupDB("sqlite3", p+"/db.sql")
 
 }
 
@@ -133,6 +134,25 @@ func addFakeNodes() {
 
 }
 
+//
+// Add a series of runs, in the given states, for a single node.
+//
+func addFakeRuns(fqdn string, states ...string) {
+
+	var n PuppetReport
+	n.Fqdn = fqdn
+	n.Runtime = "1.0"
+	n.Failed = "0"
+	n.Total = "1"
+	n.Changed = "0"
+	n.Skipped = "0"
+
+	for _, state := range states {
+		n.State = state
+		addDB(n, "")
+	}
+}
+
 //
 // Get a valid report ID.
 //
@@ -199,7 +219,7 @@ func TestBogusInit(t *testing.T) {
 	// Create a fake database
 	FakeDB()
 
-	err := SetupDB(path)
+	err := SetupDB("sqlite3", path)
 
 	if err == nil {
 		t.Errorf("We should have seen a create-error")
@@ -470,3 +490,887 @@ func TestHistory(t *testing.T) {
 	db = nil
 	os.RemoveAll(path)
 }
+
+//
+// Test that we learn the interval between runs.
+//
+func TestMedianInterval(t *testing.T) {
+
+	//
+	// Not enough data.
+	//
+	if medianInterval([]int64{100, 50}) != 0 {
+		t.Errorf("Expected an unknown interval")
+	}
+
+	//
+	// A regular interval, with one manual run in the middle.
+	//
+	times := []int64{1800 * 5, 1800 * 4, 1800*3 + 60, 1800 * 3, 1800 * 2, 1800}
+	if medianInterval(times) != 1800 {
+		t.Errorf("Unexpected interval %d", medianInterval(times))
+	}
+}
+
+//
+// Test that hosts which miss runs are marked as late.
+//
+func TestLate(t *testing.T) {
+
+	//
+	// Create a fake database.
+	//
+	FakeDB()
+
+	now := time.Now().Unix()
+
+	//
+	// Two hosts, both of which run every half an hour.
+	//
+	for _, fqdn := range []string{"late.example.com", "ok.example.com"} {
+		id, err := createHost(fqdn)
+		if err != nil {
+			t.Fatalf("Failed to create host: %s", err)
+		}
+
+		last := now
+		if fqdn == "late.example.com" {
+			last = now - 1800*4
+		}
+
+		for i := int64(0); i < 5; i++ {
+			db.Exec("INSERT INTO reports(fqdn,host_id,executed_at) VALUES(?,?,?)", fqdn, id, last-i*1800)
+		}
+		db.Exec("UPDATE hosts SET state='unchanged', last_seen=? WHERE host_id=?", last, id)
+
+		err = updateInterval(id)
+		if err != nil {
+			t.Errorf("Failed to update interval: %s", err)
+		}
+	}
+
+	var interval int
+	row := db.QueryRow("SELECT run_interval FROM hosts WHERE fqdn='ok.example.com'")
+	row.Scan(&interval)
+	if interval != 1800 {
+		t.Errorf("Unexpected interval %d", interval)
+	}
+
+	//
+	// Now the host which missed four runs should be late.
+	//
+	updateLate(3)
+
+	states, err := getStates()
+	if err != nil {
+		t.Errorf("getStates failed: %v", err)
+	}
+	for _, s := range states {
+		if s.State == "late" && s.Count != 1 {
+			t.Errorf("Expected one late host, found %d", s.Count)
+		}
+		if s.State == "unchanged" && s.Count != 1 {
+			t.Errorf("Expected one unchanged host, found %d", s.Count)
+		}
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test that state-transitions are recorded, and that we can work out
+// the reliability of a node from them.
+//
+func TestTransitions(t *testing.T) {
+
+	//
+	// Create a fake database.
+	//
+	FakeDB()
+
+	var n PuppetReport
+	n.Fqdn = "flaky.example.com"
+	n.Role = "web"
+
+	for _, state := range []string{"unchanged", "failed", "failed", "unchanged", "failed"} {
+		n.State = state
+		addDB(n, "")
+	}
+
+	//
+	// The repeated failure isn't a transition.
+	//
+	transitions, err := getTransitions("flaky.example.com")
+	if err != nil {
+		t.Errorf("getTransitions failed: %v", err)
+	}
+	if len(transitions) != 4 {
+		t.Fatalf("Unexpected number of transitions: %d", len(transitions))
+	}
+	if transitions[3].From != "" || transitions[3].To != "unchanged" {
+		t.Errorf("Unexpected first transition: %v", transitions[3])
+	}
+
+	//
+	// Spread the reports, and transitions, out over time.
+	//
+	db.Exec("UPDATE reports SET executed_at = id * 100")
+	for id, at := range map[int]int{1: 1000, 2: 2000, 3: 2600, 4: 5000} {
+		db.Exec("UPDATE state_transitions SET changed_at = ? WHERE id = ?", at, id)
+	}
+
+	for _, byRole := range []bool{false, true} {
+		stats, err := getReliability(byRole)
+		if err != nil {
+			t.Errorf("getReliability failed: %v", err)
+		}
+		if len(stats) != 1 {
+			t.Fatalf("Unexpected number of results: %d", len(stats))
+		}
+
+		s := stats[0]
+		if s.Failures != 2 {
+			t.Errorf("Unexpected failures: %d", s.Failures)
+		}
+		if s.MTTR != 600 {
+			t.Errorf("Unexpected MTTR: %d", s.MTTR)
+		}
+		if s.MTBF != 3000 {
+			t.Errorf("Unexpected MTBF: %d", s.MTBF)
+		}
+		if s.Streak != 1 {
+			t.Errorf("Unexpected streak: %d", s.Streak)
+		}
+		if byRole && s.Name != "web" {
+			t.Errorf("Unexpected role: %s", s.Name)
+		}
+	}
+
+	//
+	// A report whose transition can't be recorded isn't stored.
+	//
+	_, err = db.Exec("CREATE TRIGGER refuse BEFORE INSERT ON state_transitions BEGIN SELECT RAISE(ABORT, 'refused'); END")
+	if err != nil {
+		t.Fatalf("Failed to create trigger: %s", err.Error())
+	}
+	before, _ := countReports()
+
+	n.State = "changed"
+	err = addDB(n, "")
+	if err == nil || !strings.Contains(err.Error(), "refused") {
+		t.Errorf("Expected the report to fail, got %v", err)
+	}
+	after, _ := countReports()
+	if after != before {
+		t.Errorf("We have %d reports, not %d", after, before)
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test that flapping is scored correctly.
+//
+func TestFlapScore(t *testing.T) {
+
+	type TestCase struct {
+		States []string
+		Score  int
+	}
+
+	tests := []TestCase{
+		{[]string{"failed", "unchanged"}, 0},
+		{[]string{"failed", "failed", "failed", "failed"}, 0},
+		{[]string{"changed", "unchanged", "changed", "unchanged"}, 0},
+		{[]string{"failed", "unchanged", "failed", "changed", "failed"}, 100},
+		{[]string{"failed", "failed", "unchanged", "unchanged", "unchanged"}, 25},
+	}
+
+	for _, test := range tests {
+		var reports []PuppetReportSummary
+		for _, state := range test.States {
+			reports = append(reports, PuppetReportSummary{State: state})
+		}
+
+		score := flapScore(reports, 10)
+		if score != test.Score {
+			t.Errorf("Expected score %d for %v, got %d", test.Score, test.States, score)
+		}
+	}
+
+	//
+	// Only the window is examined.
+	//
+	reports := []PuppetReportSummary{{State: "unchanged"}, {State: "unchanged"}, {State: "unchanged"}, {State: "failed"}, {State: "unchanged"}}
+	if flapScore(reports, 3) != 0 {
+		t.Errorf("Flap score looked outside the window")
+	}
+}
+
+//
+// Test that a node which keeps failing is shown as flapping.
+//
+func TestFlapping(t *testing.T) {
+
+	//
+	// Create a fake database.
+	//
+	FakeDB()
+
+	addFakeRuns("flaky.example.com", "failed", "unchanged", "failed", "unchanged", "failed")
+	addFakeRuns("stable.example.com", "failed", "failed", "failed", "unchanged", "unchanged")
+
+	runs, err := getIndexNodes()
+	if err != nil {
+		t.Errorf("getIndexNodes failed: %v", err)
+	}
+
+	for _, r := range runs {
+		if r.Fqdn == "flaky.example.com" && !r.Flapping {
+			t.Errorf("Expected %s to be flapping, score %d", r.Fqdn, r.FlapScore)
+		}
+		if r.Fqdn == "stable.example.com" && r.Flapping {
+			t.Errorf("Expected %s not to be flapping, score %d", r.Fqdn, r.FlapScore)
+		}
+	}
+
+	if !isFlapping("flaky.example.com") || isFlapping("stable.example.com") || isFlapping("missing.example.com") {
+		t.Errorf("isFlapping disagrees with getIndexNodes")
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test that resources which change on most runs are found.
+//
+func TestNonIdempotent(t *testing.T) {
+
+	//
+	// Create a fake database.
+	//
+	FakeDB()
+
+	exec := Resource{Type: "Exec", Name: "rebuild", File: "site.pp", Line: "3"}
+	file := Resource{Type: "File", Name: "/etc/motd", File: "site.pp", Line: "9"}
+
+	add := func(fqdn string, role string, changed ...[]Resource) {
+		var n PuppetReport
+		n.Fqdn = fqdn
+		n.Role = role
+		n.State = "changed"
+		for _, c := range changed {
+			n.ResourcesChanged = c
+			addDB(n, "")
+		}
+	}
+
+	add("a.example.com", "web", []Resource{exec}, []Resource{exec, file}, []Resource{exec}, []Resource{exec})
+	add("b.example.com", "web", []Resource{exec}, nil, nil, nil)
+	add("c.example.com", "db", []Resource{exec}, []Resource{exec})
+
+	nodes, err := getNonIdempotent(7, 50, false)
+	if err != nil {
+		t.Fatalf("getNonIdempotent failed: %v", err)
+	}
+	if len(nodes) != 1 {
+		t.Fatalf("Unexpected number of resources: %d", len(nodes))
+	}
+	if nodes[0].Type != "Exec" || nodes[0].Nodes != 2 {
+		t.Errorf("Unexpected result: %v", nodes[0])
+	}
+	if nodes[0].Fqdns[0] != "a.example.com" || nodes[0].Fqdns[1] != "c.example.com" {
+		t.Errorf("Unexpected nodes: %v", nodes[0].Fqdns)
+	}
+
+	roles, err := getNonIdempotent(7, 50, true)
+	if err != nil {
+		t.Fatalf("getNonIdempotent failed: %v", err)
+	}
+	if len(roles) != 2 {
+		t.Fatalf("Unexpected number of resources: %d", len(roles))
+	}
+	if roles[0].Role != "web" || roles[0].Nodes != 2 || roles[0].Percentage != 62.5 {
+		t.Errorf("Unexpected result: %v", roles[0])
+	}
+	if roles[1].Role != "db" || roles[1].Percentage != 100 {
+		t.Errorf("Unexpected result: %v", roles[1])
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test our percentile calculation.
+//
+func TestPercentile(t *testing.T) {
+
+	if percentile(nil, 50) != 0 {
+		t.Errorf("Expected zero for no values")
+	}
+
+	values := []float64{9, 1, 8, 2, 7, 3, 6, 4, 5, 10}
+	if percentile(values, 50) != 5 {
+		t.Errorf("Unexpected median %f", percentile(values, 50))
+	}
+	if percentile(values, 95) != 10 {
+		t.Errorf("Unexpected 95th percentile %f", percentile(values, 95))
+	}
+
+	//
+	// The input shouldn't be reordered.
+	//
+	if values[0] != 9 {
+		t.Errorf("Our input was sorted")
+	}
+}
+
+//
+// Test that runtime baselines are found, and slow runs flagged.
+//
+func TestRuntimeBaselines(t *testing.T) {
+
+	//
+	// Create a fake database.
+	//
+	FakeDB()
+
+	var n PuppetReport
+	n.State = "unchanged"
+	n.Failed = "0"
+	n.Changed = "0"
+	n.Total = "1"
+
+	n.Fqdn = "slow.example.com"
+	n.Role = "web"
+	for _, runtime := range []string{"10", "11", "12", "30"} {
+		n.Runtime = runtime
+		addDB(n, "")
+	}
+
+	n.Fqdn = "fast.example.com"
+	n.Role = "db"
+	for _, runtime := range []string{"1", "2", "1"} {
+		n.Runtime = runtime
+		addDB(n, "")
+	}
+
+	nodes, err := getRuntimeBaselines(7, false)
+	if err != nil {
+		t.Fatalf("getRuntimeBaselines failed: %v", err)
+	}
+	if len(nodes) != 2 {
+		t.Fatalf("Unexpected number of results: %d", len(nodes))
+	}
+	if nodes[0].Name != "slow.example.com" || nodes[0].Median != 11 || nodes[0].P95 != 30 {
+		t.Errorf("Unexpected baseline: %v", nodes[0])
+	}
+	if !nodes[0].Slow || nodes[1].Slow {
+		t.Errorf("Unexpected slow flags: %v", nodes)
+	}
+
+	roles, err := getRuntimeBaselines(7, true)
+	if err != nil {
+		t.Fatalf("getRuntimeBaselines failed: %v", err)
+	}
+	if len(roles) != 2 || roles[0].Name != "web" || roles[1].Name != "db" {
+		t.Errorf("Unexpected roles: %v", roles)
+	}
+
+	baseline, err := getNodeBaseline("fast.example.com", 7)
+	if err != nil {
+		t.Fatalf("getNodeBaseline failed: %v", err)
+	}
+	if baseline.Runs != 3 || baseline.Median != 1 || baseline.Latest != 1 {
+		t.Errorf("Unexpected baseline: %v", baseline)
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test that the time spent upon each type of resource is averaged
+// across a node's runs.
+//
+func TestNodeTypeTimes(t *testing.T) {
+
+	//
+	// Create a fake database.
+	//
+	FakeDB()
+
+	var n PuppetReport
+	n.Fqdn = "foo.example.com"
+	n.State = "unchanged"
+	n.Failed = "0"
+	n.Changed = "0"
+	n.Total = "1"
+	n.Runtime = "4"
+
+	n.TypeTimes = []ResourceTime{{Type: "Package", Seconds: 3}, {Type: "File", Seconds: 1}}
+	addDB(n, "")
+	n.TypeTimes = []ResourceTime{{Type: "Package", Seconds: 1}, {Type: "File", Seconds: 3}, {Type: "Exec", Seconds: 4}}
+	addDB(n, "")
+
+	types, err := getNodeTypeTimes("foo.example.com", 7)
+	if err != nil {
+		t.Fatalf("getNodeTypeTimes failed: %v", err)
+	}
+	if len(types) != 3 {
+		t.Fatalf("Unexpected number of types: %d", len(types))
+	}
+	if types[0].Type != "Exec" || types[0].Seconds != 4 || types[0].Percentage != 50 {
+		t.Errorf("Unexpected slowest type: %v", types[0])
+	}
+	if types[1].Type != "File" || types[1].Seconds != 2 {
+		t.Errorf("Unexpected type: %v", types[1])
+	}
+
+	types, err = getNodeTypeTimes("bar.example.com", 7)
+	if err != nil || len(types) != 0 {
+		t.Errorf("Unexpected types for unknown node: %v %v", types, err)
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test acknowledging failing nodes, and fingerprints.
+//
+func TestAcknowledgements(t *testing.T) {
+
+	//
+	// Create a fake database.
+	//
+	FakeDB()
+
+	var n PuppetReport
+	n.Runtime = "1.0"
+	n.State = "failed"
+	n.ErrorMessages = []string{"Could not find class foo"}
+	for _, fqdn := range []string{"a.example.com", "b.example.com"} {
+		n.Fqdn = fqdn
+		addDB(n, "")
+	}
+	n.Fqdn = "c.example.com"
+	n.ErrorMessages = []string{"Something else"}
+	addDB(n, "")
+
+	acked := func() map[string]bool {
+		res := make(map[string]bool)
+		nodes, err := getIndexNodes()
+		if err != nil {
+			t.Fatalf("Failed to get nodes: %s", err.Error())
+		}
+		for _, node := range nodes {
+			if node.Ack != nil {
+				res[node.Fqdn] = true
+			}
+		}
+		return res
+	}
+
+	//
+	// Acknowledge a single node.
+	//
+	ack := PuppetAck{Fqdn: "a.example.com", Reason: "TICKET-1", User: "steve"}
+	err := addAck(&ack)
+	if err != nil {
+		t.Fatalf("Failed to acknowledge: %s", err.Error())
+	}
+	if a := acked(); len(a) != 1 || !a["a.example.com"] {
+		t.Errorf("Unexpected acknowledged nodes: %v", a)
+	}
+
+	states, _ := getStates()
+	for _, s := range states {
+		if s.State == "acknowledged" && s.Count != 1 {
+			t.Errorf("Expected one acknowledged host, found %d", s.Count)
+		}
+		if s.State == "failed" && s.Count != 2 {
+			t.Errorf("Expected two failed hosts, found %d", s.Count)
+		}
+	}
+
+	//
+	// Acknowledge everything failing in the same way.
+	//
+	node, _ := getNodeAck("b.example.com")
+	if node.Fingerprint == "" || node.Ack != nil {
+		t.Fatalf("Unexpected node: %v", node)
+	}
+	err = addAck(&PuppetAck{Fingerprint: node.Fingerprint})
+	if err != nil {
+		t.Fatalf("Failed to acknowledge: %s", err.Error())
+	}
+	if a := acked(); len(a) != 2 || a["c.example.com"] {
+		t.Errorf("Unexpected acknowledged nodes: %v", a)
+	}
+
+	//
+	// We need one of a node or a fingerprint.
+	//
+	if addAck(&PuppetAck{Reason: "steve"}) == nil {
+		t.Errorf("Expected an error for an empty acknowledgement")
+	}
+
+	//
+	// Expired acknowledgements are ignored.
+	//
+	err = addAck(&PuppetAck{Fqdn: "c.example.com", ExpiresAt: time.Now().Unix() - 1})
+	if err != nil {
+		t.Fatalf("Failed to acknowledge: %s", err.Error())
+	}
+	if a := acked(); a["c.example.com"] {
+		t.Errorf("Expired acknowledgement was used")
+	}
+
+	//
+	// Recovering clears the acknowledgement.
+	//
+	n.Fqdn = "a.example.com"
+	n.State = "unchanged"
+	n.ErrorMessages = nil
+	addDB(n, "")
+	n.State = "failed"
+	n.ErrorMessages = []string{"Something else"}
+	addDB(n, "")
+	if a := acked(); a["a.example.com"] {
+		t.Errorf("Acknowledgement survived recovery")
+	}
+
+	//
+	// Once nothing fails with the fingerprint it is removed.
+	//
+	n.Fqdn = "b.example.com"
+	addDB(n, "")
+	expireAcks()
+
+	acks, _ := getAcks()
+	if len(acks) != 0 {
+		t.Errorf("Unexpected acknowledgements: %v", acks)
+	}
+
+	//
+	// A failing node which goes late keeps its acknowledgement.
+	//
+	err = addAck(&PuppetAck{Fqdn: "c.example.com", Reason: "TICKET-2"})
+	if err != nil {
+		t.Fatalf("Failed to acknowledge: %s", err.Error())
+	}
+	db.Exec("UPDATE hosts SET run_interval = 1800, last_seen = ? WHERE fqdn = ?", time.Now().Unix()-1800*4, "c.example.com")
+	updateLate(3)
+	expireAcks()
+
+	node, _ = getNodeAck("c.example.com")
+	if node.State != "late" {
+		t.Errorf("Expected a late node, found %s", node.State)
+	}
+	acks, _ = getAcks()
+	if len(acks) != 1 || acks[0].Fqdn != "c.example.com" {
+		t.Errorf("Acknowledgement didn't survive going late: %v", acks)
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test following the rollout of builds.
+//
+func TestRollout(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	newest := time.Now().Unix() - 3*60*60
+	old := time.Now().Unix() - 2*24*60*60
+
+	add := func(fqdn string, branch string, built int64, states ...string) {
+		var n PuppetReport
+		n.Fqdn = fqdn
+		n.Runtime = "1.0"
+		n.Failed = "0"
+		n.Total = "1"
+		n.Changed = "0"
+		n.Skipped = "0"
+		n.Branch = branch
+		n.BuildTime = built
+
+		for _, state := range states {
+			n.State = state
+			addDB(n, "")
+		}
+	}
+	add("web1.example.com", "production", old, "unchanged")
+	add("web1.example.com", "production", newest, "unchanged")
+	add("web2.example.com", "production", newest, "failed")
+	add("db1.example.com", "production", old, "unchanged", "failed")
+	add("dev1.example.com", "dev", newest, "changed")
+	add("unknown.example.com", "", 0, "changed")
+
+	rollout, err := getRollout(7, time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to get rollout: %s", err.Error())
+	}
+	if len(rollout) != 2 || rollout[0].Branch != "dev" || rollout[1].Branch != "production" {
+		t.Fatalf("Unexpected branches: %v", rollout)
+	}
+
+	prod := rollout[1]
+	if prod.Nodes != 3 || prod.Newest != newest || int(prod.Current) != 66 {
+		t.Errorf("Unexpected rollout: %v", prod)
+	}
+	if len(prod.Builds) != 2 {
+		t.Fatalf("Unexpected builds: %v", prod.Builds)
+	}
+	if prod.Builds[0].BuiltAt != newest || prod.Builds[0].Nodes != 2 || prod.Builds[0].Runs != 2 || prod.Builds[0].FailureRate != 50 {
+		t.Errorf("Unexpected newest build: %v", prod.Builds[0])
+	}
+	if prod.Builds[1].BuiltAt != old || prod.Builds[1].Nodes != 1 || prod.Builds[1].Runs != 3 || prod.Builds[1].Failed != 1 {
+		t.Errorf("Unexpected old build: %v", prod.Builds[1])
+	}
+	if len(prod.Stuck) != 1 || prod.Stuck[0].Fqdn != "db1.example.com" {
+		t.Errorf("Unexpected stuck nodes: %v", prod.Stuck)
+	}
+
+	//
+	// Nodes aren't stuck until the threshold has passed.
+	//
+	rollout, _ = getRollout(7, 4*time.Hour)
+	if len(rollout[1].Stuck) != 0 {
+		t.Errorf("Unexpected stuck nodes: %v", rollout[1].Stuck)
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test summarising runs by role, branch, and environment.
+//
+func TestStats(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	add := func(fqdn string, role string, branch string, runtime string, states ...string) {
+		var n PuppetReport
+		n.Fqdn = fqdn
+		n.Runtime = runtime
+		n.Failed = "0"
+		n.Total = "1"
+		n.Changed = "0"
+		n.Skipped = "0"
+		n.Role = role
+		n.Branch = branch
+		n.Environment = "production"
+
+		for _, state := range states {
+			n.State = state
+			addDB(n, "")
+		}
+	}
+	add("web1.example.com", "web", "master", "10", "unchanged", "changed")
+	add("web2.example.com", "web", "new", "20", "failed", "unchanged")
+	add("db1.example.com", "db", "master", "30", "unchanged", "unchanged")
+	add("old.example.com", "", "", "40", "unchanged")
+
+	stats, err := getStats(7, "branch")
+	if err != nil {
+		t.Fatalf("Failed to get stats: %s", err.Error())
+	}
+	if len(stats) != 3 || stats[0].Name != "" || stats[1].Name != "master" || stats[2].Name != "new" {
+		t.Fatalf("Unexpected stats: %v", stats)
+	}
+	master := stats[1]
+	if master.Nodes != 2 || master.Runs != 4 || master.Failed != 0 || master.ChangeRate != 25 || master.SuccessRate != 100 || master.Runtime != 20 {
+		t.Errorf("Unexpected stats for master: %v", master)
+	}
+	if stats[2].FailureRate != 50 || stats[2].SuccessRate != 50 {
+		t.Errorf("Unexpected stats for new: %v", stats[2])
+	}
+
+	stats, _ = getStats(7, "role")
+	if len(stats) != 3 || stats[2].Name != "web" || stats[2].Nodes != 2 || stats[2].Runs != 4 {
+		t.Errorf("Unexpected stats: %v", stats)
+	}
+
+	stats, _ = getStats(7, "environment")
+	if len(stats) != 1 || stats[0].Name != "production" || stats[0].Nodes != 4 {
+		t.Errorf("Unexpected stats: %v", stats)
+	}
+
+	_, err = getStats(7, "fqdn; DROP TABLE reports")
+	if err == nil {
+		t.Errorf("Expected an error grouping by an unknown column")
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test the history of each role, and branch.
+//
+func TestRollups(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	add := func(fqdn string, role string, branch string, states ...string) {
+		var n PuppetReport
+		n.Fqdn = fqdn
+		n.Runtime = "1.0"
+		n.Role = role
+		n.Branch = branch
+		n.Environment = "production"
+
+		for _, state := range states {
+			n.State = state
+			addDB(n, "")
+		}
+	}
+	add("web1.example.com", "web", "production", "unchanged", "unchanged", "unchanged", "failed")
+	add("web2.example.com", "web", "feature/foo", "unchanged")
+	add("db1.example.com", "db", "production", "failed")
+
+	now := time.Now().Unix()
+	day, _ := historyResolution("day")
+	hour, _ := historyResolution("hour")
+
+	//
+	// Three unchanged reports from one node are one node.
+	//
+	rollups, err := getRollups(day, "role", "web", now-3600, now+1)
+	if err != nil {
+		t.Fatalf("Failed to get rollups: %s", err.Error())
+	}
+	if len(rollups) != 1 || rollups[0].Unchanged != 4 || rollups[0].UnchangedNodes != 2 || rollups[0].Failed != 1 || rollups[0].FailedNodes != 1 {
+		t.Errorf("Unexpected rollups: %v", rollups)
+	}
+	if rollups[0].Date != time.Now().UTC().Format("2006/01/02") {
+		t.Errorf("Unexpected date: %s", rollups[0].Date)
+	}
+
+	rollups, _ = getRollups(hour, "", "", now-3600, now+1)
+	if len(rollups) != 1 || rollups[0].Bucket != hour.bucket(now) || rollups[0].Failed != 2 || rollups[0].FailedNodes != 2 {
+		t.Errorf("Unexpected rollups: %v", rollups)
+	}
+
+	rollups, _ = getRollups(day, "environment", "production", now-3600, now+1)
+	if len(rollups) != 1 || rollups[0].Unchanged != 4 || rollups[0].Failed != 2 {
+		t.Errorf("Unexpected rollups: %v", rollups)
+	}
+
+	//
+	// Without a name we get the rollups of every branch.
+	//
+	rollups, _ = getRollups(day, "branch", "", now-3600, now+1)
+	if len(rollups) != 2 || rollups[0].Name != "feature/foo" || rollups[1].Name != "production" || rollups[1].FailedNodes != 2 {
+		t.Errorf("Unexpected rollups: %v", rollups)
+	}
+
+	//
+	// Gaps in our history are filled in.
+	//
+	rollups, _ = getRollups(hour, "", "", now-3600, now+1)
+	filled := fillRollups(rollups, hour, hour.bucket(now)-2*3600, hour.bucket(now)+3600)
+	if len(filled) != 3 || filled[0].Failed != 0 || filled[0].Date != hour.label(hour.bucket(now)-2*3600) || filled[2].Failed != 2 {
+		t.Errorf("Unexpected filled rollups: %v", filled)
+	}
+
+	nodes, _ := getSliceNodes("branch", "production")
+	if len(nodes) != 2 {
+		t.Errorf("Unexpected nodes: %v", nodes)
+	}
+	nodes, _ = getSliceNodes("role", "db")
+	if len(nodes) != 1 || nodes[0].Fqdn != "db1.example.com" {
+		t.Errorf("Unexpected nodes: %v", nodes)
+	}
+
+	//
+	// Rollups older than the retention of their resolution are pruned.
+	//
+	old := now - 30*24*60*60
+	updateRollups("old.example.com", "web", "", "", "failed", old)
+
+	rollups, _ = getRollups(day, "", "", old, now+1)
+	if len(rollups) != 2 {
+		t.Errorf("Unexpected rollups: %v", rollups)
+	}
+	rollups, _ = getRollups(hour, "", "", old, now+1)
+	if len(rollups) != 2 {
+		t.Errorf("Unexpected rollups: %v", rollups)
+	}
+
+	pruneRollups()
+
+	rollups, _ = getRollups(day, "", "", old, now+1)
+	if len(rollups) != 2 {
+		t.Errorf("Daily rollups were pruned too soon: %v", rollups)
+	}
+	rollups, _ = getRollups(hour, "", "", old, now+1)
+	if len(rollups) != 1 {
+		t.Errorf("Hourly rollups weren't pruned: %v", rollups)
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
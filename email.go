@@ -0,0 +1,279 @@
+//
+// Send alerts, and a daily digest, via email.
+//
+// New failures are queued in the database, like webhooks, and are sent
+// as a single mail per batch so that a widespread failure doesn't flood
+// anybody's inbox.
+//
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/smtp"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+//
+// The SMTP server we send mail via, and the credentials to use, if any.
+//
+var (
+	SMTPHost     = "localhost"
+	SMTPPort     = 25
+	SMTPUser     string
+	SMTPPassword string
+)
+
+//
+// EmailFrom is the sender of the mails we send.
+//
+var EmailFrom = "puppet-summary@localhost"
+
+//
+// EmailTo are the recipients of the mails we send, if empty no mails
+// are sent.
+//
+var EmailTo []string
+
+//
+// DigestError is an error message, and the number of failed runs which
+// logged it.
+//
+type DigestError struct {
+	Message string
+	Count   int
+}
+
+//
+// Digest holds the contents of our daily digest.
+//
+type Digest struct {
+	Date     string
+	Failing  []PuppetRuns
+	Flapping []PuppetRuns
+	Orphans  []PuppetTransition
+	Slowest  []PuppetRuntime
+	Errors   []DigestError
+}
+
+//
+// Send a mail, with the given subject and body, to all our recipients.
+//
+func sendMail(subject string, body string) error {
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", EmailFrom)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(EmailTo, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "Content-Type: text/plain; charset=utf-8\r\n")
+	fmt.Fprintf(&msg, "\r\n")
+	msg.WriteString(strings.Replace(body, "\n", "\r\n", -1))
+
+	var auth smtp.Auth
+	if SMTPUser != "" {
+		auth = smtp.PlainAuth("", SMTPUser, SMTPPassword, SMTPHost)
+	}
+
+	addr := net.JoinHostPort(SMTPHost, strconv.Itoa(SMTPPort))
+	return smtp.SendMail(addr, auth, EmailFrom, EmailTo, msg.Bytes())
+}
+
+//
+// Render one of our embedded templates.
+//
+func renderEmail(name string, data interface{}) (string, error) {
+
+	src, err := getResource("data/" + name)
+	if err != nil {
+		return "", err
+	}
+
+	tmpl, err := template.New(name).Parse(string(src))
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, data)
+	return buf.String(), err
+}
+
+//
+// queueFailureEmail records a node which has started failing, to be
+// included in the next batch of mail.
+//
+func queueFailureEmail(t PuppetTransition) {
+	payload, _ := json.Marshal(t)
+	err := addNotification("email", "", string(payload))
+	if err != nil {
+		fmt.Printf("Error queuing email for %s: %s\n", t.Fqdn, err.Error())
+	}
+}
+
+//
+// deliverEmails sends a single mail describing all the failures which
+// have been queued, and are due.
+//
+func deliverEmails() {
+
+	if len(EmailTo) < 1 {
+		return
+	}
+
+	now := time.Now().Unix()
+
+	queued, err := getNotifications("email", now, 1000)
+	if err != nil || len(queued) < 1 {
+		return
+	}
+
+	var failures []PuppetTransition
+	for _, n := range queued {
+		var t PuppetTransition
+		if json.Unmarshal([]byte(n.Payload), &t) == nil {
+			failures = append(failures, t)
+		}
+	}
+
+	body, err := renderEmail("email.template", failures)
+	if err == nil {
+		subject := fmt.Sprintf("[puppet-summary] %d node(s) started failing", len(failures))
+		if len(failures) == 1 {
+			subject = fmt.Sprintf("[puppet-summary] %s started failing", failures[0].Fqdn)
+		}
+		err = sendMail(subject, body)
+	}
+
+	for _, n := range queued {
+		if err == nil {
+			deleteNotification(n.ID)
+			continue
+		}
+
+		attempts := n.Attempts + 1
+		if attempts >= WebhookMaxAttempts {
+			deleteNotification(n.ID)
+			continue
+		}
+		retryNotification(n.ID, attempts, now+webhookBackoff(attempts), err.Error())
+	}
+
+	if err != nil {
+		fmt.Printf("Error sending email: %s\n", err.Error())
+	}
+}
+
+//
+// Gather the contents of the digest, covering the past day.
+//
+func getDigest() (Digest, error) {
+
+	var d Digest
+	d.Date = time.Now().Format("2006-01-02")
+	since := time.Now().Unix() - 24*60*60
+
+	nodes, err := getIndexNodes()
+	if err != nil {
+		return d, err
+	}
+	for _, n := range nodes {
+
+		//
+		// Flapping nodes are listed alone, rather than as failing
+		// upon the days they happen to have failed last.
+		//
+		if SuppressFlapping && n.Flapping {
+			d.Flapping = append(d.Flapping, n)
+			continue
+		}
+		if n.State == "failed" {
+			d.Failing = append(d.Failing, n)
+		}
+	}
+	sort.Slice(d.Failing, func(i, j int) bool { return d.Failing[i].Fqdn < d.Failing[j].Fqdn })
+	sort.Slice(d.Flapping, func(i, j int) bool { return d.Flapping[i].Fqdn < d.Flapping[j].Fqdn })
+
+	d.Orphans, err = getRecentTransitions("orphaned", since)
+	if err != nil {
+		return d, err
+	}
+
+	slowest, err := getRuntimeBaselines(1, false)
+	if err != nil {
+		return d, err
+	}
+	if len(slowest) > 5 {
+		slowest = slowest[:5]
+	}
+	d.Slowest = slowest
+
+	//
+	// Count the error messages logged by the failed runs, which
+	// means reading their reports back from disk.
+	//
+	files, err := getFailedReportFiles(since, 200)
+	if err != nil {
+		return d, err
+	}
+
+	counts := make(map[string]int)
+	for _, file := range files {
+		content, err := ioutil.ReadFile(filepath.Join(ReportPrefix, file))
+		if err != nil {
+			continue
+		}
+		report, err := ParsePuppetReport(content)
+		if err != nil {
+			continue
+		}
+		for _, msg := range report.ErrorMessages {
+			counts[msg]++
+		}
+	}
+	for msg, count := range counts {
+		d.Errors = append(d.Errors, DigestError{Message: msg, Count: count})
+	}
+	sort.Slice(d.Errors, func(i, j int) bool {
+		if d.Errors[i].Count != d.Errors[j].Count {
+			return d.Errors[i].Count > d.Errors[j].Count
+		}
+		return d.Errors[i].Message < d.Errors[j].Message
+	})
+	if len(d.Errors) > 10 {
+		d.Errors = d.Errors[:10]
+	}
+
+	return d, nil
+}
+
+//
+// sendDigest mails the daily digest.
+//
+func sendDigest() {
+
+	if len(EmailTo) < 1 {
+		return
+	}
+
+	d, err := getDigest()
+	if err == nil {
+		var body string
+		body, err = renderEmail("digest.template", d)
+		if err == nil {
+			err = sendMail(fmt.Sprintf("[puppet-summary] Daily digest for %s", d.Date), body)
+		}
+	}
+	if err != nil {
+		fmt.Printf("Error sending digest: %s\n", err.Error())
+	}
+}
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"bufio"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+//
+// A fake SMTP server, which records the body of each mail it receives,
+// or rejects them all if `reject` is set.
+//
+func fakeSMTP(t *testing.T, reject bool) (chan string, func()) {
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oldHost, oldPort := SMTPHost, SMTPPort
+	SMTPHost = "127.0.0.1"
+	SMTPPort = ln.Addr().(*net.TCPAddr).Port
+
+	mails := make(chan string, 10)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				reply := func(msg string) { conn.Write([]byte(msg + "\r\n")) }
+
+				reply("220 localhost ESMTP")
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					cmd := strings.ToUpper(strings.TrimSpace(line))
+					switch {
+					case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+						reply("250 localhost")
+					case strings.HasPrefix(cmd, "MAIL"):
+						if reject {
+							reply("451 try again later")
+						} else {
+							reply("250 OK")
+						}
+					case strings.HasPrefix(cmd, "RCPT"), strings.HasPrefix(cmd, "RSET"):
+						reply("250 OK")
+					case cmd == "DATA":
+						reply("354 go ahead")
+						var body strings.Builder
+						for {
+							line, err := r.ReadString('\n')
+							if err != nil || line == ".\r\n" {
+								break
+							}
+							body.WriteString(line)
+						}
+						mails <- body.String()
+						reply("250 OK")
+					case cmd == "QUIT":
+						reply("221 bye")
+						return
+					default:
+						reply("502 unknown")
+					}
+				}
+			}(conn)
+		}
+	}()
+
+	return mails, func() {
+		ln.Close()
+		SMTPHost, SMTPPort = oldHost, oldPort
+	}
+}
+
+//
+// Test that new failures are mailed, in a single batch.
+//
+func TestFailureEmail(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	mails, done := fakeSMTP(t, false)
+	defer done()
+
+	EmailTo = []string{"ops@example.com"}
+	defer func() { EmailTo = nil }()
+
+	addFakeRuns("foo.example.com", "unchanged", "failed", "failed")
+	addFakeRuns("bar.example.com", "changed", "failed")
+
+	deliverEmails()
+
+	select {
+	case mail := <-mails:
+		if !strings.Contains(mail, "Subject: [puppet-summary] 2 node(s) started failing") {
+			t.Errorf("Unexpected subject: %s", mail)
+		}
+		if !strings.Contains(mail, "foo.example.com") || !strings.Contains(mail, "bar.example.com") {
+			t.Errorf("Missing nodes: %s", mail)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timed out waiting for mail")
+	}
+
+	queued, _ := getNotifications("email", time.Now().Unix()+24*60*60, 100)
+	if len(queued) != 0 {
+		t.Errorf("Mailed notifications should be removed")
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test that nodes which are flapping aren't mailed each time they fail.
+//
+func TestFailureEmailFlapping(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	EmailTo = []string{"ops@example.com"}
+	defer func() { EmailTo = nil }()
+
+	addFakeRuns("flaky.example.com", "unchanged", "failed", "unchanged", "failed", "unchanged", "failed")
+
+	queued, _ := getNotifications("email", time.Now().Unix(), 100)
+	if len(queued) != 1 {
+		t.Errorf("Unexpected queue: %v", queued)
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test that failures are kept if the mail can't be sent.
+//
+func TestFailureEmailRetry(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	_, done := fakeSMTP(t, true)
+	defer done()
+
+	EmailTo = []string{"ops@example.com"}
+	defer func() { EmailTo = nil }()
+
+	addFakeRuns("foo.example.com", "unchanged", "failed")
+
+	deliverEmails()
+
+	queued, _ := getNotifications("email", time.Now().Unix()+24*60*60, 100)
+	if len(queued) != 1 || queued[0].Attempts != 1 || !strings.Contains(queued[0].LastError, "451") {
+		t.Errorf("Unexpected queue: %v", queued)
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test the daily digest is gathered, and sent.
+//
+func TestDigest(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	mails, done := fakeSMTP(t, false)
+	defer done()
+
+	EmailTo = []string{"ops@example.com"}
+	defer func() { EmailTo = nil }()
+
+	addFakeRuns("foo.example.com", "unchanged", "failed")
+	addFakeRuns("bar.example.com", "changed")
+	addFakeRuns("flaky.example.com", "unchanged", "failed", "unchanged", "failed")
+
+	d, err := getDigest()
+	if err != nil {
+		t.Fatalf("Failed to get digest: %s", err.Error())
+	}
+	if len(d.Failing) != 1 || d.Failing[0].Fqdn != "foo.example.com" {
+		t.Errorf("Unexpected failing nodes: %v", d.Failing)
+	}
+	if len(d.Flapping) != 1 || d.Flapping[0].Fqdn != "flaky.example.com" {
+		t.Errorf("Unexpected flapping nodes: %v", d.Flapping)
+	}
+
+	sendDigest()
+
+	select {
+	case mail := <-mails:
+		if !strings.Contains(mail, "Subject: [puppet-summary] Daily digest for "+d.Date) {
+			t.Errorf("Unexpected subject: %s", mail)
+		}
+		if !strings.Contains(mail, "Failing nodes (1):") || !strings.Contains(mail, "foo.example.com") || !strings.Contains(mail, "Flapping nodes (1):") {
+			t.Errorf("Unexpected digest: %s", mail)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timed out waiting for mail")
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
@@ -0,0 +1,114 @@
+//
+// Events, or annotations, which are drawn upon our graphs.
+//
+// An event is recorded via `POST /api/v1/events`, or the `annotate`
+// sub-command, and is placed upon the label of each graph which it
+// falls within.
+//
+
+package main
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//
+// ChartMarker places an event upon the label of a graph.
+//
+type ChartMarker struct {
+	Label string
+	Text  string
+}
+
+//
+// Check an event is complete, tidying its tags.
+//
+func validateEvent(event *PuppetEvent) error {
+	event.Text = strings.TrimSpace(event.Text)
+	if event.Text == "" {
+		return errors.New("an event needs some text")
+	}
+
+	var tags []string
+	for _, tag := range event.Tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		if strings.Contains(tag, ",") {
+			return errors.New("tags may not contain commas")
+		}
+		tags = append(tags, tag)
+	}
+	event.Tags = tags
+
+	if event.At == 0 {
+		event.At = time.Now().Unix()
+	}
+	return nil
+}
+
+//
+// Split a comma-separated list of tags.
+//
+func splitTags(value string) []string {
+	var tags []string
+	for _, tag := range strings.Split(value, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+//
+// Place events upon the stacked history graph, which has one bar per
+// bucket of the given resolution.
+//
+func historyMarkers(events []PuppetEvent, graph []PuppetRollup, r HistoryResolution) []ChartMarker {
+	buckets := make(map[int64]string)
+	for _, h := range graph {
+		buckets[h.Bucket] = h.Date
+	}
+
+	var res []ChartMarker
+	for _, e := range events {
+		if label, ok := buckets[r.bucket(e.At)]; ok {
+			res = append(res, ChartMarker{Label: label, Text: e.Text})
+		}
+	}
+	return res
+}
+
+//
+// Place events upon the runtime graph of a node, which has one point per
+// report, labeled from "1" for the most recent.
+//
+// Each event is placed upon the first run which followed it, so events
+// newer than the latest run, or older than the oldest, are skipped.
+//
+func runMarkers(events []PuppetEvent, reports []PuppetReportSummary) []ChartMarker {
+	var res []ChartMarker
+	for _, e := range events {
+		next := -1
+		older := false
+		for i, r := range reports {
+			at, err := time.ParseInLocation("2006-01-02 15:04:05", r.At, time.Local)
+			if err != nil {
+				continue
+			}
+			if at.Unix() < e.At {
+				older = true
+				break
+			}
+			next = i
+		}
+		if older && next >= 0 {
+			res = append(res, ChartMarker{Label: strconv.Itoa(next + 1), Text: e.Text})
+		}
+	}
+	return res
+}
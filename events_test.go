@@ -0,0 +1,112 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+//
+// Test events are checked, and tidied.
+//
+func TestValidateEvent(t *testing.T) {
+
+	event := PuppetEvent{Text: "  "}
+	if validateEvent(&event) == nil {
+		t.Errorf("Expected an error for an event without text")
+	}
+
+	event = PuppetEvent{Text: "deploy", Tags: []string{"a,b"}}
+	if validateEvent(&event) == nil {
+		t.Errorf("Expected an error for a tag containing a comma")
+	}
+
+	event = PuppetEvent{Text: " deployed ", Tags: splitTags("production, ,web")}
+	if err := validateEvent(&event); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	if event.Text != "deployed" || strings.Join(event.Tags, "|") != "production|web" {
+		t.Errorf("Event wasn't tidied: %v", event)
+	}
+	if event.At < time.Now().Unix()-5 {
+		t.Errorf("Event didn't default to now: %d", event.At)
+	}
+}
+
+//
+// Test events are placed upon the correct labels of our graphs.
+//
+func TestChartMarkers(t *testing.T) {
+
+	at := time.Date(2017, 7, 29, 12, 0, 0, 0, time.UTC).Unix()
+	events := []PuppetEvent{
+		{Text: "one", At: at},
+		{Text: "two", At: at + 24*60*60},
+	}
+
+	day, _ := historyResolution("day")
+	graph := fillRollups(nil, day, at-24*60*60, at)
+	markers := historyMarkers(events, graph, day)
+	if len(markers) != 1 || markers[0].Label != "2017/07/29" || markers[0].Text != "one" {
+		t.Errorf("Unexpected history markers: %v", markers)
+	}
+
+	//
+	// Reports are newest first.
+	//
+	format := func(offset int64) string {
+		return time.Unix(at+offset, 0).Format("2006-01-02 15:04:05")
+	}
+	reports := []PuppetReportSummary{
+		{At: format(3600)},
+		{At: format(1800)},
+		{At: format(-1800)},
+	}
+	markers = runMarkers(events, reports)
+	if len(markers) != 1 || markers[0].Label != "2" || markers[0].Text != "one" {
+		t.Errorf("Unexpected run markers: %v", markers)
+	}
+
+	//
+	// An event before our oldest report isn't shown.
+	//
+	markers = runMarkers([]PuppetEvent{{Text: "old", At: at - 3600}}, reports)
+	if len(markers) != 0 {
+		t.Errorf("Unexpected run markers: %v", markers)
+	}
+}
+
+//
+// Test events may be stored, and retrieved by tag.
+//
+func TestEvents(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	addEvent(&PuppetEvent{Text: "old", At: 100})
+	addEvent(&PuppetEvent{Text: "deployed", Tags: []string{"deploy", "production"}, At: 2000})
+	addEvent(&PuppetEvent{Text: "rebooted", At: 1000})
+
+	events, err := getEvents(500, "")
+	if err != nil {
+		t.Fatalf("Failed to get events: %s", err.Error())
+	}
+	if len(events) != 2 || events[0].Text != "rebooted" || events[1].Tags[1] != "production" {
+		t.Errorf("Unexpected events: %v", events)
+	}
+
+	events, _ = getEvents(0, "production")
+	if len(events) != 1 || events[0].Text != "deployed" {
+		t.Errorf("Unexpected events: %v", events)
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
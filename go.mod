@@ -1,8 +1,9 @@
 module github.com/skx/puppet-summary
 
-go 1.12
+go 1.24.0
 
 require (
+	github.com/go-sql-driver/mysql v1.10.1
 	github.com/google/subcommands v1.0.1
 	github.com/gorilla/handlers v1.4.0
 	github.com/gorilla/mux v1.7.0
@@ -11,5 +12,10 @@ require (
 	github.com/robfig/cron v0.0.0-20180505203441-b41be1df6967
 	github.com/skx/golang-metrics v0.0.0-20180606065905-85a4b4e0641f
 	github.com/smallfish/simpleyaml v0.0.0-20170911015856-a32031077861
+)
+
+require (
+	filippo.io/edwards25519 v1.2.0 // indirect
+	gopkg.in/check.v1 v0.0.0-20161208181325-20d25e280405 // indirect
 	gopkg.in/yaml.v2 v2.2.2 // indirect
 )
@@ -0,0 +1,177 @@
+//
+// The history of our nodes, rolled up into hourly and daily buckets.
+//
+// Each report is counted in the bucket it was executed within, for all
+// nodes, and for the nodes with its role, environment, and git branch.
+// Both the number of reports in each state, and the number of distinct
+// nodes which reported that state, are counted.
+//
+
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+//
+// HistoryResolution is a period our history is rolled up into, along
+// with how long those rollups are kept.
+//
+type HistoryResolution struct {
+	Name      string
+	Seconds   int64
+	Retention time.Duration
+	Range     string
+	Format    string
+}
+
+//
+// HistoryResolutions are the resolutions of our history.  The `Range` is
+// the period shown by default, and `Format` is used to label each bucket.
+//
+var HistoryResolutions = []HistoryResolution{
+	{Name: "hour", Seconds: 60 * 60, Retention: 7 * 24 * time.Hour, Range: "48h", Format: "2006/01/02 15:00"},
+	{Name: "day", Seconds: 24 * 60 * 60, Retention: 90 * 24 * time.Hour, Range: "14d", Format: "2006/01/02"},
+}
+
+//
+// HistoryDimensions are the columns of a report our history may be
+// grouped by.
+//
+var HistoryDimensions = []string{"role", "environment", "branch"}
+
+//
+// PuppetRollup holds the counts of reports, and of distinct nodes, in
+// each state within a bucket of our history.
+//
+type PuppetRollup struct {
+	Name           string
+	Bucket         int64
+	Date           string
+	Failed         int
+	Changed        int
+	Unchanged      int
+	FailedNodes    int
+	ChangedNodes   int
+	UnchangedNodes int
+}
+
+//
+// Find the resolution with the given name.
+//
+func historyResolution(name string) (HistoryResolution, error) {
+	for _, r := range HistoryResolutions {
+		if r.Name == name {
+			return r, nil
+		}
+	}
+	return HistoryResolution{}, fmt.Errorf("unknown resolution '%s', expected hour or day", name)
+}
+
+//
+// Is the given name a dimension our history may be grouped by?
+//
+func historyDimension(name string) bool {
+	for _, d := range HistoryDimensions {
+		if d == name {
+			return true
+		}
+	}
+	return false
+}
+
+//
+// Work out the window of history to show, from the user's `resolution`
+// along with either a `period` before now, or the epochs `from` and `to`.
+//
+func historyWindow(resolution string, period string, from string, to string) (HistoryResolution, int64, int64, error) {
+	if len(resolution) < 1 {
+		resolution = "day"
+	}
+	r, err := historyResolution(resolution)
+	if err != nil {
+		return r, 0, 0, err
+	}
+
+	end := time.Now().Unix()
+	if len(to) > 0 {
+		end, err = parseFormTime(to)
+		if err != nil {
+			return r, 0, 0, err
+		}
+	}
+
+	if len(from) > 0 {
+		start, err := parseFormTime(from)
+		if err != nil {
+			return r, 0, 0, err
+		}
+		if start >= end {
+			return r, 0, 0, fmt.Errorf("the window must end after it starts")
+		}
+		return r, start, end, nil
+	}
+
+	if len(period) < 1 {
+		period = r.Range
+	}
+	d, err := parsePeriod(period)
+	if err != nil {
+		return r, 0, 0, err
+	}
+	return r, end - int64(d.Seconds()), end, nil
+}
+
+//
+// Label a bucket of our history, which is bucketed in UTC.
+//
+func (r HistoryResolution) label(bucket int64) string {
+	return time.Unix(bucket, 0).UTC().Format(r.Format)
+}
+
+//
+// Find the bucket containing the given time.
+//
+func (r HistoryResolution) bucket(at int64) int64 {
+	return at - at%r.Seconds
+}
+
+//
+// Fill in the buckets of a window which no reports were received within,
+// so that our graphs show the gaps.
+//
+func fillRollups(rollups []PuppetRollup, r HistoryResolution, from int64, to int64) []PuppetRollup {
+	found := make(map[int64]PuppetRollup)
+	for _, x := range rollups {
+		found[x.Bucket] = x
+	}
+
+	var res []PuppetRollup
+	for bucket := r.bucket(from); bucket < to; bucket += r.Seconds {
+		x, ok := found[bucket]
+		if !ok {
+			x = PuppetRollup{Bucket: bucket, Date: r.label(bucket)}
+		}
+		res = append(res, x)
+	}
+	return res
+}
+
+//
+// Set how long the rollups of each resolution are kept, from periods
+// such as "7d".
+//
+func setHistoryRetention(hourly string, daily string) error {
+	for i, period := range []string{hourly, daily} {
+		d, err := parsePeriod(period)
+		if err != nil {
+			return err
+		}
+		if d < time.Duration(HistoryResolutions[i].Seconds)*time.Second {
+			return fmt.Errorf("the %sly history must be kept for at least one %s", HistoryResolutions[i].Name, HistoryResolutions[i].Name)
+		}
+		HistoryResolutions[i].Retention = d
+	}
+	return nil
+}
@@ -27,8 +27,12 @@ func main() {
 	subcommands.Register(subcommands.HelpCommand(), "")
 	subcommands.Register(subcommands.FlagsCommand(), "")
 	subcommands.Register(subcommands.CommandsCommand(), "")
+	subcommands.Register(&annotateCmd{}, "")
+	subcommands.Register(&importCmd{}, "")
+	subcommands.Register(&ingestCmd{}, "")
 	subcommands.Register(&metricsCmd{}, "")
 	subcommands.Register(&pruneCmd{}, "")
+	subcommands.Register(&rebuildCmd{}, "")
 	subcommands.Register(&serveCmd{}, "")
 	subcommands.Register(&versionCmd{}, "")
 	subcommands.Register(&yamlCmd{}, "")
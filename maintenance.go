@@ -0,0 +1,152 @@
+//
+// Maintenance windows, during which failures are expected.
+//
+// While a node is within a window it is marked upon the index, changes
+// to its state aren't notified, it is ignored by alert-rules, and any
+// failures which start are excluded from its reliability figures.
+//
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron"
+)
+
+//
+// Does the window cover the given node?
+//
+func (w PuppetMaintenance) covers(fqdn string, role string) bool {
+	if w.Fqdn != "" {
+		match, _ := filepath.Match(w.Fqdn, fqdn)
+		if !match {
+			return false
+		}
+	}
+	if w.Role != "" && w.Role != role {
+		return false
+	}
+	return true
+}
+
+//
+// Is the window open at the given time?
+//
+func (w PuppetMaintenance) activeAt(at int64) bool {
+	if w.Schedule == "" {
+		return at >= w.StartsAt && at < w.EndsAt
+	}
+
+	schedule, err := cron.ParseStandard(w.Schedule)
+	if err != nil {
+		return false
+	}
+
+	//
+	// The window is open if it started within the past `Duration`
+	// seconds.
+	//
+	started := schedule.Next(time.Unix(at-w.Duration, 0))
+	return started.Unix() <= at
+}
+
+//
+// Describe when the window is open.
+//
+func (w PuppetMaintenance) When() string {
+	if w.Schedule != "" {
+		return fmt.Sprintf("%s, for %s", w.Schedule, time.Duration(w.Duration)*time.Second)
+	}
+	format := "2006-01-02 15:04"
+	return time.Unix(w.StartsAt, 0).Format(format) + " until " + time.Unix(w.EndsAt, 0).Format(format)
+}
+
+//
+// Active reports whether the window is open now.
+//
+func (w PuppetMaintenance) Active() bool {
+	return w.activeAt(time.Now().Unix())
+}
+
+//
+// Check a window is complete, and can be parsed.
+//
+func validateMaintenance(w PuppetMaintenance) error {
+	if w.Name == "" {
+		return errors.New("a maintenance window needs a name")
+	}
+	if w.Fqdn == "" && w.Role == "" {
+		return errors.New("a maintenance window needs an fqdn pattern, or a role")
+	}
+	if w.Fqdn != "" {
+		if _, err := filepath.Match(w.Fqdn, ""); err != nil {
+			return fmt.Errorf("invalid fqdn pattern '%s'", w.Fqdn)
+		}
+	}
+
+	if w.Schedule != "" {
+		_, err := cron.ParseStandard(w.Schedule)
+		if err != nil {
+			return fmt.Errorf("invalid schedule '%s': %s", w.Schedule, err.Error())
+		}
+		if w.Duration <= 0 {
+			return errors.New("a recurring maintenance window needs a duration")
+		}
+		return nil
+	}
+
+	if w.EndsAt <= w.StartsAt {
+		return errors.New("a maintenance window must end after it starts")
+	}
+	return nil
+}
+
+//
+// Find the window, if any, which covers the given node at the given
+// time.
+//
+func maintenanceWindow(windows []PuppetMaintenance, fqdn string, role string, at int64) *PuppetMaintenance {
+	for i := range windows {
+		if windows[i].covers(fqdn, role) && windows[i].activeAt(at) {
+			return &windows[i]
+		}
+	}
+	return nil
+}
+
+//
+// inMaintenance reports whether the given node is currently within a
+// maintenance window.
+//
+func inMaintenance(fqdn string, role string) bool {
+	windows, err := getMaintenance()
+	if err != nil {
+		return false
+	}
+	return maintenanceWindow(windows, fqdn, role, time.Now().Unix()) != nil
+}
+
+//
+// Parse a time given in a form, either in seconds past the epoch, or in
+// the local time-zone.
+//
+func parseFormTime(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	if epoch, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return epoch, nil
+	}
+	for _, format := range []string{"2006-01-02 15:04", "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(format, strings.TrimSpace(value), time.Local); err == nil {
+			return t.Unix(), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid time '%s', expected something like 2006-01-02 15:04", value)
+}
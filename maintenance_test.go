@@ -0,0 +1,137 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+//
+// Test which nodes, and times, a window covers.
+//
+func TestMaintenanceActive(t *testing.T) {
+
+	oneoff := PuppetMaintenance{Fqdn: "web*.example.com", StartsAt: 1000, EndsAt: 2000}
+	if !oneoff.covers("web1.example.com", "") || oneoff.covers("db1.example.com", "") {
+		t.Errorf("Unexpected fqdn matching")
+	}
+	if oneoff.activeAt(999) || !oneoff.activeAt(1000) || !oneoff.activeAt(1999) || oneoff.activeAt(2000) {
+		t.Errorf("Unexpected one-off window")
+	}
+
+	byRole := PuppetMaintenance{Role: "db"}
+	if !byRole.covers("db1.example.com", "db") || byRole.covers("db1.example.com", "web") {
+		t.Errorf("Unexpected role matching")
+	}
+
+	//
+	// Every day at 02:00, for two hours.
+	//
+	daily := PuppetMaintenance{Schedule: "0 2 * * *", Duration: 2 * 60 * 60}
+	day := time.Date(2017, 7, 29, 0, 0, 0, 0, time.Local)
+	tests := map[int]bool{1: false, 2: true, 3: true, 4: false, 23: false}
+	for hour, expected := range tests {
+		at := day.Add(time.Duration(hour) * time.Hour).Add(time.Minute).Unix()
+		if daily.activeAt(at) != expected {
+			t.Errorf("Unexpected state at %02d:01", hour)
+		}
+	}
+}
+
+//
+// Test broken windows are rejected.
+//
+func TestValidateMaintenance(t *testing.T) {
+
+	tests := map[string]PuppetMaintenance{
+		"needs a name":         {Fqdn: "*", StartsAt: 1, EndsAt: 2},
+		"fqdn pattern, or a":   {Name: "x", StartsAt: 1, EndsAt: 2},
+		"invalid fqdn pattern": {Name: "x", Fqdn: "[", StartsAt: 1, EndsAt: 2},
+		"invalid schedule":     {Name: "x", Role: "web", Schedule: "steve", Duration: 1},
+		"needs a duration":     {Name: "x", Role: "web", Schedule: "@daily"},
+		"must end after":       {Name: "x", Role: "web", StartsAt: 2, EndsAt: 1},
+	}
+	for expected, window := range tests {
+		err := validateMaintenance(window)
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected an error containing '%s', got %v", expected, err)
+		}
+	}
+
+	if validateMaintenance(PuppetMaintenance{Name: "x", Role: "web", Schedule: "@daily", Duration: 60}) != nil {
+		t.Errorf("Unexpected error for a valid window")
+	}
+}
+
+//
+// Test that nodes in maintenance are marked, and don't notify or count
+// against their reliability.
+//
+func TestMaintenanceSuppression(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	Webhooks = []string{"http://localhost/"}
+	defer func() { Webhooks = nil }()
+
+	now := time.Now().Unix()
+	err := addMaintenance(&PuppetMaintenance{Name: "patching", Fqdn: "web*", StartsAt: now - 60, EndsAt: now + 3600})
+	if err != nil {
+		t.Fatalf("Failed to add window: %s", err.Error())
+	}
+
+	addFakeRuns("web1.example.com", "unchanged", "failed", "unchanged")
+	addFakeRuns("db1.example.com", "unchanged", "failed")
+
+	//
+	// Only the node outside the window notifies.
+	//
+	queued, _ := getNotifications("webhook", now+24*60*60, 100)
+	if len(queued) != 1 || !strings.Contains(queued[0].Payload, "db1.example.com") {
+		t.Errorf("Unexpected notifications: %v", queued)
+	}
+
+	nodes, _ := getIndexNodes()
+	for _, node := range nodes {
+		if (node.Maintenance == "patching") != (node.Fqdn == "web1.example.com") {
+			t.Errorf("Unexpected maintenance marker for %s: '%s'", node.Fqdn, node.Maintenance)
+		}
+	}
+
+	//
+	// The failure within the window isn't counted.
+	//
+	reliability, err := getReliability(false)
+	if err != nil {
+		t.Fatalf("Failed to get reliability: %s", err.Error())
+	}
+	for _, r := range reliability {
+		if r.Name == "web1.example.com" && r.Failures != 0 {
+			t.Errorf("Failure during maintenance was counted: %v", r)
+		}
+		if r.Name == "db1.example.com" && r.Failures != 1 {
+			t.Errorf("Failure wasn't counted: %v", r)
+		}
+	}
+
+	//
+	// Nor is a node in maintenance considered by alert-rules.
+	//
+	rule := AlertRule{State: "failed"}
+	addFakeRuns("web1.example.com", "failed")
+	nodes, _ = getIndexNodes()
+	value, _ := rule.value(nodes, now)
+	if value != 1 {
+		t.Errorf("Unexpected value: %f", value)
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
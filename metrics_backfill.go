@@ -0,0 +1,135 @@
+//
+// Rebuild historical metrics from the reports, and history, we hold.
+//
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+//
+// backfillPoints returns the metrics we would have sent at the end of
+// each period of the given resolution, between `since` and `until`.
+//
+// For each period we send the state-counts of the nodes, based upon
+// their latest report at that time, along with the number of reports
+// received, the resources they failed and changed, and their runtime.
+//
+// We also send the daily run-counts held in the history table, as
+// `runs.<state>`.
+//
+func backfillPoints(prefix string, since time.Time, until time.Time, resolution time.Duration) ([]metricPoint, error) {
+
+	if resolution <= 0 {
+		return nil, errors.New("the resolution must be positive")
+	}
+
+	var points []metricPoint
+
+	//
+	// The latest report of each node, as we walk forward in time.
+	//
+	// Nodes which last reported long enough before `since` are
+	// orphaned throughout, so we only need to know they exist.
+	//
+	lower := since.Unix() - OrphanSeconds
+	seen, err := getLastSeenBefore(lower)
+	if err != nil {
+		return nil, err
+	}
+	latest := make(map[string]PuppetReportPoint)
+	for fqdn, at := range seen {
+		latest[fqdn] = PuppetReportPoint{Fqdn: fqdn, At: at}
+	}
+
+	//
+	// The reports made during the current period.
+	//
+	end := since.Add(resolution)
+	var activity PuppetActivity
+
+	//
+	// Send the metrics of each period which ends before the given
+	// time, and move on to the next.
+	//
+	flush := func(before int64) {
+		for !end.After(until) && end.Unix() < before {
+			metrics := make(map[string]string)
+
+			//
+			// The state of each node, as it was then.
+			//
+			var nodes []PuppetRuns
+			for fqdn, r := range latest {
+				state := r.State
+				if end.Unix()-r.At > OrphanSeconds {
+					state = "orphaned"
+				}
+				nodes = append(nodes, PuppetRuns{Fqdn: fqdn, State: state})
+			}
+			for _, state := range countStates(nodes) {
+				metrics[fmt.Sprintf("state.%s", state.State)] = fmt.Sprintf("%d", state.Count)
+			}
+
+			total := 0.0
+			for _, r := range activity.Runtimes {
+				total += r
+			}
+			average := 0.0
+			if len(activity.Runtimes) > 0 {
+				average = total / float64(len(activity.Runtimes))
+			}
+			metrics["runtime.average"] = fmt.Sprintf("%.2f", average)
+			metrics["runtime.p95"] = fmt.Sprintf("%.2f", percentile(activity.Runtimes, 95))
+			metrics["resources.failed"] = fmt.Sprintf("%d", activity.Failed)
+			metrics["resources.changed"] = fmt.Sprintf("%d", activity.Changed)
+			metrics["reports.received"] = fmt.Sprintf("%d", activity.Reports)
+
+			points = append(points, metricPoints(metrics, prefix, end)...)
+
+			end = end.Add(resolution)
+			activity = PuppetActivity{}
+		}
+	}
+
+	err = getReportPoints(lower, until.Unix(), func(r PuppetReportPoint) error {
+		flush(r.At)
+		latest[r.Fqdn] = r
+		if r.At > end.Add(-resolution).Unix() {
+			activity.Reports++
+			activity.Failed += r.Failed
+			activity.Changed += r.Changed
+			activity.Runtimes = append(activity.Runtimes, r.Runtime)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	flush(until.Unix() + 1)
+
+	//
+	// Now the daily history.
+	//
+	history, err := getHistory()
+	if err != nil {
+		return nil, err
+	}
+	for _, day := range history {
+		at, err := time.ParseInLocation("2006/01/02", day.Date, time.UTC)
+		if err != nil || at.Before(since) || at.After(until) {
+			continue
+		}
+		metrics := map[string]string{
+			"runs.failed":    day.Failed,
+			"runs.changed":   day.Changed,
+			"runs.unchanged": day.Unchanged,
+		}
+		points = append(points, metricPoints(metrics, prefix, at)...)
+	}
+
+	return points, nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+//
+// Test that historical metrics are rebuilt from the reports.
+//
+func TestBackfillPoints(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	//
+	// Add some runs, and then move them into the past.
+	//
+	addFakeRuns("foo.example.com", "failed")
+	addFakeRuns("bar.example.com", "changed")
+	addFakeRuns("foo.example.com", "unchanged")
+
+	base := time.Unix(1500000000, 0).Truncate(time.Hour)
+	for i, offset := range []time.Duration{10 * time.Minute, 20 * time.Minute, 70 * time.Minute} {
+		_, err := db.Exec("UPDATE reports SET executed_at=? WHERE id=?", base.Add(offset).Unix(), i+1)
+		if err != nil {
+			t.Fatalf("Failed to update report: %s", err.Error())
+		}
+	}
+
+	points, err := backfillPoints("puppet", base, base.Add(2*time.Hour), time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to backfill: %s", err.Error())
+	}
+
+	//
+	// Index the points by time and name.
+	//
+	values := make(map[int64]map[string]string)
+	for _, point := range points {
+		if values[point.At.Unix()] == nil {
+			values[point.At.Unix()] = make(map[string]string)
+		}
+		values[point.At.Unix()][point.Name] = point.Value
+	}
+	if len(values) != 2 {
+		t.Fatalf("Unexpected number of periods: %d", len(values))
+	}
+
+	first := values[base.Add(time.Hour).Unix()]
+	if first["puppet.state.failed"] != "1" || first["puppet.state.changed"] != "1" || first["puppet.reports.received"] != "2" {
+		t.Errorf("Unexpected first period: %v", first)
+	}
+
+	second := values[base.Add(2*time.Hour).Unix()]
+	if second["puppet.state.failed"] != "0" || second["puppet.state.unchanged"] != "1" || second["puppet.reports.received"] != "1" {
+		t.Errorf("Unexpected second period: %v", second)
+	}
+
+	//
+	// Long after the last report the nodes are orphaned.
+	//
+	later := base.Add(10 * 24 * time.Hour)
+	points, err = backfillPoints("puppet", later, later.Add(time.Hour), time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to backfill: %s", err.Error())
+	}
+	for _, point := range points {
+		if point.Name == "puppet.state.orphaned" && point.Value != "2" {
+			t.Errorf("Expected the nodes to be orphaned: %v", point)
+		}
+	}
+
+	_, err = backfillPoints("puppet", base, base.Add(time.Hour), 0)
+	if err == nil {
+		t.Errorf("Expected an error with no resolution")
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
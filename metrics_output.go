@@ -0,0 +1,328 @@
+//
+// The backends which metrics may be submitted to.
+//
+
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+//
+// MetricsOutput is implemented by each of the places we can submit
+// our metrics to.
+//
+type MetricsOutput interface {
+
+	//
+	// Send a single metric, recorded at the given time.
+	//
+	Send(name string, value string, at time.Time) error
+
+	//
+	// Close flushes anything pending, and releases the output.
+	//
+	Close() error
+}
+
+//
+// The default port used by each output, when none is given.
+//
+var outputPorts = map[string]int{
+	"carbon":       2003,
+	"statsd":       8125,
+	"influxdb":     8086,
+	"influxdb-udp": 8089,
+}
+
+//
+// The format each output uses for a single metric, which is also what
+// we show when running with `-nop`.
+//
+// StatsD has no notion of time, so gauges are always "now".
+//
+var outputFormats = map[string]func(name string, value string, at time.Time) string{
+	"carbon": func(name string, value string, at time.Time) string {
+		return fmt.Sprintf("%s %s %d\n", name, value, at.Unix())
+	},
+	"statsd": func(name string, value string, at time.Time) string {
+		return fmt.Sprintf("%s:%s|g\n", name, value)
+	},
+	"influxdb": func(name string, value string, at time.Time) string {
+		return fmt.Sprintf("%s value=%s %d\n", name, value, at.UnixNano())
+	},
+	"influxdb-udp": func(name string, value string, at time.Time) string {
+		return fmt.Sprintf("%s value=%s %d\n", name, value, at.UnixNano())
+	},
+}
+
+//
+// writerOutput writes each metric, in the given format, to a stream.
+//
+// This is used for `-nop`, StatsD, and InfluxDB over UDP.  Over UDP each
+// metric is sent as its own datagram.
+//
+type writerOutput struct {
+	w      io.Writer
+	format func(name string, value string, at time.Time) string
+}
+
+func (o *writerOutput) Send(name string, value string, at time.Time) error {
+	_, err := io.WriteString(o.w, o.format(name, value, at))
+	return err
+}
+
+func (o *writerOutput) Close() error {
+	if c, ok := o.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+//
+// OutputOptions control how metrics are submitted.
+//
+type OutputOptions struct {
+
+	//
+	// The InfluxDB database to write to, when using HTTP.
+	//
+	Database string
+
+	//
+	// Use carbon's pickle protocol, rather than plaintext.
+	//
+	Pickle bool
+
+	//
+	// How many times to retry a failed submission.
+	//
+	Retries int
+
+	//
+	// The timeout for each attempt to submit.
+	//
+	Timeout time.Duration
+}
+
+//
+// RetryDelay is how long we wait before the first retry of a failed
+// submission, subsequent retries wait longer.
+//
+var RetryDelay = time.Second
+
+//
+// Call `send` until it succeeds, or we've run out of retries.
+//
+func withRetries(opts OutputOptions, count int, address string, send func() error) error {
+	var err error
+	for attempt := 0; attempt <= opts.Retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(RetryDelay * time.Duration(attempt))
+		}
+		err = send()
+		if err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("failed to send %d metrics to %s after %d attempt(s): %s", count, address, opts.Retries+1, err.Error())
+}
+
+//
+// carbonOutput collects metrics, and writes them all to a carbon server
+// over a single connection when closed.
+//
+type carbonOutput struct {
+	address string
+	opts    OutputOptions
+	points  []metricPoint
+}
+
+func (o *carbonOutput) Send(name string, value string, at time.Time) error {
+	o.points = append(o.points, metricPoint{Name: name, Value: value, At: at})
+	return nil
+}
+
+func (o *carbonOutput) Close() error {
+	if len(o.points) < 1 {
+		return nil
+	}
+
+	//
+	// Build the whole payload up-front.
+	//
+	var payload bytes.Buffer
+	if o.opts.Pickle {
+		for i := 0; i < len(o.points); i += PickleBatchSize {
+			end := i + PickleBatchSize
+			if end > len(o.points) {
+				end = len(o.points)
+			}
+			frame, err := carbonPickle(o.points[i:end])
+			if err != nil {
+				return err
+			}
+			payload.Write(frame)
+		}
+	} else {
+		for _, point := range o.points {
+			payload.WriteString(outputFormats["carbon"](point.Name, point.Value, point.At))
+		}
+	}
+
+	return withRetries(o.opts, len(o.points), o.address, func() error {
+		conn, err := net.DialTimeout("tcp", o.address, o.opts.Timeout)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		conn.SetWriteDeadline(time.Now().Add(o.opts.Timeout))
+		_, err = conn.Write(payload.Bytes())
+		return err
+	})
+}
+
+//
+// PickleBatchSize is the most points we'll send in a single pickle.
+//
+var PickleBatchSize = 500
+
+//
+// carbonPickle encodes the points for carbon's pickle protocol, which
+// expects a list of (name, (timestamp, value)) tuples, pickled, and
+// preceded by its length.
+//
+func carbonPickle(points []metricPoint) ([]byte, error) {
+	var p bytes.Buffer
+
+	// PROTO 2, EMPTY_LIST, MARK
+	p.Write([]byte{0x80, 0x02, ']', '('})
+
+	for _, point := range points {
+		value, err := strconv.ParseFloat(point.Value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("the value of %s isn't a number: %s", point.Name, point.Value)
+		}
+
+		// BINUNICODE name
+		p.WriteByte('X')
+		binary.Write(&p, binary.LittleEndian, uint32(len(point.Name)))
+		p.WriteString(point.Name)
+
+		// BININT timestamp
+		p.WriteByte('J')
+		binary.Write(&p, binary.LittleEndian, int32(point.At.Unix()))
+
+		// BINFLOAT value
+		p.WriteByte('G')
+		binary.Write(&p, binary.BigEndian, math.Float64bits(value))
+
+		// TUPLE2 (timestamp, value), then TUPLE2 (name, ...)
+		p.Write([]byte{0x86, 0x86})
+	}
+
+	// APPENDS, STOP
+	p.Write([]byte{'e', '.'})
+
+	var frame bytes.Buffer
+	binary.Write(&frame, binary.BigEndian, uint32(p.Len()))
+	frame.Write(p.Bytes())
+	return frame.Bytes(), nil
+}
+
+//
+// influxOutput collects metrics in the InfluxDB line-protocol, and
+// writes them all over HTTP when closed.
+//
+type influxOutput struct {
+	url   string
+	opts  OutputOptions
+	count int
+	buf   bytes.Buffer
+}
+
+func (o *influxOutput) Send(name string, value string, at time.Time) error {
+	o.buf.WriteString(outputFormats["influxdb"](name, value, at))
+	o.count++
+	return nil
+}
+
+func (o *influxOutput) Close() error {
+	if o.count < 1 {
+		return nil
+	}
+
+	client := &http.Client{Timeout: o.opts.Timeout}
+	return withRetries(o.opts, o.count, o.url, func() error {
+		res, err := client.Post(o.url, "text/plain", bytes.NewReader(o.buf.Bytes()))
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+
+		if res.StatusCode < 200 || res.StatusCode > 299 {
+			return fmt.Errorf("InfluxDB returned %s", res.Status)
+		}
+		return nil
+	})
+}
+
+//
+// newMetricsOutput creates the named output, which will submit metrics
+// to the given host and port.  If the port is zero the usual port for
+// that output is used.
+//
+// If `nop` is set the metrics are written to STDOUT instead.
+//
+func newMetricsOutput(kind string, host string, port int, opts OutputOptions, nop bool) (MetricsOutput, error) {
+
+	//
+	// Carbon is what we've always supported.
+	//
+	if kind == "" {
+		kind = "carbon"
+	}
+
+	format, ok := outputFormats[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown output '%s', valid choices are carbon, statsd, influxdb, influxdb-udp", kind)
+	}
+
+	if nop {
+		return &writerOutput{w: out, format: format}, nil
+	}
+
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	if port == 0 {
+		port = outputPorts[kind]
+		if kind == "carbon" && opts.Pickle {
+			port = 2004
+		}
+	}
+	address := net.JoinHostPort(host, strconv.Itoa(port))
+
+	switch kind {
+	case "carbon":
+		return &carbonOutput{address: address, opts: opts}, nil
+	case "influxdb":
+		return &influxOutput{url: fmt.Sprintf("http://%s/write?db=%s", address, url.QueryEscape(opts.Database)), opts: opts}, nil
+	default:
+		conn, err := net.DialTimeout("udp", address, opts.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		return &writerOutput{w: conn, format: format}, nil
+	}
+}
@@ -0,0 +1,291 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+//
+// Send a couple of metrics to the given output.
+//
+func sendTestMetrics(t *testing.T, kind string, port int, database string) {
+	output, err := newMetricsOutput(kind, "127.0.0.1", port, OutputOptions{Database: database}, false)
+	if err != nil {
+		t.Fatalf("Failed to create %s output: %s", kind, err.Error())
+	}
+	at := time.Unix(1500000000, 0)
+	output.Send("puppet.state.failed", "2", at)
+	output.Send("puppet.state.changed", "3", at)
+	err = output.Close()
+	if err != nil {
+		t.Fatalf("Failed to send %s metrics: %s", kind, err.Error())
+	}
+}
+
+//
+// Test an unknown output is rejected.
+//
+func TestUnknownOutput(t *testing.T) {
+	_, err := newMetricsOutput("steve", "127.0.0.1", 0, OutputOptions{}, false)
+	if err == nil {
+		t.Errorf("Expected an error for an unknown output")
+	}
+}
+
+//
+// Test the metric-format of each output, via `-nop`.
+//
+func TestOutputNop(t *testing.T) {
+
+	bak := out
+	defer func() { out = bak }()
+
+	tests := map[string]string{
+		"carbon":       "puppet.state.failed 2 1500000000\n",
+		"statsd":       "puppet.state.failed:2|g\n",
+		"influxdb":     "puppet.state.failed value=2 1500000000000000000\n",
+		"influxdb-udp": "puppet.state.failed value=2 1500000000000000000\n",
+	}
+
+	for kind, expected := range tests {
+		out = new(bytes.Buffer)
+
+		output, err := newMetricsOutput(kind, "", 0, OutputOptions{}, true)
+		if err != nil {
+			t.Fatalf("Failed to create %s output: %s", kind, err.Error())
+		}
+		output.Send("puppet.state.failed", "2", time.Unix(1500000000, 0))
+		output.Close()
+
+		if out.(*bytes.Buffer).String() != expected {
+			t.Errorf("Unexpected %s output: '%s'", kind, out.(*bytes.Buffer).String())
+		}
+	}
+}
+
+//
+// Test submitting to a fake carbon server.
+//
+func TestCarbonOutput(t *testing.T) {
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	lines := make(chan string, 2)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+
+	sendTestMetrics(t, "carbon", ln.Addr().(*net.TCPAddr).Port, "")
+
+	for _, expected := range []string{"puppet.state.failed 2 1500000000", "puppet.state.changed 3 1500000000"} {
+		select {
+		case line := <-lines:
+			if line != expected {
+				t.Errorf("Unexpected carbon line: '%s'", line)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Timed out waiting for carbon metrics")
+		}
+	}
+}
+
+//
+// Test submitting to fake StatsD and InfluxDB UDP listeners.
+//
+func TestUDPOutputs(t *testing.T) {
+
+	tests := map[string]string{
+		"statsd":       "puppet.state.failed:2|g\n",
+		"influxdb-udp": "puppet.state.failed value=2 1500000000000000000\n",
+	}
+
+	for kind, expected := range tests {
+
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sendTestMetrics(t, kind, conn.LocalAddr().(*net.UDPAddr).Port, "")
+
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		buf := make([]byte, 1024)
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			t.Fatalf("Failed to read %s packet: %s", kind, err.Error())
+		}
+		if string(buf[:n]) != expected {
+			t.Errorf("Unexpected %s packet: '%s'", kind, string(buf[:n]))
+		}
+		conn.Close()
+	}
+}
+
+//
+// Test submitting to a fake InfluxDB HTTP server.
+//
+func TestInfluxOutput(t *testing.T) {
+
+	var body string
+	var database string
+
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		content, _ := ioutil.ReadAll(req.Body)
+		body = string(content)
+		database = req.URL.Query().Get("db")
+		res.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	_, port, _ := net.SplitHostPort(strings.TrimPrefix(server.URL, "http://"))
+	p, _ := strconv.Atoi(port)
+
+	sendTestMetrics(t, "influxdb", p, "fleet")
+
+	if database != "fleet" {
+		t.Errorf("Unexpected database: '%s'", database)
+	}
+	if body != "puppet.state.failed value=2 1500000000000000000\npuppet.state.changed value=3 1500000000000000000\n" {
+		t.Errorf("Unexpected body: '%s'", body)
+	}
+}
+
+//
+// Test the encoding of carbon's pickle protocol.
+//
+func TestCarbonPickle(t *testing.T) {
+
+	points := []metricPoint{{Name: "puppet.state.failed", Value: "2", At: time.Unix(1500000000, 0)}}
+
+	frame, err := carbonPickle(points)
+	if err != nil {
+		t.Fatalf("Failed to pickle: %s", err.Error())
+	}
+
+	//
+	// The length-prefix should match the pickle which follows.
+	//
+	if int(binary.BigEndian.Uint32(frame[:4])) != len(frame)-4 {
+		t.Errorf("Incorrect length-prefix")
+	}
+
+	expected := []byte{0x80, 0x02, ']', '(', 'X', 19, 0, 0, 0}
+	expected = append(expected, []byte("puppet.state.failed")...)
+	expected = append(expected, 'J', 0x00, 0x2f, 0x68, 0x59)
+	expected = append(expected, 'G', 0x40, 0, 0, 0, 0, 0, 0, 0)
+	expected = append(expected, 0x86, 0x86, 'e', '.')
+
+	if !bytes.Equal(frame[4:], expected) {
+		t.Errorf("Unexpected pickle: %v", frame[4:])
+	}
+
+	//
+	// Values must be numeric.
+	//
+	points[0].Value = "steve"
+	_, err = carbonPickle(points)
+	if err == nil {
+		t.Errorf("Expected an error for a non-numeric value")
+	}
+}
+
+//
+// Test submitting pickled metrics to a fake carbon server.
+//
+func TestCarbonPickleOutput(t *testing.T) {
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	received := make(chan []byte, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		content, _ := ioutil.ReadAll(conn)
+		received <- content
+	}()
+
+	output, err := newMetricsOutput("carbon", "127.0.0.1", ln.Addr().(*net.TCPAddr).Port, OutputOptions{Pickle: true}, false)
+	if err != nil {
+		t.Fatalf("Failed to create output: %s", err.Error())
+	}
+	output.Send("puppet.state.failed", "2", time.Unix(1500000000, 0))
+	output.Send("puppet.state.changed", "3", time.Unix(1500000000, 0))
+	err = output.Close()
+	if err != nil {
+		t.Fatalf("Failed to send metrics: %s", err.Error())
+	}
+
+	select {
+	case content := <-received:
+		if int(binary.BigEndian.Uint32(content[:4])) != len(content)-4 {
+			t.Errorf("Expected a single pickle, got %d bytes", len(content))
+		}
+		if !bytes.Contains(content, []byte("puppet.state.changed")) {
+			t.Errorf("Missing metric in pickle")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timed out waiting for carbon metrics")
+	}
+}
+
+//
+// Test that failed submissions are retried, and then reported.
+//
+func TestCarbonRetries(t *testing.T) {
+
+	old := RetryDelay
+	RetryDelay = time.Millisecond
+	defer func() { RetryDelay = old }()
+
+	//
+	// Nothing is listening upon this port.
+	//
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+
+	output, err := newMetricsOutput("carbon", "127.0.0.1", port, OutputOptions{Retries: 2, Timeout: time.Second}, false)
+	if err != nil {
+		t.Fatalf("Failed to create output: %s", err.Error())
+	}
+	output.Send("puppet.state.failed", "2", time.Now())
+
+	err = output.Close()
+	if err == nil {
+		t.Fatalf("Expected an error sending to a closed port")
+	}
+	if !strings.Contains(err.Error(), "failed to send 1 metrics") || !strings.Contains(err.Error(), "after 3 attempt(s)") {
+		t.Errorf("Unexpected error: %s", err.Error())
+	}
+}
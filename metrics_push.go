@@ -0,0 +1,158 @@
+//
+// Periodically push metrics from within the server.
+//
+
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+//
+// MetricsBufferSize is the largest number of points we'll keep while
+// waiting to retry a failed push.  When full the oldest are dropped.
+//
+var MetricsBufferSize = 10000
+
+//
+// PushStatus describes the state of our periodic metrics push, and is
+// reported by the health end-point.
+//
+type PushStatus struct {
+	Enabled     bool
+	LastAttempt string
+	LastSuccess string
+	LastError   string
+	Pending     int
+	Dropped     int
+}
+
+//
+// metricsPusher sends our metrics to a metrics-server, keeping any
+// points it failed to send so they may be retried on the next push.
+//
+// Points which StatsD failed to receive aren't kept, as they'd be
+// recorded at the time of the retry.
+//
+type metricsPusher struct {
+	sync.Mutex
+
+	output string
+	host   string
+	port   int
+	opts   OutputOptions
+	prefix string
+	groups []string
+
+	//
+	// Leave flapping nodes out of the state-counts.
+	//
+	suppress bool
+
+	pending []metricPoint
+	sending bool
+	status  PushStatus
+}
+
+//
+// pusher is the active metricsPusher, if metrics are being pushed.
+//
+var pusher *metricsPusher
+
+//
+// Record a failed push.
+//
+func (p *metricsPusher) failed(err error) {
+	p.status.LastError = err.Error()
+	p.status.Pending = len(p.pending)
+}
+
+//
+// push gathers the current metrics, and sends them along with any which
+// failed to send previously.
+//
+// We don't hold our lock while sending, which may take as long as our
+// timeout and retries allow, so that our status may still be reported.
+//
+func (p *metricsPusher) push() {
+	p.Lock()
+	defer p.Unlock()
+
+	//
+	// Skip this push if the previous one is still sending.
+	//
+	if p.sending {
+		return
+	}
+
+	now := time.Now()
+	p.status.LastAttempt = now.Format("2006-01-02 15:04:05")
+
+	metrics, err := getMetrics(p.suppress, p.groups...)
+	if err != nil {
+		p.failed(err)
+		return
+	}
+
+	//
+	// Queue the new points behind those we're still waiting to send,
+	// dropping the oldest if the buffer is full.
+	//
+	p.pending = append(p.pending, metricPoints(metrics, p.prefix, now)...)
+	if len(p.pending) > MetricsBufferSize {
+		p.status.Dropped += len(p.pending) - MetricsBufferSize
+		p.pending = p.pending[len(p.pending)-MetricsBufferSize:]
+	}
+
+	points := make([]metricPoint, len(p.pending))
+	copy(points, p.pending)
+
+	p.sending = true
+	p.Unlock()
+	err = p.send(points)
+	p.Lock()
+	p.sending = false
+
+	if err != nil {
+
+		//
+		// StatsD has no notion of time, so retrying would record
+		// these points as "now" rather than when we gathered them.
+		//
+		if p.output == "statsd" {
+			p.status.Dropped += len(p.pending)
+			p.pending = nil
+		}
+		p.failed(err)
+		return
+	}
+
+	p.pending = p.pending[len(points):]
+	p.status.LastSuccess = p.status.LastAttempt
+	p.status.LastError = ""
+	p.status.Pending = len(p.pending)
+}
+
+//
+// Send the given points to our metrics-server.
+//
+func (p *metricsPusher) send(points []metricPoint) error {
+	output, err := newMetricsOutput(p.output, p.host, p.port, p.opts, false)
+	if err != nil {
+		return err
+	}
+	return sendPoints(output, points)
+}
+
+//
+// Status returns the state of the most recent push.
+//
+func (p *metricsPusher) Status() PushStatus {
+	p.Lock()
+	defer p.Unlock()
+
+	status := p.status
+	status.Enabled = true
+	return status
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+//
+// Test that failed pushes are retried, and their status recorded.
+//
+func TestMetricsPush(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	// Add some hosts.
+	addFakeNodes()
+
+	//
+	// A fake InfluxDB which fails the first request.
+	//
+	requests := 0
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		requests++
+		content, _ := ioutil.ReadAll(req.Body)
+		bodies = append(bodies, string(content))
+		if requests == 1 {
+			res.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		res.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	_, port, _ := net.SplitHostPort(strings.TrimPrefix(server.URL, "http://"))
+	p, _ := strconv.Atoi(port)
+
+	push := &metricsPusher{output: "influxdb", host: "127.0.0.1", port: p, prefix: "puppet"}
+
+	push.push()
+	status := push.Status()
+	if status.LastError == "" || status.LastSuccess != "" {
+		t.Errorf("Expected the first push to fail: %v", status)
+	}
+	if status.Pending != 7 {
+		t.Errorf("Unexpected pending count: %d", status.Pending)
+	}
+
+	//
+	// The second push should include the points from the first.
+	//
+	push.push()
+	status = push.Status()
+	if status.LastError != "" || status.LastSuccess == "" || status.Pending != 0 {
+		t.Errorf("Expected the second push to succeed: %v", status)
+	}
+	if strings.Count(bodies[1], "puppet.state.failed ") != 2 {
+		t.Errorf("Failed points weren't retried: %s", bodies[1])
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test that the retry-buffer is bounded.
+//
+func TestMetricsPushBuffer(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	old := MetricsBufferSize
+	MetricsBufferSize = 8
+	defer func() { MetricsBufferSize = old }()
+
+	//
+	// Nothing is listening upon this port.
+	//
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+
+	push := &metricsPusher{output: "carbon", host: "127.0.0.1", port: p, prefix: "puppet"}
+	push.push()
+	push.push()
+
+	status := push.Status()
+	if status.Pending != 8 || status.Dropped != 6 {
+		t.Errorf("Unexpected buffer status: %v", status)
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test that failed points aren't retried via StatsD, which would record
+// them at the wrong time.
+//
+func TestMetricsPushStatsD(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	//
+	// Nothing is listening upon this port.
+	//
+	ln, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := ln.LocalAddr().(*net.UDPAddr).Port
+	ln.Close()
+
+	push := &metricsPusher{output: "statsd", host: "127.0.0.1", port: p, prefix: "puppet"}
+	push.push()
+
+	status := push.Status()
+	if status.LastError == "" {
+		t.Fatalf("Expected the push to fail: %v", status)
+	}
+	if status.Pending != 0 || status.Dropped != 7 {
+		t.Errorf("Unexpected buffer status: %v", status)
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test our status may be reported while a push is still sending.
+//
+func TestMetricsPushStatus(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	//
+	// A fake InfluxDB which doesn't respond until we're done.
+	//
+	received := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		close(received)
+		<-release
+		res.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	_, port, _ := net.SplitHostPort(strings.TrimPrefix(server.URL, "http://"))
+	p, _ := strconv.Atoi(port)
+
+	push := &metricsPusher{output: "influxdb", host: "127.0.0.1", port: p, prefix: "puppet"}
+
+	done := make(chan struct{})
+	go func() {
+		push.push()
+		close(done)
+	}()
+	<-received
+
+	status := make(chan PushStatus)
+	go func() {
+		status <- push.Status()
+	}()
+
+	select {
+	case s := <-status:
+		if s.LastAttempt == "" || s.LastSuccess != "" {
+			t.Errorf("Unexpected status while sending: %v", s)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("Status blocked while a push was sending")
+	}
+
+	//
+	// A push while the previous one is sending is skipped.
+	//
+	push.push()
+
+	close(release)
+	<-done
+
+	if s := push.Status(); s.LastSuccess == "" || s.Pending != 0 {
+		t.Errorf("Expected the push to succeed: %v", s)
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
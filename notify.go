@@ -0,0 +1,188 @@
+//
+// Notify webhooks when nodes change state.
+//
+// Notifications are queued in the database, so that they survive a
+// restart, and delivered by a regular job which retries failures with
+// an increasing delay.
+//
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+)
+
+//
+// Webhooks are the URLs which notifications are POSTed to.
+//
+var Webhooks []string
+
+//
+// WebhookStates are the states which trigger a notification when a node
+// enters, or leaves, them.
+//
+var WebhookStates = map[string]bool{"failed": true, "late": true, "orphaned": true}
+
+//
+// WebhookTemplate renders the payload of each notification, if it is
+// nil the default template is used.
+//
+var WebhookTemplate *template.Template
+
+//
+// WebhookMaxAttempts is the number of times we'll try to deliver a
+// notification before giving up.
+//
+var WebhookMaxAttempts = 10
+
+//
+// Parse a payload template.
+//
+func parseWebhookTemplate(content string) (*template.Template, error) {
+	funcMap := template.FuncMap{
+		"json": func(s string) string {
+			out, _ := json.Marshal(s)
+			return string(out)
+		},
+	}
+	return template.New("webhook").Funcs(funcMap).Parse(content)
+}
+
+//
+// Render the payload for the given transition.
+//
+func renderWebhook(t PuppetTransition) (string, error) {
+
+	tmpl := WebhookTemplate
+	if tmpl == nil {
+		src, err := getResource("data/webhook.template")
+		if err != nil {
+			return "", err
+		}
+		tmpl, err = parseWebhookTemplate(string(src))
+		if err != nil {
+			return "", err
+		}
+	}
+
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, t)
+	return buf.String(), err
+}
+
+//
+// notifyTransition queues a notification, for each webhook, if the
+// transition is one which we're interested in, and an email if the
+// node has started failing, unless the node is within a maintenance
+// window.
+//
+// Nodes which are flapping aren't notified, or mailed, unless
+// SuppressFlapping is disabled, as they'd be notified upon every run.
+//
+func notifyTransition(t PuppetTransition) {
+
+	//
+	// Nodes within a maintenance window are expected to misbehave.
+	//
+	if inMaintenance(t.Fqdn, t.Role) {
+		return
+	}
+	if SuppressFlapping && isFlapping(t.Fqdn) {
+		return
+	}
+
+	//
+	// New failures are mailed, too.
+	//
+	if len(EmailTo) > 0 && t.To == "failed" && t.From != "failed" {
+		queueFailureEmail(t)
+	}
+
+	if len(Webhooks) < 1 {
+		return
+	}
+	if !WebhookStates[t.From] && !WebhookStates[t.To] {
+		return
+	}
+
+	payload, err := renderWebhook(t)
+	if err != nil {
+		fmt.Printf("Error rendering webhook for %s: %s\n", t.Fqdn, err.Error())
+		return
+	}
+
+	for _, url := range Webhooks {
+		err = addNotification("webhook", url, payload)
+		if err != nil {
+			fmt.Printf("Error queuing webhook for %s: %s\n", t.Fqdn, err.Error())
+		}
+	}
+}
+
+//
+// The delay, in seconds, before the next attempt to deliver a
+// notification which has failed the given number of times.
+//
+func webhookBackoff(attempts int) int64 {
+	delay := int64(30)
+	for i := 1; i < attempts && delay < 60*60; i++ {
+		delay *= 2
+	}
+	if delay > 60*60 {
+		delay = 60 * 60
+	}
+	return delay
+}
+
+//
+// POST a single payload.
+//
+func postWebhook(url string, payload string) error {
+	client := &http.Client{Timeout: 10 * time.Second}
+	res, err := client.Post(url, "application/json", strings.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("%s returned %s", url, res.Status)
+	}
+	return nil
+}
+
+//
+// deliverNotifications attempts to deliver each queued notification
+// which is due.
+//
+func deliverNotifications() {
+
+	now := time.Now().Unix()
+
+	queued, err := getNotifications("webhook", now, 100)
+	if err != nil {
+		return
+	}
+
+	for _, n := range queued {
+		err = postWebhook(n.Target, n.Payload)
+		if err == nil {
+			deleteNotification(n.ID)
+			continue
+		}
+
+		attempts := n.Attempts + 1
+		if attempts >= WebhookMaxAttempts {
+			fmt.Printf("Giving up on webhook to %s after %d attempts: %s\n", n.Target, attempts, err.Error())
+			deleteNotification(n.ID)
+			continue
+		}
+		retryNotification(n.ID, attempts, now+webhookBackoff(attempts), err.Error())
+	}
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+//
+// Test the default payload is valid JSON.
+//
+func TestRenderWebhook(t *testing.T) {
+
+	payload, err := renderWebhook(newTransition("foo.example.com", "web\"", "unchanged", "failed", 1500000000))
+	if err != nil {
+		t.Fatalf("Failed to render webhook: %s", err.Error())
+	}
+
+	var out map[string]interface{}
+	err = json.Unmarshal([]byte(payload), &out)
+	if err != nil {
+		t.Fatalf("Invalid JSON '%s': %s", payload, err.Error())
+	}
+	if out["fqdn"] != "foo.example.com" || out["role"] != "web\"" || out["to"] != "failed" || out["epoch"] != float64(1500000000) {
+		t.Errorf("Unexpected payload: %v", out)
+	}
+
+	//
+	// Custom templates may be used instead.
+	//
+	WebhookTemplate, err = parseWebhookTemplate(`{"text": "{{.Fqdn}} is now {{.To}}"}`)
+	if err != nil {
+		t.Fatalf("Failed to parse template: %s", err.Error())
+	}
+	defer func() { WebhookTemplate = nil }()
+
+	payload, _ = renderWebhook(newTransition("foo.example.com", "", "unchanged", "failed", 1500000000))
+	if payload != `{"text": "foo.example.com is now failed"}` {
+		t.Errorf("Unexpected payload: %s", payload)
+	}
+}
+
+//
+// Test the delay between attempts grows, up to a limit.
+//
+func TestWebhookBackoff(t *testing.T) {
+
+	tests := map[int]int64{1: 30, 2: 60, 3: 120, 8: 3600, 20: 3600}
+	for attempts, expected := range tests {
+		if webhookBackoff(attempts) != expected {
+			t.Errorf("Unexpected backoff after %d attempts: %d", attempts, webhookBackoff(attempts))
+		}
+	}
+}
+
+//
+// Test that transitions are queued, delivered, and retried.
+//
+func TestWebhookDelivery(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	failing := false
+	var payloads []string
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		content, _ := ioutil.ReadAll(req.Body)
+		payloads = append(payloads, string(content))
+		if failing {
+			res.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	Webhooks = []string{server.URL}
+	defer func() { Webhooks = nil }()
+
+	//
+	// Only the move into "failed" is interesting.  The node has been
+	// working for long enough that its recovery isn't flapping.
+	//
+	addFakeRuns("foo.example.com", "unchanged", "unchanged", "unchanged", "unchanged", "changed", "failed")
+
+	deliverNotifications()
+	if len(payloads) != 1 || !strings.Contains(payloads[0], "\"to\": \"failed\"") {
+		t.Fatalf("Unexpected payloads: %v", payloads)
+	}
+
+	queued, _ := getNotifications("webhook", time.Now().Unix()+24*60*60, 100)
+	if len(queued) != 0 {
+		t.Errorf("Delivered notifications should be removed")
+	}
+
+	//
+	// Failures are kept, to be retried later.
+	//
+	failing = true
+	addFakeRuns("foo.example.com", "unchanged")
+
+	deliverNotifications()
+	queued, _ = getNotifications("webhook", time.Now().Unix()+24*60*60, 100)
+	if len(queued) != 1 || queued[0].Attempts != 1 || !strings.Contains(queued[0].LastError, "502") {
+		t.Fatalf("Unexpected queue: %v", queued)
+	}
+
+	//
+	// Not yet due, so nothing more is sent.
+	//
+	deliverNotifications()
+	if len(payloads) != 2 {
+		t.Errorf("Notification retried too soon")
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test that webhooks aren't notified of nodes which are flapping.
+//
+func TestWebhookFlapping(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	Webhooks = []string{"http://localhost/"}
+	defer func() { Webhooks = nil }()
+
+	//
+	// The first move into "failed" is notified, after which the node
+	// is flapping.
+	//
+	addFakeRuns("flaky.example.com", "unchanged", "failed", "unchanged", "failed", "unchanged", "failed")
+
+	queued, _ := getNotifications("webhook", time.Now().Unix(), 100)
+	if len(queued) != 1 {
+		t.Errorf("Unexpected queue: %v", queued)
+	}
+
+	//
+	// Unless we've been told to notify about them anyway.
+	//
+	SuppressFlapping = false
+	defer func() { SuppressFlapping = true }()
+
+	addFakeRuns("flaky.example.com", "unchanged")
+
+	queued, _ = getNotifications("webhook", time.Now().Unix(), 100)
+	if len(queued) != 2 {
+		t.Errorf("Unexpected queue: %v", queued)
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test that nodes becoming orphaned are recorded, and notified.
+//
+func TestOrphanNotification(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	Webhooks = []string{"http://localhost/"}
+	defer func() { Webhooks = nil }()
+
+	addFakeRuns("foo.example.com", "unchanged")
+
+	_, err := db.Exec("UPDATE hosts SET last_seen=300")
+	if err != nil {
+		t.Fatalf("Failed to update host: %s", err.Error())
+	}
+	updateOrphans()
+
+	transitions, err := getTransitions("foo.example.com")
+	if err != nil {
+		t.Fatalf("Failed to get transitions: %s", err.Error())
+	}
+	found := false
+	for _, tr := range transitions {
+		if tr.From == "unchanged" && tr.To == "orphaned" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Orphaning wasn't recorded: %v", transitions)
+	}
+
+	queued, _ := getNotifications("webhook", time.Now().Unix(), 100)
+	if len(queued) != 1 || !strings.Contains(queued[0].Payload, "orphaned") {
+		t.Errorf("Unexpected queue: %v", queued)
+	}
+
+	//
+	// Running again changes nothing.
+	//
+	updateOrphans()
+	queued, _ = getNotifications("webhook", time.Now().Unix(), 100)
+	if len(queued) != 1 {
+		t.Errorf("Unexpected queue: %v", queued)
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
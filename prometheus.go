@@ -0,0 +1,184 @@
+//
+// Export the state of our fleet in the Prometheus text-format, so that
+// it may be scraped via `GET /metrics`.
+//
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+//
+// Counters which are updated as reports are submitted.
+//
+var (
+	reportsReceived     uint64
+	reportsDuplicate    uint64
+	reportParseFailures uint64
+)
+
+//
+// MetricsMaxSeries is the largest number of series we'll export for any
+// single labelled metric, to protect Prometheus from a huge fleet.
+//
+var MetricsMaxSeries = 1000
+
+//
+// RuntimeBuckets are the upper-bounds, in seconds, of the buckets in our
+// runtime histogram.
+//
+var RuntimeBuckets = []float64{5, 10, 30, 60, 120, 300, 600}
+
+//
+// Escape a value for use as a label.
+//
+func promLabel(value string) string {
+	value = strings.Replace(value, "\\", "\\\\", -1)
+	value = strings.Replace(value, "\"", "\\\"", -1)
+	value = strings.Replace(value, "\n", "\\n", -1)
+	return value
+}
+
+//
+// Format a number the way Prometheus expects.
+//
+func promValue(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+//
+// Write the HELP and TYPE lines which precede a metric.
+//
+func promHeader(out io.Writer, name string, kind string, help string) {
+	fmt.Fprintf(out, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(out, "# TYPE %s %s\n", name, kind)
+}
+
+//
+// Write the state-counts of the nodes, grouped by the given label.
+//
+// If there are more groups than we allow the whole metric is skipped,
+// and the number of series we dropped is returned instead.
+//
+func promStates(out io.Writer, name string, label string, help string, nodes []PuppetRuns, group func(PuppetRuns) string) int {
+
+	groups := make(map[string][]PuppetRuns)
+	for _, node := range nodes {
+		groups[group(node)] = append(groups[group(node)], node)
+	}
+
+	var keys []string
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	series := 0
+	for _, key := range keys {
+		series += len(countStates(groups[key]))
+	}
+	if series > MetricsMaxSeries {
+		return series
+	}
+
+	promHeader(out, name, "gauge", help)
+	for _, key := range keys {
+		for _, state := range countStates(groups[key]) {
+			fmt.Fprintf(out, "%s{%s=\"%s\",state=\"%s\"} %d\n", name, label, promLabel(key), promLabel(state.State), state.Count)
+		}
+	}
+	return 0
+}
+
+//
+// Write the metrics describing the given nodes, and our own counters.
+//
+func writePrometheus(out io.Writer, nodes []PuppetRuns) {
+
+	//
+	// The number of series we refused to export, by metric.
+	//
+	dropped := make(map[string]int)
+
+	//
+	// The number of nodes in each state.
+	//
+	promHeader(out, "puppet_nodes", "gauge", "The number of nodes in each state.")
+	for _, state := range countStates(nodes) {
+		fmt.Fprintf(out, "puppet_nodes{state=\"%s\"} %d\n", promLabel(state.State), state.Count)
+	}
+
+	//
+	// The same, broken down by role and environment.
+	//
+	dropped["puppet_nodes_by_role"] = promStates(out, "puppet_nodes_by_role", "role", "The number of nodes in each state, by role.", nodes,
+		func(node PuppetRuns) string { return node.Role })
+	dropped["puppet_nodes_by_environment"] = promStates(out, "puppet_nodes_by_environment", "environment", "The number of nodes in each state, by environment.", nodes,
+		func(node PuppetRuns) string { return node.Environment })
+
+	//
+	// A histogram of the runtime of the latest run of each node.
+	//
+	counts := make([]int, len(RuntimeBuckets))
+	total := 0.0
+	for _, node := range nodes {
+		runtime, _ := strconv.ParseFloat(node.Runtime, 64)
+		total += runtime
+		for i, bound := range RuntimeBuckets {
+			if runtime <= bound {
+				counts[i]++
+			}
+		}
+	}
+	promHeader(out, "puppet_runtime_seconds", "histogram", "The runtime of the latest puppet-run of each node.")
+	for i, bound := range RuntimeBuckets {
+		fmt.Fprintf(out, "puppet_runtime_seconds_bucket{le=\"%s\"} %d\n", promValue(bound), counts[i])
+	}
+	fmt.Fprintf(out, "puppet_runtime_seconds_bucket{le=\"+Inf\"} %d\n", len(nodes))
+	fmt.Fprintf(out, "puppet_runtime_seconds_sum %s\n", promValue(total))
+	fmt.Fprintf(out, "puppet_runtime_seconds_count %d\n", len(nodes))
+
+	//
+	// The time since each node last reported.
+	//
+	if len(nodes) > MetricsMaxSeries {
+		dropped["puppet_node_last_report_age_seconds"] = len(nodes)
+	} else {
+		now := time.Now().Unix()
+		promHeader(out, "puppet_node_last_report_age_seconds", "gauge", "The time since each node last submitted a report.")
+		for _, node := range nodes {
+			seen, _ := strconv.ParseInt(node.Epoch, 10, 64)
+			fmt.Fprintf(out, "puppet_node_last_report_age_seconds{fqdn=\"%s\"} %d\n", promLabel(node.Fqdn), now-seen)
+		}
+	}
+
+	//
+	// Our own counters.
+	//
+	promHeader(out, "puppet_reports_received_total", "counter", "The number of reports received and stored.")
+	fmt.Fprintf(out, "puppet_reports_received_total %d\n", atomic.LoadUint64(&reportsReceived))
+	promHeader(out, "puppet_reports_duplicate_total", "counter", "The number of duplicate reports which were ignored.")
+	fmt.Fprintf(out, "puppet_reports_duplicate_total %d\n", atomic.LoadUint64(&reportsDuplicate))
+	promHeader(out, "puppet_report_parse_failures_total", "counter", "The number of reports which could not be parsed.")
+	fmt.Fprintf(out, "puppet_report_parse_failures_total %d\n", atomic.LoadUint64(&reportParseFailures))
+
+	//
+	// Finally report anything the cardinality-guard skipped.
+	//
+	promHeader(out, "puppet_metrics_series_dropped", "gauge", "The number of series skipped because they exceeded the limit.")
+	var names []string
+	for name := range dropped {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(out, "puppet_metrics_series_dropped{metric=\"%s\"} %d\n", name, dropped[name])
+	}
+}
@@ -0,0 +1,81 @@
+//
+// Test our Prometheus exposition.
+//
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+//
+// Test the metrics written for a handful of nodes.
+//
+func TestWritePrometheus(t *testing.T) {
+
+	nodes := []PuppetRuns{
+		{Fqdn: "foo.example.com", State: "failed", Role: "web", Environment: "production", Runtime: "3", Epoch: "0"},
+		{Fqdn: "bar.example.com", State: "changed", Role: "web", Environment: "production", Runtime: "45", Epoch: "0"},
+		{Fqdn: "baz.example.com", State: "changed", Role: "db\"", Environment: "staging", Runtime: "700", Epoch: "0"},
+	}
+
+	var out bytes.Buffer
+	writePrometheus(&out, nodes)
+	text := out.String()
+
+	expected := []string{
+		"# TYPE puppet_nodes gauge",
+		"puppet_nodes{state=\"changed\"} 2",
+		"puppet_nodes{state=\"failed\"} 1",
+		"puppet_nodes_by_role{role=\"web\",state=\"failed\"} 1",
+		"puppet_nodes_by_role{role=\"db\\\"\",state=\"changed\"} 1",
+		"puppet_nodes_by_environment{environment=\"staging\",state=\"changed\"} 1",
+		"puppet_runtime_seconds_bucket{le=\"5\"} 1",
+		"puppet_runtime_seconds_bucket{le=\"60\"} 2",
+		"puppet_runtime_seconds_bucket{le=\"+Inf\"} 3",
+		"puppet_runtime_seconds_sum 748",
+		"puppet_node_last_report_age_seconds{fqdn=\"foo.example.com\"}",
+		"puppet_reports_received_total",
+		"puppet_report_parse_failures_total",
+	}
+	for _, line := range expected {
+		if !strings.Contains(text, line) {
+			t.Errorf("Missing '%s' in output:\n%s", line, text)
+		}
+	}
+}
+
+//
+// Test that labelled metrics are dropped once there are too many series.
+//
+func TestPrometheusCardinality(t *testing.T) {
+
+	old := MetricsMaxSeries
+	MetricsMaxSeries = 2
+	defer func() { MetricsMaxSeries = old }()
+
+	nodes := []PuppetRuns{
+		{Fqdn: "foo.example.com", State: "failed", Role: "web", Runtime: "3", Epoch: "0"},
+		{Fqdn: "bar.example.com", State: "changed", Role: "db", Runtime: "4", Epoch: "0"},
+		{Fqdn: "baz.example.com", State: "changed", Role: "db", Runtime: "5", Epoch: "0"},
+	}
+
+	var out bytes.Buffer
+	writePrometheus(&out, nodes)
+	text := out.String()
+
+	if strings.Contains(text, "puppet_nodes_by_role{") {
+		t.Errorf("Per-role metrics should have been dropped")
+	}
+	if strings.Contains(text, "puppet_node_last_report_age_seconds{") {
+		t.Errorf("Per-node metrics should have been dropped")
+	}
+	if !strings.Contains(text, "puppet_metrics_series_dropped{metric=\"puppet_node_last_report_age_seconds\"} 3") {
+		t.Errorf("Dropped series not reported:\n%s", text)
+	}
+	if !strings.Contains(text, "puppet_nodes{state=\"changed\"} 2") {
+		t.Errorf("Unlabelled totals should always be present")
+	}
+}
@@ -0,0 +1,637 @@
+//
+// Rebuild the history, and the state of our hosts, from stored reports.
+//
+// The reports are read in batches, oldest first, and the tables derived
+// from them are rewritten a transaction at a time, so a rebuild can run
+// over millions of reports.
+//
+// Buckets of history which began before our oldest report are left alone,
+// as some of the reports counted within them may have been pruned.  The
+// state-transitions of each host are recomputed, except for those into,
+// or out of, the late and orphaned states, which reports can't tell us
+// about and so are kept.
+//
+
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+//
+// RebuildBatch is the number of reports processed within each transaction
+// of a rebuild, or an import, unless another is given.
+//
+var RebuildBatch = 1000
+
+//
+// The state of a host, as we replay its reports.
+//
+type replayedHost struct {
+	State       string
+	Runtime     string
+	Role        string
+	Branch      string
+	Environment string
+	BuildTime   int64
+	LastSeen    int64
+
+	// The times of its most recent reports, newest first.
+	Times []int64
+
+	// The states of its most recent reports, newest first.
+	States []string
+}
+
+//
+// Record a report of the host, keeping only as many recent reports as
+// we need to work out its run-interval and flapping-score.
+//
+func (h *replayedHost) add(state string, at int64) {
+	h.Times = append([]int64{at}, h.Times...)
+	if len(h.Times) > 21 {
+		h.Times = h.Times[:21]
+	}
+	h.States = append([]string{state}, h.States...)
+	if len(h.States) > FlapWindow {
+		h.States = h.States[:FlapWindow]
+	}
+}
+
+//
+// The fingerprint of a report, along with the time it was executed.
+//
+type reportFingerprint struct {
+	At          int64
+	Fingerprint string
+}
+
+//
+// A stored report, as read by a rebuild.
+//
+type storedReport struct {
+	ID          int64
+	Fqdn        string
+	State       string
+	At          int64
+	Runtime     sql.NullString
+	Role        sql.NullString
+	Branch      sql.NullString
+	Environment sql.NullString
+	BuildTime   sql.NullInt64
+}
+
+//
+// Read the next batch of reports, in the order they were executed,
+// following the report with the given time and id.
+//
+func getRebuildReports(at int64, id int64, limit int) ([]storedReport, error) {
+
+	rows, err := db.Query("SELECT id, fqdn, COALESCE(state, ''), executed_at, runtime, role, branch, environment, build_time FROM reports WHERE executed_at > ? OR ( executed_at = ? AND id > ? ) ORDER BY executed_at, id LIMIT ?", at, at, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []storedReport
+	for rows.Next() {
+		var r storedReport
+		err = rows.Scan(&r.ID, &r.Fqdn, &r.State, &r.At, &r.Runtime, &r.Role, &r.Branch, &r.Environment, &r.BuildTime)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, r)
+	}
+	return res, rows.Err()
+}
+
+//
+// Rebuild the history, rollups, state-transitions, and hosts from the
+// reports table.
+//
+// The `fingerprints` of any reports we've parsed are given, and used for
+// the nodes whose latest report they are, otherwise those we have are
+// kept.
+//
+// The number of reports processed is returned.
+//
+func rebuildFromReports(fingerprints map[string]reportFingerprint, batch int, verbose bool) (int, error) {
+
+	//
+	// Ensure we have a DB-handle
+	//
+	if db == nil {
+		return 0, errors.New("SetupDB not called")
+	}
+
+	var oldest sql.NullInt64
+	row := db.QueryRow("SELECT MIN(executed_at) FROM reports")
+	err := row.Scan(&oldest)
+	if err != nil {
+		return 0, err
+	}
+	if !oldest.Valid {
+		return 0, nil
+	}
+
+	//
+	// We rebuild the buckets which began after our oldest report.
+	//
+	after := oldest.Int64
+	for _, r := range HistoryResolutions {
+		_, err = db.Exec("DELETE FROM rollups WHERE resolution = ? AND bucket >= ?", r.Seconds, after)
+		if err != nil {
+			return 0, err
+		}
+		_, err = db.Exec("DELETE FROM rollup_nodes WHERE resolution = ? AND bucket >= ?", r.Seconds, after)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	_, err = db.Exec("DELETE FROM state_transitions WHERE changed_at >= ? AND from_state NOT IN ('late', 'orphaned') AND to_state NOT IN ('late', 'orphaned')", after)
+	if err != nil {
+		return 0, err
+	}
+
+	day, _ := historyResolution("day")
+	first := day.bucket(after)
+	if first < after {
+		first += day.Seconds
+	}
+
+	hosts := make(map[string]*replayedHost)
+	history := make(map[string][3]int)
+
+	count := 0
+	last := storedReport{At: oldest.Int64 - 1}
+
+	for {
+		reports, err := getRebuildReports(last.At, last.ID, batch)
+		if err != nil {
+			return count, err
+		}
+		if len(reports) < 1 {
+			break
+		}
+
+		tx, err := db.Begin()
+		if err != nil {
+			return count, err
+		}
+
+		for _, r := range reports {
+
+			err = rollupReport(tx, r.Fqdn, r.Role.String, r.Environment.String, r.Branch.String, r.State, r.At, after)
+			if err != nil {
+				tx.Rollback()
+				return count, err
+			}
+
+			if day.bucket(r.At) >= after {
+				date := day.label(day.bucket(r.At))
+				counts := history[date]
+				switch r.State {
+				case "failed":
+					counts[0]++
+				case "changed":
+					counts[1]++
+				case "unchanged":
+					counts[2]++
+				}
+				history[date] = counts
+			}
+
+			h, ok := hosts[r.Fqdn]
+			if !ok {
+				h = &replayedHost{}
+				hosts[r.Fqdn] = h
+			}
+
+			err = replayTransition(tx, r, h, ok)
+			if err != nil {
+				tx.Rollback()
+				return count, err
+			}
+
+			h.State = r.State
+			h.Runtime = r.Runtime.String
+			h.Role = r.Role.String
+			h.Branch = r.Branch.String
+			h.Environment = r.Environment.String
+			h.BuildTime = r.BuildTime.Int64
+			h.LastSeen = r.At
+			h.add(r.State, r.At)
+		}
+
+		err = tx.Commit()
+		if err != nil {
+			return count, err
+		}
+
+		count += len(reports)
+		last = reports[len(reports)-1]
+
+		if verbose {
+			fmt.Printf("Processed %d reports\n", count)
+		}
+	}
+
+	err = rebuildHistory(history, day.label(first))
+	if err != nil {
+		return count, err
+	}
+
+	err = rebuildHosts(hosts, fingerprints, batch)
+	if err != nil {
+		return count, err
+	}
+
+	pruneHistory()
+	err = pruneRollups()
+	return count, err
+}
+
+//
+// Record the state-transition made by a report, if its state differs
+// from that of the host, given the host's previous report if `seen`.
+//
+// If the host became late, or orphaned, since its previous report then
+// the transitions we kept already describe the change.
+//
+func replayTransition(tx *sql.Tx, r storedReport, h *replayedHost, seen bool) error {
+
+	previous := h.State
+	if seen {
+		var count int
+		row := tx.QueryRow("SELECT COUNT(*) FROM state_transitions WHERE fqdn = ? AND changed_at > ? AND changed_at <= ? AND ( from_state IN ('late', 'orphaned') OR to_state IN ('late', 'orphaned') )", r.Fqdn, h.LastSeen, r.At)
+		err := row.Scan(&count)
+		if err != nil || count > 0 {
+			return err
+		}
+	} else {
+
+		//
+		// The first report we hold follows whatever transition
+		// we recorded before it, if any.
+		//
+		row := tx.QueryRow("SELECT to_state FROM state_transitions WHERE fqdn = ? AND changed_at < ? ORDER BY changed_at DESC, id DESC LIMIT 1", r.Fqdn, r.At)
+		err := row.Scan(&previous)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+		if previous == "late" || previous == "orphaned" {
+			return nil
+		}
+	}
+
+	if previous == r.State {
+		return nil
+	}
+
+	host_id, err := txHostId(tx, r.Fqdn)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("INSERT INTO state_transitions(host_id, fqdn, role, from_state, to_state, changed_at) VALUES(?,?,?,?,?,?)", host_id, r.Fqdn, r.Role.String, previous, r.State, r.At)
+	return err
+}
+
+//
+// Replace the daily history, from the given date onwards.
+//
+func rebuildHistory(history map[string][3]int, from string) error {
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("DELETE FROM history WHERE date >= ?", from)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for date, day := range history {
+		_, err = tx.Exec("INSERT INTO history(date, failed, changed, unchanged) VALUES(?,?,?,?)", date, day[0], day[1], day[2])
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+//
+// Update each host from the latest of its reports, a batch at a time.
+//
+// A host which is late, or orphaned, is left so unless it has reported
+// since we marked it.
+//
+func rebuildHosts(hosts map[string]*replayedHost, fingerprints map[string]reportFingerprint, size int) error {
+
+	var names []string
+	for fqdn := range hosts {
+		names = append(names, fqdn)
+	}
+
+	for len(names) > 0 {
+		batch := names
+		if len(batch) > size {
+			batch = batch[:size]
+		}
+		names = names[len(batch):]
+
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+
+		for _, fqdn := range batch {
+			err = rebuildHost(tx, fqdn, hosts[fqdn], fingerprints)
+			if err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+
+		err = tx.Commit()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//
+// Update a single host, creating it if it is missing.
+//
+func rebuildHost(tx *sql.Tx, fqdn string, h *replayedHost, fingerprints map[string]reportFingerprint) error {
+
+	var state string
+	var last_seen int64
+	var fingerprint sql.NullString
+
+	host_id, err := txHostId(tx, fqdn)
+	if err != nil {
+		return err
+	}
+
+	row := tx.QueryRow("SELECT state, last_seen, fingerprint FROM hosts WHERE host_id = ?", host_id)
+	err = row.Scan(&state, &last_seen, &fingerprint)
+	if err != nil {
+		return err
+	}
+
+	if (state != "late" && state != "orphaned") || last_seen != h.LastSeen {
+		state = h.State
+	}
+
+	if f, ok := fingerprints[fqdn]; ok && f.At == h.LastSeen {
+		fingerprint.String = f.Fingerprint
+	}
+	if h.State != "failed" {
+		fingerprint.String = ""
+	}
+
+	var reports []PuppetReportSummary
+	for _, s := range h.States {
+		reports = append(reports, PuppetReportSummary{State: s})
+	}
+
+	_, err = tx.Exec("UPDATE hosts SET last_seen = ?, state = ?, runtime = ?, role = ?, branch = ?, build_time = ?, environment = ?, fingerprint = ?, run_interval = ?, flap_score = ? WHERE host_id = ?",
+		h.LastSeen,
+		state,
+		h.Runtime,
+		h.Role,
+		h.Branch,
+		h.BuildTime,
+		h.Environment,
+		fingerprint.String,
+		medianInterval(h.Times),
+		flapScore(reports, FlapWindow),
+		host_id)
+	return err
+}
+
+//
+// Get the id of a host within the given transaction, creating the host
+// if it is missing.
+//
+func txHostId(tx *sql.Tx, fqdn string) (int, error) {
+
+	var host_id int
+	row := tx.QueryRow("SELECT host_id FROM hosts WHERE fqdn = ?", fqdn)
+	err := row.Scan(&host_id)
+	if err != sql.ErrNoRows {
+		return host_id, err
+	}
+
+	_, err = tx.Exec("INSERT INTO hosts(fqdn, state, last_seen, runtime, pinned, role, branch, build_time) VALUES (?, '', 0, 0, 0, '', '', 0)", fqdn)
+	if err != nil {
+		return 0, err
+	}
+
+	row = tx.QueryRow("SELECT host_id FROM hosts WHERE fqdn = ?", fqdn)
+	err = row.Scan(&host_id)
+	return host_id, err
+}
+
+//
+// The time a report was executed, as reported by the node, or the given
+// time if that can't be parsed.
+//
+// Times without an offset from UTC are taken to be local.
+//
+func executedAt(report PuppetReport, fallback int64) int64 {
+	t, err := time.ParseInLocation("2006-01-02 15:04:05", strings.TrimSpace(report.At), time.Local)
+	if report.Offset != "" {
+		t, err = time.Parse("2006-01-02 15:04:05 -07:00", strings.TrimSpace(report.At)+" "+report.Offset)
+	}
+	if err != nil {
+		return fallback
+	}
+	return t.Unix()
+}
+
+//
+// Record the fingerprint of a report, if it is the latest of its node
+// we've seen.
+//
+func addFingerprint(fingerprints map[string]reportFingerprint, report PuppetReport, at int64) {
+	if f, ok := fingerprints[report.Fqdn]; ok && f.At > at {
+		return
+	}
+
+	f := reportFingerprint{At: at}
+	if report.State == "failed" {
+		f.Fingerprint = failureFingerprint(report.ErrorMessages)
+	}
+	fingerprints[report.Fqdn] = f
+}
+
+//
+// Re-parse the YAML reports beneath the given prefix, updating the
+// reports table from them, a batch at a time.
+//
+// Reports we already hold are updated in place, keeping the time we
+// received them, and those we don't are added with the time they were
+// executed.  The fingerprints of the reports are returned, along with
+// the number of files which couldn't be parsed.
+//
+func rebuildReports(prefix string, size int, verbose bool) (map[string]reportFingerprint, int, error) {
+
+	//
+	// Ensure we have a DB-handle
+	//
+	if db == nil {
+		return nil, 0, errors.New("SetupDB not called")
+	}
+
+	type parsed struct {
+		report PuppetReport
+		path   string
+		at     int64
+	}
+
+	fingerprints := make(map[string]reportFingerprint)
+	failures := 0
+	count := 0
+
+	var batch []parsed
+
+	flush := func() error {
+		if len(batch) < 1 {
+			return nil
+		}
+
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+
+		for _, p := range batch {
+			at, err := rebuildReport(tx, p.report, p.path, p.at)
+			if err != nil {
+				tx.Rollback()
+				return err
+			}
+
+			addFingerprint(fingerprints, p.report, at)
+		}
+
+		err = tx.Commit()
+		if err != nil {
+			return err
+		}
+
+		count += len(batch)
+		batch = nil
+
+		if verbose {
+			fmt.Printf("Parsed %d reports\n", count)
+		}
+		return nil
+	}
+
+	err := filepath.Walk(prefix, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		report, err := ParsePuppetReport(content)
+		if err != nil {
+			if verbose {
+				fmt.Printf("Skipping %s: %s\n", path, err.Error())
+			}
+			failures++
+			return nil
+		}
+
+		relative, err := filepath.Rel(prefix, path)
+		if err != nil {
+			return err
+		}
+
+		batch = append(batch, parsed{report: report, path: relative, at: executedAt(report, info.ModTime().Unix())})
+		if len(batch) >= size {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, failures, err
+	}
+
+	return fingerprints, failures, flush()
+}
+
+//
+// Update a report we hold from its YAML, or add it if we don't hold it.
+//
+// The time the report was received is returned.
+//
+func rebuildReport(tx *sql.Tx, data PuppetReport, path string, at int64) (int64, error) {
+
+	var id int64
+	row := tx.QueryRow("SELECT id, executed_at FROM reports WHERE yaml_file = ?", path)
+	err := row.Scan(&id, &at)
+
+	if err == sql.ErrNoRows {
+		host_id, err := txHostId(tx, data.Fqdn)
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = insertReport(tx, data, host_id, path, at)
+		return at, err
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec("UPDATE reports SET fqdn = ?, state = ?, runtime = ?, failed = ?, changed = ?, total = ?, skipped = ?, role = ?, branch = ?, build_time = ?, environment = ? WHERE id = ?",
+		data.Fqdn,
+		data.State,
+		data.Runtime,
+		data.Failed,
+		data.Changed,
+		data.Total,
+		data.Skipped,
+		data.Role,
+		data.Branch,
+		data.BuildTime,
+		data.Environment,
+		id)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec("DELETE FROM changed_resources WHERE report_id = ?", id)
+	if err != nil {
+		return 0, err
+	}
+	_, err = tx.Exec("DELETE FROM resource_times WHERE report_id = ?", id)
+	if err != nil {
+		return 0, err
+	}
+
+	return at, insertResources(tx, id, data, at)
+}
@@ -0,0 +1,230 @@
+//
+// Ingest the reports written into a spool-directory, such as that of
+// the `store` processor of a puppet-master which can't reach us.
+//
+// Each report is claimed by moving it beneath `processing/`, so that it
+// is never read twice, then recorded just as if it had been uploaded.
+// Afterwards it is moved beneath `processed/`, or `failed/`.
+//
+// If we're interrupted the reports left beneath `processing/` are
+// recorded again when we next start, which is safe as reports we've
+// already recorded are ignored.
+//
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+//
+// SpoolSettle is how long a report must have been left alone before a
+// scan of a spool-directory will ingest it, so that we don't read one
+// which is still being written.
+//
+var SpoolSettle = 2 * time.Second
+
+//
+// The directories we move reports into, beneath a spool-directory.
+//
+var spoolDirs = []string{"processing", "processed", "failed"}
+
+//
+// spool is a directory of reports to be ingested.
+//
+type spool struct {
+	path    string
+	pattern string
+}
+
+//
+// Is the given path, relative to the spool, one of our own directories?
+//
+func (s *spool) ours(rel string) bool {
+	top := strings.Split(filepath.ToSlash(rel), "/")[0]
+	for _, dir := range spoolDirs {
+		if top == dir {
+			return true
+		}
+	}
+	return false
+}
+
+//
+// Move a report from one place to another, beneath the spool.
+//
+func (s *spool) move(from string, to string) error {
+	err := os.MkdirAll(filepath.Dir(to), 0755)
+	if err != nil {
+		return err
+	}
+	return os.Rename(from, to)
+}
+
+//
+// Ingest the report at the given path, relative to the spool.
+//
+// Reports which don't match our pattern, or which have already been
+// claimed, are ignored.
+//
+func (s *spool) ingest(rel string) {
+	if s.ours(rel) {
+		return
+	}
+	if match, _ := filepath.Match(s.pattern, filepath.Base(rel)); !match {
+		return
+	}
+
+	processing := filepath.Join(s.path, "processing", rel)
+	err := s.move(filepath.Join(s.path, rel), processing)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			fmt.Printf("Failed to claim %s: %s\n", rel, err.Error())
+		}
+		return
+	}
+
+	s.finish(rel)
+}
+
+//
+// Record a report we've claimed, then move it to `processed/`, or to
+// `failed/` along with the reason it failed.
+//
+func (s *spool) finish(rel string) {
+	processing := filepath.Join(s.path, "processing", rel)
+
+	content, err := ioutil.ReadFile(processing)
+	if err == nil {
+		_, err = submitReport(content)
+		if err == errDuplicateReport {
+			err = nil
+		}
+	}
+
+	if err != nil {
+
+		// Don't spam stdout when running test-cases.
+		if flag.Lookup("test.v") == nil {
+			fmt.Printf("Failed to ingest %s: %s\n", rel, err.Error())
+		}
+
+		failed := filepath.Join(s.path, "failed", rel)
+		if s.move(processing, failed) == nil {
+			ioutil.WriteFile(failed+".error", []byte(err.Error()+"\n"), 0644)
+		}
+		return
+	}
+
+	err = s.move(processing, filepath.Join(s.path, "processed", rel))
+	if err != nil {
+		fmt.Printf("Failed to move %s: %s\n", rel, err.Error())
+	}
+}
+
+//
+// Record the reports left beneath `processing/` when we were last
+// interrupted.
+//
+func (s *spool) recover() {
+	dir := filepath.Join(s.path, "processing")
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err == nil {
+			s.finish(rel)
+		}
+		return nil
+	})
+}
+
+//
+// Ingest the reports within the spool which have been left alone for
+// at least `settle`.
+//
+func (s *spool) scan(settle time.Duration) {
+	var found []string
+	filepath.Walk(s.path, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(s.path, path)
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			if rel != "." && s.ours(rel) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.Mode().IsRegular() && time.Since(info.ModTime()) >= settle {
+			found = append(found, rel)
+		}
+		return nil
+	})
+
+	for _, rel := range found {
+		s.ingest(rel)
+	}
+}
+
+//
+// Watch the spool until `stop` is closed, ingesting each report which
+// is written into it.
+//
+// We're told of new reports via inotify, where possible, and scan the
+// spool every `interval` in case we missed any.
+//
+func (s *spool) run(interval time.Duration, stop <-chan struct{}) {
+
+	s.recover()
+
+	//
+	// The paths of new reports, or "" when we should scan.
+	//
+	found := make(chan string, 1024)
+	err := s.watch(found, stop)
+	if err != nil {
+		fmt.Printf("Polling %s every %s, as it can't be watched: %s\n", s.path, interval, err.Error())
+	}
+
+	s.scan(SpoolSettle)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case rel := <-found:
+			if rel == "" {
+				s.scan(SpoolSettle)
+			} else {
+				s.ingest(rel)
+			}
+		case <-ticker.C:
+			s.scan(SpoolSettle)
+		case <-stop:
+			return
+		}
+	}
+}
+
+//
+// Tell the watcher about a new report, or that it should scan, without
+// blocking; if it is busy then its next scan will find the report.
+//
+func notifySpool(found chan<- string, rel string) {
+	select {
+	case found <- rel:
+	default:
+	}
+}
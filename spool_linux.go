@@ -0,0 +1,115 @@
+//
+// Watch a spool-directory with inotify.
+//
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+)
+
+//
+// The events we watch for: reports which have been written, or moved
+// into place, and new directories which we must watch too.
+//
+const spoolEvents = syscall.IN_CLOSE_WRITE | syscall.IN_MOVED_TO | syscall.IN_CREATE
+
+//
+// Watch the spool, and each directory beneath it, sending the path of
+// each report written to `found`, until `stop` is closed.
+//
+func (s *spool) watch(found chan<- string, stop <-chan struct{}) error {
+
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return err
+	}
+
+	//
+	// Using a non-blocking file lets us interrupt reads by closing it.
+	//
+	events := os.NewFile(uintptr(fd), "inotify")
+
+	dirs := make(map[int32]string)
+	add := func(dir string) error {
+		return filepath.Walk(filepath.Join(s.path, dir), func(path string, info os.FileInfo, err error) error {
+			if err != nil || !info.IsDir() {
+				return nil
+			}
+			rel, err := filepath.Rel(s.path, path)
+			if err != nil {
+				return nil
+			}
+			if rel != "." && s.ours(rel) {
+				return filepath.SkipDir
+			}
+			wd, err := syscall.InotifyAddWatch(fd, path, spoolEvents)
+			if err != nil {
+				return err
+			}
+			dirs[int32(wd)] = rel
+			return nil
+		})
+	}
+
+	err = add(".")
+	if err != nil {
+		events.Close()
+		return err
+	}
+
+	go func() {
+		<-stop
+		events.Close()
+	}()
+
+	go func() {
+		buf := make([]byte, 64*(syscall.SizeofInotifyEvent+syscall.NAME_MAX+1))
+		for {
+			n, err := events.Read(buf)
+			if err != nil {
+				return
+			}
+
+			for offset := 0; offset+syscall.SizeofInotifyEvent <= n; {
+				event := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+				start := offset + syscall.SizeofInotifyEvent
+				name := string(bytes.TrimRight(buf[start:start+int(event.Len)], "\x00"))
+				offset = start + int(event.Len)
+
+				if event.Mask&syscall.IN_Q_OVERFLOW != 0 {
+					notifySpool(found, "")
+					continue
+				}
+
+				dir, ok := dirs[event.Wd]
+				if !ok || name == "" {
+					continue
+				}
+				rel := filepath.Join(dir, name)
+
+				//
+				// Reports may already have been written within
+				// a new directory, so we scan after watching it.
+				//
+				if event.Mask&syscall.IN_ISDIR != 0 {
+					if !s.ours(rel) {
+						add(rel)
+						notifySpool(found, "")
+					}
+					continue
+				}
+
+				if event.Mask&(syscall.IN_CLOSE_WRITE|syscall.IN_MOVED_TO) != 0 {
+					notifySpool(found, rel)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,20 @@
+//go:build !linux
+// +build !linux
+
+//
+// Spool-directories are only watched with inotify upon Linux, elsewhere
+// they are polled.
+//
+
+package main
+
+import (
+	"errors"
+)
+
+//
+// Watching isn't supported here.
+//
+func (s *spool) watch(found chan<- string, stop <-chan struct{}) error {
+	return errors.New("inotify is only available upon Linux")
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+//
+// Create a spool-directory, and a prefix to store reports beneath.
+//
+func fakeSpool(t *testing.T) (*spool, func()) {
+	dir, err := ioutil.TempDir("", "spool")
+	if err != nil {
+		t.Fatal(err)
+	}
+	prefix, err := ioutil.TempDir("", "prefix")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bak := ReportPrefix
+	ReportPrefix = prefix
+
+	return &spool{path: dir, pattern: "*.yaml"}, func() {
+		ReportPrefix = bak
+		os.RemoveAll(dir)
+		os.RemoveAll(prefix)
+	}
+}
+
+//
+// Test the reports within a spool-directory are ingested.
+//
+func TestSpool(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	s, cleanup := fakeSpool(t)
+	defer cleanup()
+
+	content, err := getResource("data/valid.yaml")
+	if err != nil {
+		t.Fatal("Failed to load YAML asset data/valid.yaml")
+	}
+
+	//
+	// A report, a broken one, a file which isn't a report, and a
+	// report we were ingesting when we were interrupted.
+	//
+	os.MkdirAll(filepath.Join(s.path, "www.steve.org.uk"), 0755)
+	os.MkdirAll(filepath.Join(s.path, "processing"), 0755)
+	ioutil.WriteFile(filepath.Join(s.path, "www.steve.org.uk", "201707292317.yaml"), content, 0644)
+	ioutil.WriteFile(filepath.Join(s.path, "broken.yaml"), []byte("steve"), 0644)
+	ioutil.WriteFile(filepath.Join(s.path, "README"), []byte("steve"), 0644)
+	ioutil.WriteFile(filepath.Join(s.path, "processing", "leftover.yaml"), content, 0644)
+
+	s.recover()
+	s.scan(0)
+
+	for _, name := range []string{"processed/www.steve.org.uk/201707292317.yaml", "processed/leftover.yaml", "failed/broken.yaml", "failed/broken.yaml.error", "README"} {
+		if !Exists(filepath.Join(s.path, name)) {
+			t.Errorf("Expected %s to exist", name)
+		}
+	}
+	for _, name := range []string{"www.steve.org.uk/201707292317.yaml", "broken.yaml", "processing/leftover.yaml"} {
+		if Exists(filepath.Join(s.path, name)) {
+			t.Errorf("Expected %s to have been moved", name)
+		}
+	}
+
+	//
+	// The same report was spooled twice, but is recorded once.
+	//
+	count, _ := countReports()
+	if count != 1 {
+		t.Errorf("We have %d reports, not 1", count)
+	}
+
+	//
+	// Reports which are still being written are left alone.
+	//
+	ioutil.WriteFile(filepath.Join(s.path, "new.yaml"), content, 0644)
+	s.scan(time.Hour)
+	if !Exists(filepath.Join(s.path, "new.yaml")) {
+		t.Errorf("A new report was ingested too soon")
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test a report which was written, but not recorded, is recorded when
+// it is submitted again.
+//
+func TestSubmitInterrupted(t *testing.T) {
+
+	// Create a fake database
+	FakeDB()
+
+	_, cleanup := fakeSpool(t)
+	defer cleanup()
+
+	content, err := getResource("data/valid.yaml")
+	if err != nil {
+		t.Fatal("Failed to load YAML asset data/valid.yaml")
+	}
+
+	report, err := ParsePuppetReport(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.MkdirAll(filepath.Join(ReportPrefix, report.Fqdn), 0755)
+	ioutil.WriteFile(filepath.Join(ReportPrefix, report.Fqdn, report.Hash), content, 0644)
+
+	_, err = submitReport(content)
+	if err != nil {
+		t.Errorf("Failed to submit: %s", err.Error())
+	}
+	_, err = submitReport(content)
+	if err != errDuplicateReport {
+		t.Errorf("Expected a duplicate, got %v", err)
+	}
+
+	count, _ := countReports()
+	if count != 1 {
+		t.Errorf("We have %d reports, not 1", count)
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
+
+//
+// Test reports are ingested as soon as they're written, where we can
+// watch for them.
+//
+func TestSpoolWatch(t *testing.T) {
+
+	if runtime.GOOS != "linux" {
+		t.Skip("inotify is only available upon Linux")
+	}
+
+	// Create a fake database
+	FakeDB()
+
+	s, cleanup := fakeSpool(t)
+	defer cleanup()
+
+	content, err := getResource("data/valid.yaml")
+	if err != nil {
+		t.Fatal("Failed to load YAML asset data/valid.yaml")
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		s.run(time.Hour, stop)
+		close(done)
+	}()
+
+	//
+	// A report within a new directory is found too.
+	//
+	time.Sleep(100 * time.Millisecond)
+	os.MkdirAll(filepath.Join(s.path, "www.steve.org.uk"), 0755)
+	time.Sleep(100 * time.Millisecond)
+	ioutil.WriteFile(filepath.Join(s.path, "www.steve.org.uk", "201707292317.yaml"), content, 0644)
+
+	processed := filepath.Join(s.path, "processed", "www.steve.org.uk", "201707292317.yaml")
+	for i := 0; i < 50 && !Exists(processed); i++ {
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	close(stop)
+	<-done
+
+	if !Exists(processed) {
+		t.Errorf("The report wasn't ingested")
+	}
+
+	//
+	// Cleanup here because otherwise later tests will
+	// see an active/valid DB-handle.
+	//
+	db.Close()
+	db = nil
+	os.RemoveAll(path)
+}
@@ -35,22 +35,52 @@ type EmbeddedResource struct {
 //
 var RESOURCES = map[string]EmbeddedResource{
 
+	"data/alert.template": {
+		Filename: "data/alert.template",
+		Contents: "H4sIAAAAAAACA6vmUlBQSsxJLSpRslKors4qzs9T0AsqzUmtrdUBSRWXJJakIkkFg/hQubLEnFKInF4YiAkVzk0tLk5MR9bkCxFBGFlUkpoSnwixEmQkiO9YApVPyyxCknUD8eByRanF+TllSNJBUAGQCq5aLgDNBtF5zwAAAA==",
+		Length:   207,
+	},
+
+	"data/alerts.template": {
+		Filename: "data/alerts.template",
+		Contents: "H4sIAAAAAAACA81Y/W7bNhD/v0/BacXsAJHUBAW2tbaBot0XtrZBkm0YhmGgxLPFhCJVkrJjGH6gvcaebEdRki1ZTpuiGBogNnl3PP7ug8ejJ1+8evvy+o+L70hmczF7NHFfRFC5mAYgg9kjQiYZUOYGOLTcCpi9EKCtmcR+5jk5WErSjGoDdhqUdh5+E9QsweUtyTTMp8FmE/2qRYFjfrfdxnO65KmSEX4ERIOYBiZT2qalJY4ekHhfu6Q5TIMlh1WBQgFBCQsSd1txZrMpA1QGYTU5JVxyy6kITUoFTM+iJ+9BQxBOakycKGWN1bSIci4jpDTA7FqAyQBso8ikmheWGJ0earox8c27EvQ6PIvOzqOnlbIbE8wmsV/2YTq6YPrrJ3ETmUmi2LpWKemSpIIaMw1wmFBN/FfIYE5L0cBHScZbSedKyiXocC5KzlqZrlStyO0Kek/GASitVZLYdYEh8pOgt8yqxUIARk0IWhhgAWHU0prsIHh6Q6Z64TLpS786IFRzGsJdQSUDNg3mVDjZiurQayXarTrQnJdxUQPG6FBJsQ5m1x4OruALarmS6FqUu2epS8mwUv9/iU5i78q9cMQYj150OGsN38XTO7OJfevcjva90BalEKGAue37rhR7YWzU4VdPrjpYjWSiMUNSXeZJyC3kaBkdPv/ISWYXZVGADa/KPKd6jSajIyj+C96DEpei65yOKwbs0XyRHRg0VzrvZaYjYSqlLgsOMBqgOs0CgiUoU+jpi7dX11gTlMvZmnfgij0gXBalDRdalcWBHEpW7PrYWLizbQQdpiaxA1IImkKmBJ67aXBVI/L10AKCH9A8jCFMrByQ3p3gJoRWEvxvi0YN0ZRJztGlkzbWC7EuMpfDpB2FjVsmMZ8dpvDR+B0hTmLni3si35nuTSYxxrceDtW6XSXMzto7DYcNtZhdZ0BoxSCacqxZJFkTi0TMkcsS74Pt1vNDjbPNhs+JBOJZ5Gy7NZsNSIZCK3RMhsXKlT8554tSA4sI8ZtW9AIFuVyQUlou3B5cE6eUZNSQBADDgacqzRADuoMIhbIgVbnAuw6lJZlz3Vm/rtRqMEoscbNJXOwZ9pVMTPG8orU+OJ+9wBOwBPTBeSNamRR5+nbbiFqauELu3ekn1WeYKI0ZihD9FK8uXrQztJyBNO08U8vOLTKxuhNkm82cI7HNyPr0K0vtIOM1GEMXw2u4THsMnO1tudlobHvgwNwKmfcEvCNRtTcJvLsDjG3tB+YWYwXGkOPNtKOvqJZOktS50MXFZnUqbbeIhg0wq/2OcmuDj/GZw+pUaAvshe2L9R1QAWwPUhWmXSZ4szqHAxPMJZlUVfFcNodlP9kapXtpdlnn5GGiNZzPKNXuyajvuXZGHDJ2Bn5Ath3YPADv/VnyQXlQAT7Mgq5Qg+cTZ8sb1VTSjGKiVBWtKU7YrFdVtaDGYve3Hsqffm3v1LAjlEy3D4g5dtKgq1bJD+9vg480wNhGhTkLn3bbqE6L1G83sIs51v1oyji1SmMbdFkPyW/4ujnS/tynCQSnCRfcrp2y3ezhqjiDvEAHydSp+mk3e7gqI9QKjEU1V35E3igG5iPMw+5VlU7RpR99BBasosYhwW9uLE8/AobPX1TS9AoPVZBjbqEvKV5EqOX1bjakqtvuDvX9nyYpM2sL8yyOF9xmZRKlKo/N7V1c+K7c+K48mP3A7Y9lQi60uoHUfg6AjYUlRLeYoNGcoz///YecPzn7OsSPb0lIrhyb/IzsB4LtdJC+WHRf67tuPb6hS+qpDeLH43kpq5fE+GTTbPB4PIrqy0v/WT1vnSl/jU4i19INrXBrbMbNifsJYjxKS22UHp2OCuWSRuPK6k4b77tpUM2+KsrYS+fi8chf16OT5/uC29MHadOQI4R7FZ5EuH6Uq9JAWYxOW51kDCekp9asuMUeeQxR1SyfdLmb/vMhjskv+FwlLwVPb6M+N6X49j171iczlZY5SBsJlVZvfjLdOcdaPR61wemZ4v5cA377/NEAktecsapNOYrl/ADL0v0yA6vfuWRq9SAcq2pJpPDJMG41nJLR34mg8nZgAURYgpZo9yv/mhsfta1D23Zj+agd1XLNoPublP8pCnu76vfE/wAIjUXNYBQAAA==",
+		Length:   5216,
+	},
+
 	"data/css/bootstrap.min.css": {
 		Filename: "data/css/bootstrap.min.css",
 		Contents: "H4sIAAAAAAAC/+z9ba/jOLIniL+fT6HOQqEyKy2nJD8d26jz7/vvGcxt4PZ9sdMLDFCdu6Al2lalLKkl+TyUx/PZF+KTyGBQkn1OVfdi+yZulw/5YzAYDDLIEBn88uMf/ov3o/f/L4qmbipSek+z6Wy69D4em6bcfPlyoM1O5k3j4vSpRf+pKF+r9HBsvCgIQz8KwoX31+e0aWg18f6cx9MW9B9pTPOaJt45T2jl/eXPf+VE65Zq2hzPu5bel+Z5V39RVXzZZcXuy4nUDa2+/Mef//Tf/vN//Le2yi9ffvyDlxfViWTpr3Qa13XLaDCdef+LURaVef/L00jnNC4yUn8xy/345dicssu+yBt/T05p9rqpSV77Na3S/dZ/prtvaeM39KXx6/RX6pPkl3PdbMIg+H7rn2o857orktfLiVSHNN8EV1I1aZzRCanThE4S2pA0qyf79BCTskmLvP15ruhkXxStzI6UJO1/DlVxLicnkuaTE83Pk5w8TWoasxL1+XQi1eslSesyI6+bXVbE367knKTFJCb5E6knZVUcKlrXk6c0oYVCpnmW5tRnBbZPtGWNZD7J0kO+2ZGatrmc0CYvmo8/x0XeVEVWf/2kSORFTrdH2nb5Jrj+fEyThOZfJw09lRlpqIG7ksuOxN/atuSJHxdZUW2aiuR1SSqaN1eyIXGTPtEJ2RyLJ1pdinPTstCKbberfm7SJqNfL7uiSmjl74qmKU6bsHzxkqJpaHLdTeqmKvID78FnztQqCK7JPudpdfOa0U3akCyNr8dQdst0uaInL9hyTPor3UT0dD2R6tuFc/ldEATbjvfNd/t9cK1PJBPawso8BN9f6/NuUp/LS1nUads5m4pmpG2TRnu1+H7L5C7F5hR9S6kpyo0/XdBTS/siGu1PozYlPR2ENDbBtX46sF7aVEXRfLq0AtxnxfOGd8mV65VscUhP3jwoX67H6qLYkBq+K15aTtP8sGl7nOYsaeufil9deXjytaxoxwg5N8U1LhI6+bZLJmVFJzU5lcZwOxV5UZckphP1SxNcSE/X3blpinyS5uW5mRRlwwdGTTMaN5N2AJKKEjXcWOFNmh9plTZb3pfiL0GpY+8prdNdRmUNnOSFjWmmpPuiOnE1Foh2svAYIz83ryX9iSd/nWhJFa1pY6TU590pbb5epKxJWVJSkTymG15+G5+ruqg2ZZHmDa1EZT8naU12GU2+6tWqxIsolNA9OWeybZsN67J9EZ9rP81zWnFO7PRLSZKk7bxgq/SJQS+6ovLZ8qq1Jj7S+NuueDEbTZK06FqoqYYaubYyaVl4quJQrz8/n3a0+rrZyMpYa/y6THNf1xQHujg3JlqOBaaqeq9RUsVHtE1vGyFbRA9aldunNEsQDjreeYIft0UypLGuAgmNi4q0cxOmg0y/WeU1bZRWTGcLevKmy4j9Z7Wgp60cYV5UvkidaafiusjSxKvT7IlW14weaJ5gyqVGqjk7yAFtzeBNq+dy5m/nBZ1ea0oyUtZ0I39cm2TSHLuKr+0i4X8U5yqmGw9ZahwXu5IZ/4W/K9KMVsx4GUuOuoq/xHX9pbXBYrXwxxNNUuKVVZo3lx8nG7JvTfZmR/dFRTXL8Yf0VBZVQ/Jmy5cIR5IUz0zWWpZmXgJPL2MoHV7UlXMlE8ImtoYmfCrrun/DVl/cxP98rOj+K2/ARajn5oP38YNHmqb62OZ+8j58+qDbYSeaZQs4I/x//fThF/JE6rhKy2bzQZScqMzvPljEPlzZouTv56Khram4WCr23Xq93pbkQP1dRck3P83bFdWGPBVpcm3adZNaezDl8flSymf61VrOSdNOe3j51rCeyIv/nCbNkS3jNJkeo8lxNikvRVUeSV5vZtvnNCme682MZ+lUWbME0WlOnnakMpdE012TP05jUtFmMk2qojyXj1qaVPmmKH1Moa7TjOxohognCILr1Bg21ijRyTCk1yQT+etor9W+2+/3VhmfU6dJV1hLOiKcJUmiUbn+USwAYmosBX7479lreUzjIq+9fyfZPkvzQ/3Dtq7izbnKPk6nX1p0/eWgYP5RwvyKHs4Zqaa0aD7dXuT/911K9+nLJ681+aT5+AM97WiS0MQvSpq3s+sPnybjKT4X+33UEWN/3kzALH9T8abRSjfVmd7cgvrp8F0H+L8VQOR31Ounww+frlOFRdbB7Xo2LF+26B5khAJo63i+HtnqtmIeBMbaOlSzJy93Korm2JoEkjcpyVJS04Sb7aJ+gZhDRV7rmGRUa5HPrEFaf+umeTFl/S0IIvJBh5bZuUZhOwNGz5VATczUwi4cBSQ2Cp/SHKskisLIwMVZcU4Q3DIITWbyJ5oVJUWgq2BtNo/mcZqhwL0BPGSkRnikAaj7dK7TGMWZbeErGRQ4M4BHSqoGxS1Mgg2pUNjSgvn0VDavKHhlgM81xWk+GLB9mp1QmCnr5uhnpDpQDBoGAIqCQoteWqOyAYpTfENBpqAreiqecObmBvDXojj5aY4iFzayOOMsmv1S7PcoyuyQOj3kJEOBZpfExQFFgR6pSI1KOjK741icUMFEIdQDHGb2RpM6qIH+KEiCwszeSIrnPCtI4pMMlXO0QOEo1OySc+kEmr2S5rviBcU9gLmUvPpxWsUOMa2BPpaUoE2aBQC4ryjejzOzg9rh4pLTzOyk1pShMLOT9hlBFW02h5NYUh6LnKJT6MzsoqciO5+oa0TMlhi47VYUvcLQ5xLFmr319youErSjZmZH7YgTOQfTGi6seQhRqJjmZg/tCnxam88s2IlUONTsJbYJRHFmB8XkRCuCAs3OYZ4rDLYCLGboMJubHcJdnigQTGvtJlEsnhD0IrDRfJOEgc2+Yc5NP6N7nHKEgGOaN7gZXcwQeOVke46gfznXTbpHbfliYY19FLYEc1lC88bdwhWGdvMMFgokpu3s7zMXPloALM/SuDlX6NBamr14IqXfqjku6SXoGP5pAwPOgKnCFXhp9gVNUhwGlmhH4miL2QfMI4niTOm71ivLB7Dko6XfboSfSYWOs+Ua9FLd9OJXAZj/eqChZQFRmNk/JTnXaMtWM9CyAp3JV3MwDVVO/hZ20/vgS1uyfXCzv+gvNEb1ZPUA+/+pKtzTzGqNwp2j8CGwtnRsJYliQ3tr5gZHyArajZ6BRbkbafbf38+0bjfgbvwCzEr7wo0FXRhXlOb1scAlt8Ia6F7CPTzAJvZg4Soi7wGvzS4kVVU8O/VjHSJgp3asIwSNr5DWMwTqWnqt5/bk51p8rhdAzuwL9P6coXud9RJDs0+ZKByMwpc4IyfSp1Ah2NQfUlTQIdjTZ5TsURhYM6eoFQgDYFReKfPVodCFBY2zosbJmtJ6JlWe5gd301dwxs5xsmDOIhnNE9QFEQI/QEXypMAcBiHwAsTF6URRAxwCV8CJHHKKAyN0rkT1OwQeAQl2aHgI/AIVbZ6pgwu4ECjKsu2EGPfthCFcR2fM+e3qYuAlEHCX8gBXgRg+8vs9WmKNlTgWVfprkTd4GehCSBIUZfbj7pxlx6JC2QZehB3N8HpBH7bN2qcxaVDJAWdCczyfdrVDO4AnQWBdygGcCUeSJ845OAQOBQZ2zO4hcCowrIPhtY10sQt8CtwSDZiOELgXjEIu9oGfwSiDNwO4HIwSzubMgfe22KH9D1wPzxXNUa9sCNwODam/1ShuBV1i+OYvBN6GXZXSfUzw8Q0cDq1d5OsWDAx8Dgmpj7sCX6CGwPNQkpJWcZai3QDcD8wv7fQkh8ALkaX5NxQGPBDHArc2wANRnutjmeLNB068Gm+4Kf3DDm+yKfe6wGdr4FBoYf7u1SdZeSQ73CAAtwIs4lgnhcDBIIvxz5MYfubGO+uY46w1TZXuzg3qwguBs8Eu5KwNdFfONr8U7bQFXMiVJMeB0BnOvxU7ZwvgdVB4fD4CnoesOOBfA8JlCH2lqJc2XEYWQfyjQQjcEzl99p/TPCmeUTBcnsQFPgtANwVB3Qoh8FK4lhfASdFSw2sF3j32NR0FrmG3O4DAL1FTXDtWsFuKsnz1E/R7KA2Ba0Kgna1azTC489tSCF0VHXkUvcDQrp4A3oq4oknatGtOnPMV/DaU13t8WoH+inOT0Qo1A8BVwc+vYMAHa+lfVrSucSEDJwUlldNwABcFw7nmIuCgaIpnB69z+Em1QSdF4JaoE6ffMwReiWMfFIyv844dVsI5AJ5AdhCmbmjlIL1G4EmR7dC+XQcIeuGHKDZEsEsHNkKwKwcWrA3l0X3f8ckjXMNJ8ZDWDT9M5i6zsI8h9H1IDIHHgRdwfk4M1w9g5FHqx0WeOkbfem3DExqnybnAjlHQKABjK0FBoTVtuz7oRsDv0c4/bixYCNInmuGGNQIOkLYzURhYC5K6wWtegg8mFDUbEXBP0L+f2XUKTPYR8FB8Ywd8EVgIHZjoDA0PuJTkGYeBT3ppfUQd3xHwSHzLHTu3CDgkdmT36u+L6nTOCAoHux/UKxMt9+bZoV1G4m+uvUcE3BA7iqPMziFlianZ/mFvHtehFb6VioDD4VicK8fRnmgWmmecMnJChQ48Dsm5zFz+hgj4G8r0cHj1dwTdHEXA4VDHaV0XFTrEgbdhlzZxgS5KI+Bq2DXxCNTLrhmBesWUPAiI2YxfsFFtoarzDuvoKNglEDcCxU7AYS0Abo80pn5WZBk67wBvh8L6TTsDodoLnB00Ocf83DKGBZ9H2FWqfidbBNwcokyPKy8CDo8Tzc/+kZx25+qAz3jA8XEqEpK5Nx0R8H8UKc4F2H5XBFdW4PiozzkbrOhiJ5oj3/XZnRsMG9pYfkAYA0c2WDvpjpUAfbn7hcaN+GSPf7OMgCfEKCJuTGGlFu5S/aoD/CRGSYfvLgLnNowyfUoHvC1GOZdzMYKHOqqU5IeMugvAcx2ygKs1wAej8G5pA/eLKuHo0gVcnOZ1gU9D0OdyLmklrhpg6AXcAfRgl/Z4dwpkZWPd0n6wwQ7/SgT8KwyLLwGXwYfrj+987eoKLrW8M/Xuvi2/4BeU3Y2ohpT+MT0cM7Yn4RNMddiRj8GE/fvEb9bqR8Y//DvNnmg7lLz/pGf6YaL+nvxblZJsol3n1Wqdly/mofHpPHpYrML5TFwZ/G42m23R6xDmfUR4DVHnTV5C7OqVKXrV8nIiuaiaV2S32sK7O/w67YZd4VPXZUWRaLaIVvG257oPL6eu1zbHNBd3aLcybVG+eOTcFF53fj4+137Fvry19UikX+z3NW02flS+gEumAbtHAy63ntIkyeh1GpOqONc041f2HqdpQ0+P5DE9HSZ4HstJTwe/onVZ5HX6RCdT9iEpJ2nmiaIqof3TvBK9NW/0bPW7fpxw27k0kfdlKpKk53qzLF94tiKN3592U1d3F3sUDdWuLXp5ZmvyN9fHTEVyceGCZJk3jWqPkpr6ae4X52brF0OI/mwuB/4RCUhpEXx/PVai59mkHrWDWfwtzAJLkncAt93VJr2BlNLrtK78Is9eu+sjZFcX2bmhWyHh8kUKuP3ZXTAUmue3qeAG9JZ9nKlo3KgZpLuPKGvkak7a9bO4i47k8NGjeKsb0qSx4Iz1t9736ioyvGjM+WHa93NVZOr+8AVcAJ4ew8n0GE2mx9lkepxPpsfFZHpcTo7hhN9EO84nx8XkuHRPN+JyzAJejpmG4E709Bh6U3YYZNL+lL+iLjFSibMucaYS513iXCUuusSFSlx2iUuR2FWu6u6qVjV3Fat6u2pVrV2lqs6uSlnjpf/qkBiIq9XK6AQp+AFlb43ZWwV6r0Q0m7pcfH811EZqi8Z96OT+bf35pm6RsRKY7I+hljhjU3LbB5GeyjmetT2jBXKY83ZMjnN9ofHAUheT4+JiLgSuTEZLPbU1bKWyaV7gcdlklCQXZH7TSi7ln0LFZtYAnF/FJeKPpzQX5mO1fChfPl14BVpLwvLlehWismJPtHI6kerbhEWtUBe3I3rCTEu8f6Cz65QtEdoVLb8bzA10+7fIYgtYPY8liEx+NlvP5SkiW5yu1vNFkgDkxXNFysvzMW0ou9JNNzxJ8lU80yomNYUxGFSGAJ7LEgeqDMkxKdk5+F8tZJcjoKdzQ5OLPgGw5LJKWagVY3F2JUamXJSZieYK7WEZrANBsz7HMa1rRTNeLWeJpCkyTZoy0aS5W8yjWNBM832hCIar4GEvCbY5JjWWYpKaL6LlWpASZ95k3gNZJrOdpCYyTYIy0aC5XC5CxV5C8kOXRdbz+TySJHmeSVGkGQQf5rPFbH6d7g6wV9jCydJ51VddAVWJlsTrsIvLLtsdVIfZoGS/D5IHXgfsOS3JVUcc0mg3Y3WwDkQqWNNkLxph9KT820Wa7JN1u7DaHVSHOqcFoqH0Csx+RYqvaLxbsDpEByOYKKEJ5VWAnu5SXBXQ+W69W1+n7H49/xwqZzo5A6+VIdvMg/LFCzxtzamHDNJWm0U2OWe6OQwwW1hkXpFNisw7t3CPFfK6cgIaXKfsotg5Z1eUVewL7jdoZ/+6u72cU4Hm+wiIFZTZX/6CbR3chR+zFN+WSKLcGbHoVsuc8KJ8uSa9rW8FeE2SSWIGg+n2LteksSMuKdPIG9Nj5pJM87Z5La2sIA0zQ3K1vwzQ5TwlFYdBC8UTVAGaZWlZp/UWszWgepPv8KFtPI96kZCG+EWVHtKcZD6PgTHR41KJdfuRZuXWFaPK48YkzVN2/bw+aTZ8HXy/dVqwLhyGMu6tWnrawpOtTeASZDVddPove1zX/o6wV2SbjNSNHx/TLJloGaUj/awXsEaCBhSrFi1FxDfTUviSxtyxG8G1Bnw0rWCtKuXFeqtmJAM9BP/D36IgnHt/C4J/C364Tju4X9EnWtU6hWl5zjKxaDKHXWiNu8BWWrmhlgNV6yWjAwOMDdBeDGE2HEM4RKa1yylWHeOSsI7Bhf1DX9O4J7KnZW6ATqC3XX0Qo5a+VpmxbH5guuMxPfrhSpKkahcPzo2DHtnCMd/2x1D7C82zYvKXIidxMfkT85uTevLhT8W5Smnl/Sd9/tBFV2O01IwSlS/e3Jg/2jlJrk5W0WJOsd3Eeh/t57ZX6vptl4wj7VqxzQDRmebq0mIepXlNGy/w/JBZfM1JPI0Wn7ajkS3Dns60HhGQOfWAmXOFZoIBmVgcPHNmk1Ws2fwMNpd6tbNRzunnokp4yKGNCDyUZTyxtXIirf0b679F+w/xNcZxjPRqWVHP0JoA8WkbbiXD7pYVZTxt+0JBgmqD67QtVsdVkWUspNGJvEiBzOb66sB/3XDYddoOQJJq0e2ck3HY9YHAaK47DmF+OvcipqtLpC/YwsEusF5HaIH1ylEgjIIALRGGvEiX4e+zc5q8W2unVfF8MXC+XpSvS9uUloXMzw5+OFG/gu6nlhqpn92vmfo1V78W6tdS/VqpXw/q15r/OiWy6vZX0P3UUiP1s/s1U7/m6tdC/VqqXyv160H9ElXXJ1l1+yvofmqpkfrZ/ZqpX3P1a6F+LdWvlfr1oH6Jql9qWXX7K+h+aqmR+tn9mqlfc/VroX4t1a+V+vWgfq2RiE6trtrO+F71u/4DG9BtLzouokv35aZLDeXYDKdL/n8rLTcQuQ+z6Uz8X5e7VvNAl/Yg0pZLhNxKZC4eEGpLmalxtxBpc4y5ucicYbzNRGak8aYEgPEm5YCxxhY/YXQRva3Lj2eFIgsVIocEAoJKkkHWAqGLk2U8iAxUpgyxEoiFk/ulREDeFyJj7mR9LhAzJ+czgYgg50pkTs6l5JyMS7nx2Vrl1Me2Q/hYM/ujzQl5jqM7WkTAEY7eqI/+mgPMzqiP/gNPd/RFffRXHODoivroLwUAcr3g6XMn03MOmDl5nnFABHmWgnLyLOTlZFlIy+gD/km87QXDmaB3hoSEBgTtFQkNDCjaPQK6NpB6PwnAgwFAO0wgVwYS7TmBXJpIu60LAzDvaercQM56WjozkJHdUtAFPS01e6KnocFo19Y/bIFgGTlWi2XkGBtOI8f4dRo5Vg0wci0TTiPX8uo0cm2ToJFrG+w0cq1cnEauFR80cq1wnUaubarLyNUnp5FTWW4jpyBuIychlpGTGW4jJxFuIycRlpGTGW4jJxFuIycRlpGTGW4jp+TiMnISYBs5loMaOZXjNHIK4TRyEgGNnEx3GjkJcBo5CYBGTqY7jZwEOI2cBEAjJ9OdRk6Jw2HkZL5l5OrToJHTIENGToMOGbkO6jByHWDIyHXIISPXIR1GrgMMGbkOOWTkOqTDyHWAISOnybffyHVAaOR6XRn/oB24ZeVYLZaVY2w4rRzj12nlWDXAyrVMOK1cy6vTyrVNglaubbDTyrVycVq5VnzQyrXCdVq5tqkuK3dKnFZOZbmtnIK4rZyEWFZOZritnES4rZxEWFZOZritnES4rZxEWFZOZritnJKLy8pJgG3lWA5q5VSO08ophNPKSQS0cjLdaeUkwGnlJABaOZnutHIS4LRyEgCtnEx3WjklDoeVk/mWlTslg1ZOgwxZOQ06ZOU6qMPKdYAhK9chh6xch3RYuQ4wZOU65JCV65AOK9cBhqycJt9+K9cBR1g5zf/+D/JxW2aO1WKZOcaG08wxfp1mjlUDzFzLhNPMtbw6zVzbJGjm2gY7zVwrF6eZa8UHzVwrXKeZa5vqMnPZwWnmVJbbzCmI28xJiGXmZIbbzEmE28xJhGXmZIbbzEmE28xJhGXmZIbbzCm5uMycBNhmjuWgZk7lOM2cQjjNnERAMyfTnWZOApxmTgKgmZPpTjMnAU4zJwHQzMl0p5lT4nCYOZlvmbnsMGjmNMiQmdOgQ2augzrMXAcYMnMdcsjMdUiHmesAQ2auQw6ZuQ7pMHMdYMjMafLtN3Md0DJz4l2gvscYxXuU6mtyU5SbB+1bnji50iZ1B7C28Bx5c0SOlrPKtatS4OYUcvyQl3lsdkXy+thUj+qpIS3pqJL2RdEAlErqUEdKEoBSSd3TYQ/u4xfgYltTlI4rTUmSXJEq4KuPrL3g5GCEUhF981lS2+zTSh7D09rTD1OSiIuMPYo1SK4fZ0rWzHORHIYetRewNoGhCJ/Z/+r5qLS8qUPb2f1N8WJVXOQJe5MW0TE084hkWnqHZmIlLV1EMzutXKgxod7awh/agiiseUje0c6zG4fkIeXspiF5x7Hc30RNDCIxt0SdzOqmSkutwZu8OfrF3m9eS/qxSJJPF/SU23q/lhTYEfWuvPNIPDtaxadbLy6yn+OM1PWPP7XT81frBqH5Xl1cZOdTvuWLf3aKTL7RZlCZyPfabqJNs0ynDCbTKb/7iEyzKudoT8DJVF6ZtKZmmCMUBqkH5mCzuoMaUo9QCaQemIPZBQe1rh53j6NqIkptRKpS4V7UcdKjco8miwDTNYGjXaijgcIuXjy0/ywtEfdZMDVRWaieiFxMUWCW1AekLisL1RUHQawuqRNIXVYWqi8Oglpd7ktDuC4YV4bcKgNgAzpjsokojUHOrTVD95mSgK7jpaU2ab4vMJ3h6ajCtFmYthjpUh8gfTMdVRKMjkVf6gCkb6ajioHRkfTd17zwvu7uebn1QccMKIPGGqIJHSG3GvRePIvndLafWTog7pJhaqCyUE0QuZgywCzZ70hdVhaqFQ6CWF1SB5C6rCxUQxwEtbouPfd4MR0wruu5VQXABrTFZBNRGIOcW2cG7xKSfRTHltrwC4KY1sgcVGl4JqYzIEfqhV0PzEEVBqeG1CN1wq4H5qDKglPr6nFfvMR1QL936dYUEzWgKAaLiJ7oxNxqMnQhdBfHSku0uDAX7UjyNAi/724HvBgH+XkgeI/kifexc0Kslivm8LeoOn0U7JCzdgNB3FD0T7W6hCgu9rRJLQfHlDlR+FWFHam2vfufjodHsZm1bp06gNh+rw907AHZO8A+UB8lexfXBzriMQLwctZ+OBiG6kLSvQ+TW0oeby3ZifPukjfX2Qn+7pJGnRdwL/EmSWt3Sm8T9G0FNTnfWfDWGjUp31lQr/Fi3Au9Rcgakb6hNlDweIOsbq8RKwj8N5vget2nNEtq2ly6D7OBHfYp6AI6ZfRA8wRcutMmcFjWEcIlCoH/17zgpt1R7AJaIVEGFu2/K3+qf0zwMJOnBQgjswqCKws493PzWtKf+HPYX987Np9WA3tXYle8fJ1oiRVJ0uKrjIszZxcqlTSFB/xva0N4/FqrTnmfZvSr2UtXo478APO1XrzygHs/n85Zk5YZ/Soi8P3c9t3Xix7gDdYpwk5gjbSzeFN/s6h5xbkpzw1+QZRJcmVeCR2OV7hYLK7TfVGd/LjIm6rI3ANB3aLU4qgtyxcvjO6o1BXGrktNT+RA5SXZURdO+278tmXb/9cv8gYr/M6vE4sEzxNMsCboAfC8abioJzZDFgaE2uun10fnPYiYqiDUWKe2+W65JHu6Vnoc3CP0SeAF3oPMCINoEq4Wk2g2m0yXN/VILyHQmA2b18qMxPTInm6TUYDW6/W2KEmcNq+bEBRqV+FseDsKWnUIYdxU5lSLV3YuI246t3ZdL/9zkrJYg8nXiZleUZIUefb6dSJNYgf1zCGP7JcodclEq3CQsAh0kheNT7KseKbJVYY8NYGOCRgaLFKWlFQkj0VcG2R3JqGt+U/oUxpTv0xfaOaz4Kab4NNFo5+Qhn41ONEn8yY99eS2Zdkzy1kRk8yNOxV5czSzjfA4MxZLjisM+2rq1ycP8jjpATA2JwMUNE77oJzZiSUiXqI+2eLBcqBoMIwQi8wyRRJAkWSHAZGYAEQkNgWnSExov0iyg0skZg4uEhNjiCQ7GCKZL9kNfqZFjMuL7Vi4TuXKZDJlCxHkCjaMczsc2VHS9NiiVFDmf+jeE7YcNq5vI5Eugy2MowlDmKra0EWlyhaxrBwozqK1LBMZSFmxQrWDyGoCmrNFqhHSIDIE9BmK/7PoBY2Ib3ST4MTkq6fT0HBd42WNxld29oBg53M/t59N3rHoYOKAGAv6bNkLtAP7caIzsZJTWQDN1SyXRQ4tybO6Ypg9g+LpCBmS6ZIxowlFjGEMOfcxoqoyRixM7WOjB6KPfowJ3cz5/ESBPkvomwe1YQEHxVa2KqN0uzm0J7c+gSgnAYiude2AmvXZaudnrEA7kbXBWdjhh8SOE6duGTu4Pe1MplovQUL8VGZnEFpLji6q3qk9oCrRPGeFtj3HymstNtZPADy8aOwThNRDnT1dJyO4oR2Wz7Wz0pqB3hoB97qgviqSMCAjTmFu7TDyhvKASqxlgUN5sgNUno6QJbPsgCvP+zYL1NijQ3hL0fJjdCg73KhDUB5Ahxh7ug496GIKbxHTdXoktb+nNGm3Ybb1N/NBL5lz2zyaLpSUJOM2ZbW64WZazou/+mme0JdNtMVcQGzm1mdxuIfZWvGdt2JN4dMnmje1OEDWI+TPOOdwdT4AcwLkrZJl15JhNYOTySge69MAzAmQl1sCTdr4dNpqhThX42krTzRZLd3MXFG3WCYYeUealXyhCTLYAgBLw+qwNg9YnlyPIxBttYFkGAVBVGyTQdPbYfizOP63dh328IN62XiM7rt9a60V+2652oXLh+0bygKudQ0nSVLkpsy3rhNrW0ziPRLpBgNSQpwHgSpvJRvq2OXaKq/yoMqrDE3lzTSsDlTlYR6i8hJiqbyRgaq8iLRuMtij8hz/+6g8yo/DsbwI36rycUDC5W77hrKAa6fKCxm6TlptMYn3SMRSeb0EraqiggoPEg1VlHm2soscqOoiWVN0PcWmjSq5mYOoOAdYCq4lo+otov7rbPUoN0f/PsqNcIOqNn+B4I2qTR/mD7PtG8oaPDsVW8jPdS5si0nbKQ1LrXW8WtKy3v7fjoLsts5CLnjMMvLFpb6ywVXT9ovT/blAvZ/qwtqs/dcTv4vVL5RXd9I6PuGbfjrHu2c2TfiJ1qCqvSp1K0G5ucHogmKa3kA4O7Axqm6NiIft2VCcpbFjwbsmv3TCcbPyaApZvzVvFDGm1cs9ndnN5EYy9xb3qQz26sJtVcqJ165YTLrQO4ZTwf3uCNEeJ3sXFta4cmpSce994TAXBbUnGqCcsSxl2yyEMIZ4OnyIQ7oy+/rpNgbFpwPtA8tK7Ux1nP1FqD8C88AcZnBnKLqjqUoLrcefEGbH9GYXDfgWXoFXwc16GFp+meudFdWnvoqWlhPxep22c1Hf15zuQA36Mac7YNP7Hl134Mb2ydgnZB3fhfxT7TfFOT76JGbj9UTytDxn7I3QrTvH/J6kFj3nmlY+d9jxQz3sOAaSWtuJVsLIY0LuOPHsm/uuycV9tik/yKWlbLSU7ufGgm8s+G92+Avyov003ruazWb4K7Ba83TeL7g0R53wmZUv3gKsPUPHUwouLOer+2y2a/KBMybtKLI/Pm33acae3cjKI/koTq/8tNTObQ08vqBOvEyXiysxuULYYIgL6uPcNbmf0D05Z81l6GFgsKJm1xS08lqPyyShYb2E6bL9B7ehcfvPIG+rzkhaJGn/maxquqXoy7SipPnjNKmKMimeWwt5OGR0vKBu5AGRmj2wYY64YmJzj/WBk9rGpDbYbkl8ELgZDRzRp8m8/TesH2/sU3RqMSuQAwrrMpm36cuzu60bpAjRLnPTm8nJOqYf0HkDqM04lPOK2+Ak4U13JDnQoXcNZ7zQDa8ggnojukzI3KCiS9h4LbGfPH8lEZAPo2g3Dwzypi7fQCsK5skKsKrrsqQ/rMujxHUjD4jUkPkJ5GiKbnKP9YGT2uj5CXTwIHAzGjimT5nohvXjjX3aMz/JCrD5CeZt+vLsbsPnJytz05vZPz+ZnTeA2oxDueansVMFmKVkMTzaTlsOPHiLa8oi3j0sYlD7PCZ0HhtUdFEbT672k5/P18kcKmK0WCyjhUF+jFKjtGbrh/lsbbKqK7WkP6zUo8R1Iw+I1JCJCuRoGm9yj/WBk9roiQp08CBwMxo4ok+56Ib144192jNRyQqwiQrmbfry7G7DJyorc9Ob2T9RmZ03gNqMQ7kmqrFTBZioZDH3RKW/ou2YpXZxYH09mS93DwnpSOhC7h5tHtC/cBckC2god8vkYdERHqXIGKFouSa7WONQ12JGeViFh4VzS9VQRshspCdrOq2xawkaJzJ6BtI7rx+1GYcas0Ji8hno+Lv7q2e+YXSxycbI2DgzQJfgc4yZs3Hn9M8uWsf0QTYjIM5JZczIhjOKKOOeUcBj+rgK7AOSzGHVlJJotjSo6II1nmvvJ0/j9SqEW8/1w2IfJAb5MdqK0koWD4swMlnVFVbSH9bZUeK6kQdEashkA3I05Ta5x/rASW30xAM6eBC4GQ0c0adcdMP68cY+7ZmKZAXYbATzNn15drfh05KVuenN7J+czM4bQG3GoZw+pJFTBfQkiWLuiUrEEepXlPViNrcG3ny2nxGdiOGs4/F8RsxS8XoWRNAOrpZhHK514mMUGiVF4mgt1/KCT8MnyqmPcImOENRtDNjywnzcRobuK9UZR0TvIjXev2106hBuMxY3oh+5xAZV4k392OfZ5tRRx7aZtenJsrrK4dUGeZu+vAGftt5h/aDNKJAz3OO4+QDMRbKUey7K0vzbxbphijmpuufCZbmJ+mWoRZuwgQnDnyc5K73X+ce+CI9waDGkdzr7W0heF7Aesn2ooNxizBbRKrY+Jp/zhFZZmtPel9mviLhgdaNVU+O1F2Kwr8LMYx/C1WGRx/YvwdLh8n5Xnbo66pNWR3ch8S138TriL7VG/KXuGsC/tt9JGzstqR+IU5jPGtw8TqnHcdidm6bIv3ZY45YtrWnjyKvPu1OqZ5rH8khCL/KjfYCFZxGZLLiJ17adVCDgCoboz+b1TtP8okXIiIssI2VNlcy4osnkFm3GLmoqNFPEwSqeryzEVj+Gq4CqpV0s26frRGcHKuygCjpoictv0lOaH/z9OecHeyipqSkvHDJIwq4qOYsROZ3BIDggz13IplpWRUmr5nXDWz15Sut0l2Zp8wqq6AGOQl2nMalo03cUN+hEbzzkUb44Tlxp7xPMyxcvIfWRJjCVnWz6m3I4i4t/fUee+JMkGOKqFjgT9utcYtcPwSIInG8KNMCJ5mfHNUMWQYqf8FQXDcMgCLb6eNl271dttXe1lvACswrjFolYYuBwHHhIZJuldSPCasLjY9o6UhlkLTdLy013Pf1l25vXE6VKSzWOP7GTUiPCWPGD/O0MbpYH1xZ6YKCbpuyVJaY9F3kBVHvsx8B60yR9ShNayduvoTqfuFmz7oBTC+J74YHtTMKPWfpI8GhmrSnyWFSLOKOk2uyK5jj20KN28AWLB2qzIJclSI65Hlq2/9A1xdYd4N+kKt8RIBNXBs5Nl21tgEbyI1bAroH7qLYeCG9dloM7DQDXYNe3E0NbiJwA7I+fhR5zFIcGy6o4pMnmv/7PP7dZf22L7YvqNP1LGldFXeyb6aEdoTRvPtKcMffTnmQ1/XSFW0U2CZq2nkOIc84cOQ7ZTK69i7aVJ+YV6khJO0wHRlTvkhCOonYR3TuKWrG2f3QT/z59oQm4XK7OMQMbsF4HV20ugnJ0iORcetz+TqY5edqRymd1itPSniIiUJe4yBuaN5sPH3RzCuNw2kZXyxB2t6vfYHSQD7NZbe2sB9VdI/s1q97z76I2Jh5vWGY9cJdSaXuMibbdkCuXsVGKHHdTbILaLkbcxuGna2Et+hOXDirGBh7J3wzla7tULFvzzHSs2pU66sKr0ChfVCAGrYVsj/sZyKhLtDrK636ipbQs8/aIuCoiN4JNUWQ7Upm5C5DrdTXoKTpTKl2/QAb7UoAeLXKPDnKPBjnjjT5jl8ylmxfNRz0m9See0gUU5glwwfvpgjqN9M7U4lyDy0xu5I2VN0XJx69iw5ykQKZVc1eRLQdDD+Fy30LrHLXNdDFk5EF+HBoAASP6jE8UA10kqPWJH4ppcg8JILx36jxRdV8X2or4xl7yLEWwZjK2rLFw+irHbMZnC2qGr3mAEQQf7EHMorv0kwkjSCeMDEIOvn+Pay59DHR+41tcw3KJg8w77CIcyxRdLl5aFgxCTRBei26F00Mj8ASVMabczHCvKR6h9TfXsd0rdFvnk6DXMWT15+wc8M+9Tfg8uh2fR4gCMcIsVGQI7m8GTm5HzJI9EyRc/PRMcK5JZV6+bF1TnZaHTnYj5yPAZd9cODj13TYd2w0ATR9QunewYSjZO43ZHbQcVu39uvM3MXB2L+v1/3Kum3Sf0sR0q+tTC/ezZ+S1ODdiU9t9UWNe+U1NS1KRhqKUrXnQzAFRFND3NiU7318HyPBLkfhEiOPNTWO3Wfw5IQ0RPS0+3NRfWUn81v84vAja6gZrE/St9TiKOoP6Ms9tReNGmOfgEx6pTt9buLe7XG3ciqFRMR9e1Xp5XIhSJGAGwlcXxk/zpjtfDOkjLjz9kuAMhuR+BLE7YC4SsaMPsmtyPg/+9hE1HS1wYOx2jACirRkburOXvx60i9ORRSTPMG6ogx0nymZjFBSKjPuuzLiKfTrX5g7oHITAKn+L2L8O1h2YUco2qhljgwz38teDvlHZXDzjumGx40SNVLYhkVnK5vVoGqJZ+n6lM+V9s+vwshCp9OZS924PRjRXrk1GxxRBqF6GXhLq/eanPyhkhzrBnzUZ+ZgQwqsVUtw5QyCnalzUxpw5Qk4XWeQcIZl6ccbDXL6IltazHNAfArQ105kNFu5iWTyE0FZ1A2CwPbHR2pNxcFsG90yDhX9rt22vXK0X/tydpe/9bJquXHdHOHZkw8Uxd/OAjEf24Ahsjz/2Bqdon/zgw5ZwkkS+YKnBHaBfVlErhZzLwXDMl4R8ysGwyqGLZooPVGie9ZnqvUY7DGGGsH6nrnTcbm3p5OQJxL2zwm+BI0SszGOWDr2wI3GPZPAtHtMKLARfxvkU9XfPqQjU6ElS2skK44CGlWvW2He6Y/seZzMYB544JjHR/9AYUUnOR54pvODCj7wI6jl58t/vIJPsi8f0dLh0TmilHH5DdvXF+dAne7NZwlpF0o++GbqnVFRCH4k5THrOcNwWEc0TT3OC2rDD5K2sPf4/sCXaAScsVetQO89QrnZRJ/RIhLnpDaAFpAtmdFzlGAfsR+cO1Fwl8HFZR5Gu+8zet1Bdz2nvtVrrVieB4eMknRev9+iIow2IJ7JzP45vVHAdhe5OBlkrb1f7bc1yIaCWOXG6bsPxeYcMH0nvkB8z5n7nRpsDhN2l4byUaZaBmcnM6NoK+04iPmfpBRx9NgGgcVay3iI7c0xQg84A+HVD4m/4aO2yNJZZ5Gn7G5xrtrgOTwr3zgW/xRRw28gfPeA10Thnz/snhP5xMWZM/AaTwHtPAL9DI9FB35CdLw5KstfX/ZLk8D6LgRHhQ+0FL2MCaij8tH3P5zt+jhELId3FSV7YTyGyU699a6HBk5bITDd8OlOcxtXmT3USU10XAkdXFtbRlTZFrkr9lw27gZJ1N2pUVh1XRZa1GwgWmlc/54ov/wZeIwj4AZZosZjI/5+GzjcScLTVXHZpSnL8OmKaMmSlRbg3TvGOPi4DubEubTEV/kN6KouqIXmz1XzDWip4r1DbNYje6bBDEhAFrvjxYVAWHDJuitIN4RH9UczQg4jvxgzbFqkXwgL9QDN5kY8zize5HoLy5RN/wbmoUpo3fDOZkTypY1LSTh3ek6soCFhY7nZKI2lOK3+fndPk0SbrQvAxruX3lQWl3hqw/Z+B6wAsj662Al6M+17gGBmbPobnAI0a/FKBqqutB/B6gnFKXr+TNguG2RmoaoDFFmLcPzCFgVVyUXMNdEwZJEJtLdGS2FUkT3TXgm4jlcNpIRxOfZ/W+VPHOllt0dGluR1TZmHmNTEXDkNCf+w00jNoTWwAV3cTdrGHluJJniN13DZguqLktZYffbQVzUP3p2bJzb5iX3Juv6B0a1R7o0n2ZU0j25umcZH77QIHu+sdRd0rhPZnrdCqrSP3uSNsPqE9PLZEXxjrTlVPTp4Ewc1q2uqtrzylIls4W/UXHwL7jeEwwPRbN0+St5XOm/JCeq5Lr3yisk9wsVWL8TpIvyrwTr99bePi0IOXxCZDJQxBbuQcwc79RkDmPeWdc0hvMTC39GMtd7AWj2VY3VqNsnyv2kARfn7gBHEr2wJRNmOu2RfV6WI5+3snE98xm1gz2vCq37U5ffPOYPJ+24ZeUiOMc1Gd3u0RLpvmGx/hchLsf4TLKHbvI1wuIvCoixuHnAoZB4aPcLlK9TzCZRS56xEuk4J6e8lIfudHuNAq5SNcdsWOR7hwKviJD4ToHY9wGVRueIRr0IRao9Pygl5dUP2ru+WBHDUt6C4EfdYO7D193y7ndvMMjYbbNxa8wTGG7417q4T7iN/vZodcm3fnFYDt6wydDpZBlLQyYYA+3miVeqmNUnO7lLGApi+NgUeeiFyMWtC2hOzPu/Y+QTn8Rmgzu7XdEUU8TjymgLaB0TxY9urCLPa/TSLmWOiUGXnlRXqUH9p/MNzkqv0HS4NdGjiW4ASCJSKOMb8ns0/3w+cQMHKsD0ewptaEN2AHWgIOffS96jOmJS0587PCIGoEgz2f8JEjFP16wOjpsUjG4cZw6TrHwk6A3qUXxrEyGahQnIhwFxjgVYCc4RMH6Wv7erv4w8ODs7jlR4UAZkVvGtZM8NrJngHMmG40zgGNUbXBVQlSTc+mdtzgHr29va3su00DeB2j5oaBove2751nEUcl46aWwcJ3N/LuScjZVnZpfIRS2iEy2ZtgEK3uofeRhBFEnfnj67PjhI5AuuOGDnB8UxHYUaoRaf5Eq5oi02wUwdDowUP7DxbF1z/rpP3XjwVSwjHDh2Sw6QLS0tc/A6zBJdBI+EBj8FXQ3e3BV0G9qBEM3nQ0aUAbXKugAdwYLl0T0Hw+v1M7sFWQPtTxAgO8DqyChun3roJY0GhHcWsVBAHIKigM2n/93QlWQT2YMd2IrYJ6VW1wFYRU47JhIOLcTRNdzzcKcTz7ntEyvF4bnolGL9luK/tuc9bYJdvtRe9t3ztPeeOXbPcUvruRd8+Yzrbq66sBvbQXIOjcBVdtLqqOhZudP75K58KtDzm4cHNxfFMR2F3X6a6iJImr82mnvsI9gI9w+rHBEbGDWZxX9IF5VZV+yNb8sGNgPmfpZkf3RaUOafFIS1tti6BiWX75WxCQ4INBQp7J1FfuJTmkOTuIgX/WQG8+scZ76GHcjp7dKpDbjlczoS5Jf1THrXUhGN7XGgz7y8Lcjg3S67o/AlpiXFiDrTIyWQuxzwV9vnVXYCGzGu1mm8WClsc4uNNpb9faTZswWcTBtDsYL8BywNXBrfnSxJg7XbBvNOuHpSK8AJuF5rXMOjN6aCKNVJrZ6trghSb0MV15jUGvULeJeDrGJrRkjly7+UZWL2VNBNo1wTE3ApGXzkFv+9nBmlZEGp9Z7goXY9fgHu5IPhxv+ihe9o7xpTnaBG3XMLeze0f6sn+kw7rrky1ZnmZIdmRQFJt0j0jt/D6RznpFOsOa5Rapld0r0lm/SGXdtLr0GlU7VIV1vZhR8XD7yjJEO/hvxnSfcefdNsdOIA5foZTn/RaqeR4wCzLl9nvJvPA0py9N1yL+J2uU9v1SgcuKPqXFudYKqCStED+sJQBgtjSTzJbgc6SRwWq5Y3K7Tvn5DrOrVCdNI3rypsv2f2b0pI2w1eJ7IxDKyhUIRT0HYGjXcHyWHakpf9HJ6PJptKCnK+FcCynJv8Y9QyAkI+KrifZv6KlsXsHVIhbLVBx/sdaH8taQINDz5ZkteQ3Qz8eK7tXOBMtyPu3KvhhLcuIV+4v7lqGBw6o1s1zVRg/LYB1IcsgL4Ma71ACHVWtmuarlL8NLcvAVYOPlWh2EVailO9137M1nSQh569N4gRLgsDrNLGeMAPYGrNIQ6y0/4605E4bqkZ7jqpO/6ngVr9nhR8u61270o/mz8sVb2bb2N50K5GNE2MiCFoGdsmHNcg9p3uq+IW09ZiYKyTfNJA3jpJZ84OxKBANyduJ/3Tk7tWaYs5I29CQX9ZKd7hqy2kI8mm8UytW7Y/I3ycuyho3DMZ8F1DiJs4DXqTt29P0nW5X8cj7tiqbqAm6xM00z5Gj8zD5NxZJ4Q9L8SKu0cZlzVY03PYYT7c9jeDEI6FB4BhDcRYlCoPJREGjFH4+Vvl5TbxO3/676nRFVYmJfFLHF03shEwnIpbVcUb+A4NTCAV/HFaU5v+xmH/DCe2r+YPfUnB2Ne2ML9ce1eAuXAWiP1ZNd3yxn7Gh7czyfdjlJM8fTM3OXbw2PWnL3ElV/PUt7xI+DvGlUe5TU1E9zvzg34CFAB2gQobXeY9FgJl2CCA6jjVn9Hkh3VZ90ReQ7IlqSmtq6FCwki1yFdOxMY1Iyv592dWmrfz4jGa2ai34R7Nab05iDkFH1jnPzWCsY/hzE/2M/3LpqhzjLeywn4sfZOuetIJ9L+PikIJyk9Smt2ap9YialO+vhgBle0JvGWVFj5UWOy7i1plocsWRzGCYBtbyT3RKvljNs95Ds90ECT1QmS7qOl4CUh06I8ZpGuxmE6vKXq8/dYt6uVngOWwaqJdsqeMDf96bJHvqsdjF92Ic6HZwxsqQhNepDuZovouVaosBz/w9kmcx22LwR7x/oDDC2J3QXx4AUztt+RcPdAkIR9pbLRdgJzXzqm6zn83mEcRclNLHeZN/FcRKalHDm6Hy3jgOARHh7mM8Ws/n1j3Ji/EZf9xU50dorq+JQ0br2+RXbKi1pfdlXxUlfxCrlnjPnxbUp0NzAC67XP/rFb0r+N6Q9lRQv2m01bDYcDhHm+iA0dLHKerbReW/KRnb8s/MOVuhv9RgpC2zV47uLurWeYwvh9Fn3NM/XLnQZb1+ORSJWnTXMmy65NQbWHGQ6c0y5CU1KJl2qSPFM+Vq3DSV//IlgXz0WOF8k9DBBLrItPnnR4vuJZkqtvxfB946S7pwVoAH+/mRfMvaL/xcy/U/PMfKYLBtvbCaaB6Yj1swxVVK9KycTRQJQSFkfydMT3+BiM6QXyUesvTTfp3nasHFze6GbS8BxNujU6h+AGIF/DcR/DUSLY6B3A17NAaWDpf+lcf/SuCGNG/ZsDygdQuBfevcvvRvSu8FPGwNqZ5f/l9b9S+sQrWM+bXhRWiRjL+OKZyZY/oT/x98VyesFbqx/LYrTJrzqEOVLZ3HbRE6x+4XGDQxDqedN09PB73zUMFo3hzJPnWDoUXuS3DzKEWoVtwlmAXYrG3xB0Aq0bZjYhUU9SBBW8GmuKUpJin+luziiTsjqWOgw+KmfpUrMkZKW2UtPbIuF1uK0buDRFuvoSvcFqz+oPg8HBL533R4mHwmXfts5T8DBmDcGB6Ii2DTdkSve9vIkgTVN+Mt4lli7q5R2GQ8mSK1wEHPi9Vt+xJarPD4C0vkZG7wqUciRad0SHXvolznorg5R6S9Ads4vfpoIfiCWJ4Imzhx1XsCVf99JSfa11UXT3aEDbN5TkPE/pBLqo8d4llvZ387vQCkHs1Z4B/wgwMSR7upjkes489wXsdxx/hev4IZeGyjwOK1PJMtuL9db7GbdGlVsgNeh0v2F+5X6zmJDHA+UZoWHxpOro/Bx0S+q3jIjRlK8ShJqH3u58ZOnbUkkAYdZwOm7ydxq/gbLgT5yVuyyiCq/zzICIgMg8xK+U+AB+5zsZKg7m9APGGqYoUMDrMs6R6FGCcOclvsnYyEpczLm+mSRv+FTOSLitrSLcZuyg8DNqtxbaFCP29LOvmaZvT2sFe9DmLrrEmw8p7P9zMGkW3G13N6WjFJZvaphyHDTb9FUIRdTU7muWLRvOz6ByFQQcPGO0neTuVlrh8oNKq4g4Oxxmd/b2yaRAZDRhW6Bk30Ux26G3XpsAoYaNkqbQZ2jUKOEcYNaS0kZai30ySJ/07EbRMi8vIt5jLqTyM0qPVBsUKN5eWe/i+ze/jZI9GOMnnMKmu7iGNVmTsWtzEb+QJtGqbJZ4RjQGDHcoMdSRoYeCx1y9vmQTw5de9sPVxoXHq/TkuRWxNwoGO806z38iZ0B4keWQnBkKTCP/zhBgmHu/9VPqsoMKS3bMTgm1vXwHUPsEiCoG32WSwTFAfs1Xq5Jm4xeeuMJa0e0lvbZUY2M2lXaafyyW5cK/kTKiWI41/uiaLqrjbqgB47AmeHIkdcZBy5ZIvc7c5o9agNgIpM4pzKAjw6xDw9bVKzpdwRZq8zFfpFja72ublWte529Ppf0CJZG07oYzyi9aSgY9Xfebq/HEz6mJeMowafm3ke7jCH++RYVGPMJoTecM6j6jqrEqU/dSHw2hzEGdnRIw8/Pi1zkL7+idVnkNbssxVKcow2l7YmLCiZVPNWuywPXHHrv7lxdxMwnqVkuOu6snMsbB86NjDw2rSk0Uyo3p7cQbhVuNOE38HRTrf9s4vWa5H2kPVjP8Xfp1Xdrz2A9x3dSpl6O30/G7yfFt8ipR/1/UxVH7PRvoeHvUc2IrvldqnG35t2k/27yfTcJvkFGl7umby1Wi+DAyZprhXjvivAmXkT36AnVKD3sJ9qu3kYSvZeZ8fX984l1tDV7Ux3H96ijV7Lv1I6BOvqs3/uI/J2k+k6Cu1s2l3+MJr99uhgU+W88I71PKwa76u1TYK+NexeJvo/Q7pXLZThumbY/bxv5Gez0rRyt/0weP2tYZ5froIvTUWgOrTFrp8mtJY6GGwyMZZ5FkyEfhAJe5NtgDkKcocemeuyd4RH4cRS8VYkbqCv4OOrt+u4G6go+zmDeKatRBO7lYJQ8RxG4l4NRMh9FADVp8sW/YW11zuOouEegNdnegh5FW5PaLehRBvE+KY1U07vKj5HkSCW9q/wYaY9UUdtkyXfnBpTOnOQHdRRsXIdUYyx1FH68XRX667uZwPH2zrifA1wG8CvNYH/qC5vh7tTRx6FJZCRtDH1PX/bVdmv54+3D+u760fYP9GNHz3HVpfvAhX2X5beCtHwPPbHgiEwPS302C6voUDZSnSPAW4di+z4KTm4qd8NS2KRofcxzcMxxsGIPqdg6LqHXreKuYkG6NQD4etr7XB04LDCW5kghjqbnmZEcJVP4u0MoIVzGj04ZI6ypGLOO8O8axiHiO26V9JG9UcpjSHq3hMxECd0raJM7FUIEDalmYFzqfFewtj7Ktyr1CJJA3JI1x1l3B627VdtgkMfPQKPEdQCXrO8KPucke6OgB+lBKQumHOe0MUL3ithkTUWLQEPeGRiHoO8LptdH+UZZjyEJJ2vBmuP8sIPWvRI3GZSBEtAofjrEIe/7wgP2EL5R3CMoQmkLxhynXHFS9wpbskdPO5roi8uhi+LizGsXMDqAoftsop6VIo4BWkCWgKSnLD4hksEjESAZT2lCi641ZFcX2bnhIUTbVa48x8uv0GuXk/WAft3K2mpAuNy9ri8gdPBiOY0W3yPo+e51BsGrFvlMs+xySnMjTqA6Crp2RI7tX+vpi0w6a//dHjRx4FzxAJS3y2Ma8/dz0SBPeZsDEMQl5OX9rDt+HM3x+NAMV5+MULwmjHnheUBZLSJ4XyBsZ+z3IBCPjBinqwOPrQ73adbQakOy8kg+FiWJ0+b1pyj4tBW/N9NI8CHvNfM/jGPuqob+kOp4ZQu9MnUPnzddBbIrS0oqksfi3bVuFIMaOh3bBJ6+xzwVCcn8oqT5xRr4LK8bdPv0hSZixAlvkzXy5P3tMFgEWz3OvRURVDZBpvt1XBVZ1nLfFOf4uC3OTdttisnpniTUEwwnKcmKwwUJeGkk7Yvq5E1nIhS1Hc5a/mXjNJCLEqyIAzPS0I/BxI8W33/a+qe6P7/oze7Jk0JJ8z6RWGWDPp6CHoYCFzfBJ12JBDNKl/wX2d8q5ZVH8za5tu0Un8O1SOAySAwrJt73Q8rhFzSQSJNxlpabbhI352Erz5qK1+u1napPfNEne4brlBq/AzIrX7w1mIDhFRAcIwXTNiKpivLeYTsPsCdJgwDSZ6Pxgk5c2rxlF0vzy5jZThQTD+casdedHhj2Dg0oKoOPa64tP+rUSL9iYl0DkqH2VRvY5RpL4czrNhwKL4G0fGthUrjNwn1YZis4IfZU5+f2fywHnx4l32LA614NsUmopyKxMszWf+5+mm81yhJ8yt6Ryj9RUp8r6lih+ev1ujXlfEwv2kWPkPLCCBbN6XVPMFvPPhhzBs9aBl0IavYGh6dPL3KeQEfbQlzN6R1uDpASgQyFM2PRthDe1+tI4z2TfK85ftoURdakJSK5blCuArBsZ+ubPTml2evmw7/T7Ik2aUy8/6Rn+mGi/p78W5WSbFKTvPZrWqV79IkcGZWqOpHMWDjNA9eIgCF/9L/rhlQNvurRF1tdQmdWWFpGm4ZW7PGddugIvp6LKvF3FSXfjBQM9VyRUiUYD/nwFg5NWbzNvC6uSqKLnHPXWp+71h2+KUrjRUA1WtmYYCtZCTWCqInnbK3naSRYhCxzkjbAerQ1hLDBhp/m5oMsUQAfWnq4PfB5uwpGvkPIOklVFc+I+oOQ7IG5xUCuJ3JFZtOo0QceqMo0fovge1Mgmo0R8xV/Q8kzLiPpllGri9GAFaqHiLZw2pbh2N5WJ6M/0Eo7BNz9daL1sa9GwfdbM4od03RnTUZt/LAVVh8mU1mdXNEM1hfIGrfakQ60Oi4grMLRKmNVZrprkOp8VyPlok3rQbyRd9Tp7Ehba+6vsyxKtid2OW+sJegS7By16Wi11P0p0oK9zRDO/z9qCH+vjVIcx3dslNwLtgCsxSJswYaALLMuFJOZaV3L+T5T5nLLrA93M18YYz20q5HP7K+55NbzxRbEeGS/ewjMvOLueJl21f7r2Rbt2n9AxGr29boR2m2qO+8bY0YhHqdsipiAvzdk36AD3Fy0vs2gm1WC++OhzaRgSrRp8+GDOXOZndQUZUdYREhlj+ij0z3Lsm1k5xHQUg0lXHwC82R3p9hiRPDf9efWUqKtapz3AeFHeyfcVR1Tbtlyw4LzRhrDQmu1Yau1duvpjpYzs9vLiNly3lI+8PAmG8xojcZr4mT1Nt/S1VKHUXOnCcLIAJJw8CKaLdwB43u7lyV+PECbimBni6fXRvQ2qEdfPmkN15Kdzdb4EI0Wd9G7FbKzxYO8sBbHpCrONfZIcpcnNjsuzyP7YmS5xM3Cj9OBaMzMkCNOavmwkngd0WNrB9M1jUIG8nH+HsWDi2heejpcjLWN9GCQLOOPb6pliT9LPk0+Wv7lNvmCC2ace37Z9+Ck6aFfOp+d7KGnrWj2JKb+U1qnuzRrd+va62SOLFm6pFVdUh7KKAz4tthKwuUvIiBNRex0FJLTl+YiVsI9HvxZ8rHVy4nLH6/n9zPDI6ujiLKiT+N48YeY8cdx0yOUIUbF+mwUu0EfqzzzavPpYjFngV9hKhMeiLKPU7xIX+oNhF17KO35XJTcRSxcsfyuu303AUc3WD3gaixfBvfVog8SlN3WGFRFdushgIX+kN/wu332B2H4jODyk70I1/KDT6M+51rt4hIa/ZII6w/TJe0FRkIQBOEnrxXiuGc+3kpRMNrRY/QnzK/RFOWEOxvaX/uqOH00a/o0aYqPVl2fRrz10RQen1hHsy66p6yKQ5ps/uv//HNL969yUpj+JY2roi72zVTVwbbyf2p7um6qn3747iHg//fDxKN5omUEXcZ/F4X/+lrSn0KjIRUtKWk2/D/+C6ILfBRI35Z6Anl7v3pwAQRQSm9QjxspvkE9uC5ADVncrx69rL9dPQKXejy8j3qoEycwfdx7/u5vGOrjtP41A9biTQ/Za3lM4yL34yN9qorcB9ahBwkXQQrKUMD0mZk9FrDdyUgH4kKtvtOc+3yY98H28LylYR1Djn3j6BqGBSK/H3R1yE2bo5KRopSfeLqvsey37lLlzlJzf9BHc7Oj+6Kiyuvyw9+iYLb+oZdJtAz5wVghJGlMmqKqke6XDpJA38MrZ/JiK78Sf7/F39kRX8HMd0zEYsB6tQdhycvSC6pwnZ+pO4TYOfZCeSKgZTRv2Cfydm3iOraludT/th5agNhOWM0tIjyAIdyvqAbJ9aloQKQ1INI9k47LCF1Xy4BzVp/J0HPfb/VzkCL6nNZ76lgqG7b6YUrjAOWbF3Q20+wUxAX66eXeuI4rSnO+PbYPKfxzTZjydELXi7MA+nrUsGeL5Nk7zo6/3aTYPxUK1avEtCacmpE2CwgFmrmGwUW/anftDtL48qWwxy6Ne7EmAyA+z3FUUxTZjlR6QZkkYXFGSbVPXyRG/a0ARd6QNKeVv8/OaaJwINmCW0AFSTL/WFTpr21G5iWKpJUuCzD/j5bFEwyZ9EEkGf38kSxnpJlAfrTLBIo0CczJk8xvf2rJO1Kpg/UaxEgGcLM+M9GEmhiVWZJDV57/0WXJk/1dvkqRIOUo5T+FwTSewtN9pTdoK9BDS+cGVMypICM6H+tnrEu1bnR0F9oxZk8YsrcFrQlXfDZqBbHZFc3xOuWGRJyDMz9pGZOQdlbVV1u2q/5IonZi/Q/pqSyqhuTNVXsVkQPan3r+MU263m7tkJ5ZH4tnkys9N82ZSzOjF8u1eZ0y88aIt5P/JvgSeGRrf42zvlZbht/+drcJGNsJzZ2Mk/0+fQHHVK9/9E+1/5TS5xYmbFdCn9KYciN7nYr2+Nlhon6fku53fep+v9TO2jsyvGMnegpfxyFJEHtKkBRYWiVBbH1CUmBplQSxLzWSAkurJKC+QBzq1KI6arFarthiBhElVDI2/2BAlqEDKyfKr4pnHZloyElzdJeLaZZpBUe1BBvKN9PgYgVL/zupAIb0RJQiWHKKhaiqZ70OjXrq08i+04A9fQdR7r6rT3rfWeWcfXdz+0b36O2Ux/fzvbTv7n1+shjWE4btTlKr6JSM7H4N2NP9EOXu/lOid79Vbrj7Rzfw9v4fT/oOBbiV+N0aELIDuhrJ7DCyrzVgT19DlLuvs4Pe11a54b5GmnJ7r2JE7ug/N5lbe8qa+/miqGdlcvN8Iihqs+swxQEFFSS1ETtMUslMFNZUABZWYi2rNG8G1iQc4yjSr+MmtkfNEaBb0xlYV3asNNR3EzxqMYY1fGhEADRQ/RvqGRw0KP7+dt0zugQhoW+9unT9L19+/M6ri3MV07+Qskzzw//5f/zHT7uiaOqmIuX0lObTuK6nJ1J6P375fwIAAP//bus49azZAQA=",
 		Length:   121260,
 	},
 
+	"data/digest.template": {
+		Filename: "data/digest.template",
+		Contents: "H4sIAAAAAAACA5WSTU7DMBCF9znFqFIlKkEWSF2UHQKya6goF7CSSWrJGRvbUUGW746dmP4AUdVd5s2bmc9P2fRKoQXTdx3TX9BIDc7lz8yi91lWMC44tUCyRgM3zgkkyJPq/eIhc04zavFEhLig+KjJe+d4A/mbFGFZnE6fC+eQ6iDdgWAmHMewNXQfbbgZesJEP5SSMEvWrBBMqX9QkvyL5aBeBdOEMTCV1DhMhWobC+/nU1Ql7kFqtWN0RHod63Oig3gV0Lgaa2D2Uj5bIfcYshziOTmc9HS4ZB0OmzusOYuhK83JNjCb5/fNDPL1oAeLwUpSbW5htbQ7UKgrJMsF/h3ZrJZH/xTeu1SAWoe/q0NjWHvG+BIbP9k8yZ4i7mcs1qN5+tnfGm1tSr8CAAA=",
+		Length:   703,
+	},
+
+	"data/email.template": {
+		Filename: "data/email.template",
+		Contents: "H4sIAAAAAAACAz2NPQrDMAyFd59CYwKtoWu3LjlA6QUEVuKAK1Pb1IPR3aM4abfH+/neyxPMMYRYV16gtUAMVgQ4OmptnYEJhm6OcBPJ4PGrAYVM2vKYVbNTmQumQg5mXIOi7sa0lpAX6jgl2+njWKRD7TOGfT+ofcjxx7lCxQxHa0rxvS/+4rhlqmf70u/0Fcv+8Cgi5ozMBo42vBDaAAAA",
+		Length:   218,
+	},
+
 	"data/favicon.ico": {
 		Filename: "data/favicon.ico",
 		Contents: "H4sIAAAAAAAC/6SUT4gSURzHvwsLHfMUdNndY0HQQiEE06lDECzRZS51WJaIPfRnKoJiC4khYpeglu0Q8WgXNjckm4oStUgbwoOICJ6SKbKopKKhUBu14sVXfSQmqTXw4eF78/n+fryfDDCCEfh8XCcwOwpsALAJgA/ABFr7zWcUfzxSSkStmLH6vG5MhspyGIJzAXFs86Qk21fey2F9c+6mTKysGvSP7tnvsgdy6W5OcDWefbOmIhWHqzprni9GnQUz5GY/fNfY/9mdu0rMyEZi+ue378ZO+zU3kPYEa+jxas5/u+wxh+++Sef18MFz8sGJ+RJ/kxeZrEafnuqH8Ozey/o0c2aeVO3Y64Z+/8gFl/7HwitN+WTxwIxNZ2GfnmOOOGxY3P9a+7me/T68cstOrD0WdJ/OC7vTJeyb/vEt2xont/o9ZuTD8YuENVNXg9adQ4EG/cond6zbJ2vtebAHc/eUE5w+5dEh0TOXHbqZZUv0chWsy4zk0g3BOyKFRymDrjV73vubS9T9sb7aU7WZ088ndJnBLN5z97z6wbuky7mq3get3c3/+Oz9X33Om3NXd8f/waAuZ0ynE2b1erfY/g5c3wFc2wiklwDbBIrrWnzZC9SXW8jkb36MA7Vx4FcAAAD//xkGeV9+BAAA",
 		Length:   1150,
 	},
 
+	"data/idempotency.template": {
+		Filename: "data/idempotency.template",
+		Contents: "H4sIAAAAAAACA+VZ/Y7jthH/f5+CUdLYC6yk7OGKJHe2gfYuSYMml8XtJkURBAUljSzuUqRCUvYZCz9QX6NP1iEpyZItObcJkAboAraH5Mzox/kktYsPXn/36u6fN1+QwpR8dbGwP4RTsV4GIILVBSGLAmhmCSQNMxxWb6QIv86grKQBYchb0LJWKehF7Nc9bwmGkrSgSoNZBrXJw8+CZokz8UAKBfkyeHyMvle8Qpq92+/jnG5YKkWEXwFRwJeBLqQyaW2InQ9I3NcuaAnLYMNgWyFTQJDDIloGW5aZYpkBKoPQDa4IE8wwykOdUg7L6+iTX0BDEE6qdZxIabRRtIpKJiKcaYGZHQddAJhWkU4VqwzRKj3VdK/j+59rULvwOrp+Fj13yu51sFrEXuz9dAzBHMsv4tZXi0Rmu0aloBuScqr1MkAyoYr4nzCDnNa8hY+cGes4rSkpE6DCnNcs63iGXI0i+1RQPR4LoDZGCmJ2FbrID4IjMSPXaw7oNc5ppSELSEYNbaYtBD/fTlO1tpH0oZcOCFWMhvCuoiKDbBnklFteN2vRK8m7Rw2gWSujUAtGq1AKvgtWdx4OSrA1NUwKNC3ynRG1IRk69b8X6yL2puy5I0Z/HHmHZd3GD/70xmx93xl3oL3n2qrmPOSQm2Pb1bznxlYd/hzxucRqOROFEZKqukxCZqDEndHx/MeVZHVTVxWY8LYuS6p2uGU0BMUPZ0dQ4poPjTMwxch+FFsXJxvKpSqPItNOYSilNgpOMGqgKi0CgiWokGjpm+9u77AmSBuzzdqJKXpAmKhqE66VrKsTPuR0y03aGHhnOg9aTG1gB6TiNIVCcsy7ZXDbIPL10ACCH9E8jiFMjBjhPmRw60IjCH66otFA1HVSMjTpovP1mu+qwsYw6aiwNcsiZqvTEJ7038TkIra2OOP5wbA3WMTo34Ycq3WHSlhcn+lyuNjyVau7AjCrVLtKtqDAtj2xhowkO1JKHBscEwyjOwx6bb223/+JyJxUPtJVLTSRG1DICKSi2ljm13Sn93ssfjt9RbZoyILUuqaco1KgKIFajZMoqWA5oBQ6B9ucWNvZkjBNEiUfQESLuOog98PdxRQT2AFhMtxZa4N0d4j5r764m2gJTuVpdC84TYATXF0GdkfBym4Pc9pODxjfMwFsjXOKmqj39IbyGtwWvPWC84Xy/TGb1nPYJ1ryN6I/qGwT9zBx2EcvZM5sZtBtm5ycytzV9xU2VDjOw2FWYWR/LBJdvXShczFV+7ErJ7qP61DzbThtwJX6QVtHiaAp/h8KmQGK/3VH3iB1WuVR3Vl5W3S9/FukjuV9fxg53KCKsDkr9rF/EIYOhyZhONZRHdi+DmzOQHI8++DhkrT77UcDMtkDTieBA/cdJlJh5cYK4Yd4pGNVN0JoGQjdjQtbGo67llEnldIUq7ZK4VG8GFt/bQsEZFPLbvdTi698UTtdxpkjMI+PyvKSyCnc738ZeraysY7hu9//iNQbTIj9/ifUnE3wfsk4crxA6hvc0X4/wUrclcRmUoPoy58zgYhQDmvr4yMIl1j2kR7q9CMrQMNiJ4huPEHXo48dswbg0XTEChYfHsTsIW8ZPA/Q/FONxJV6mRtXy/GJow+xWxk0RRc+J0VjGPA2cSYC3mfXaMD/r8PcZft4mP7RUsAZ+H1TgOVewAVoS/joCRsPn8mJ/+v8+fPvlT8jx8oDOWibEzOF6l5j5Hifx2OfzTZPnr+MT5y50AZhmYXPh1k5aNbHeeUb6+gdTNGMUSMVXsbeNiT5gcF24hJ2ThNwRhPGmdlZZYfR01X1TqGo6uvD6OmqNJdbPCyjmltPkSbnn7w9vEPL2ip666lfgcVQoy0S/GXasPRXwKAclFPyF0c8XUGJsYW2pCIF1PLtYTSmanjpPnOo/o1BWRhT6RdxvGamqJMolWWsH97FzY1J+3cDweorZv5WJ+RGyXtIzR8BsDawgegBAzTKGdrzP/8mzz65/jTEr89JSG7tMvk7Lj8R7KDg+GIxfGd4uHTE93RD/WyL+KN5Xgt3wZtfPrYP+Gg+i5p2rX50h2y7lZ9mlxHQtBiTsDKmYPrSvgidz9JaaalmV7NK2qBRKOm6+LxvplE1fVU0y15ZE89n/gw9u3zZZ9xfPUmbghIhnFV4GaH8rJS1hrqaXXU6yRwuyZFavWUGb95ziNwV/HK4+nj8EiOOyTeQG/KKs/QhOl5NqQZy/eJ4OpNpXWJHjLhM3ZtHsjwYxxg1n3XOOdqK/bMv1x5eXowg+ZZlmTuYTWJ5doJlY98Pw/YfTGRy+yQcWycSyQrEvNNwRWb/SjgVDyMCEGEJ2uC+X/ub6Xxyb4O5/dCXFx3V8LXE8M24fyG+iP3/Of4LPKxbP/gYAAA=",
+		Length:   6392,
+	},
+
 	"data/index.template": {
 		Filename: "data/index.template",
-		Contents: "H4sIAAAAAAAC/+w7bY/bNtLf8yumSvrIRldSNmjRp45sINn06XO43jXIyxWHIChocWwxS5MqSdlrLPyD7m/cLzuQlGS9ebPby7U9dLEfLJIzwxly3kjOpp+9+OHizd9ffgu52fDFg9T+ACdiPQ9QBIsHAGmOhNoPgNQww3HxV0kRvmfapInv8IMbNASynCiNZh6UZhX9b1ANcSYuIVe4mgfX1/FbxQuFK3YFh0OyIluWSRGzTAagkM8DnUtlstKA7Q8gaZMXZIPzYMtwV0hlAsikMCjMPNgxavI5xS3LMHKNM2CCGUZ4pDPCcX4eP74FO5nWyVJKo40iRbxhIs60rhkze446RzQ1IZ0pVhjQKhtS+qCTDz+XqPbReXz+JP7SEfugg0WaeLTb0egyc3f8i5woEy9LQTn+QhJejNiQJUctlUH1EUJmX+A8MHhlkg9kS3xvtWawY4LKXSwFl4TCHFalyAyTAiZTuPYgAEly/IIfEQpZlJwYBJMjeEaACZClAoObwg5FaxSoiCXVwlXE5KjA5ERATrZMrMFIuEQsgIDGgihLNZOlMJCjwhbqDiEjAjQi5HIHGyL2oOROWx4UAlFoWTgy1EIlgtopDazZFjWU2nHqZ2GCMoWZ4ft4KG2WE7FGCgBzeDQJH1btn9wMYFQ4jTmKtckhgvOnNdaKMO6QKizf/giSVEVOhEXzSHX7FNro1rwtaGtXIusqUGm7xExkvKTV0uoRUdkKJo28C3gMU7juyOwww2lsXdIRcgqHDoVK9jaBSvwufgU3hR5+swxtCs1adGk0sI5KTWZLFCyJcob2ghgC86MeA3CyRK5n8O7YBXB9raw0EH+nSJHD4dAetCb4wq7q4RCcdbFQ0Dbs+9YwJYZoNHam6zaSm38G4YVfv7BDcUmyy7WSpaAXkks1g/Ah/Qbp6usumKXdk+AjMngpqjkHgoyIAvD+2DicwagMb0V2Synwa/v3aaRoZv1kcvyf08WPCrF6QpHipxHCT3l3Cd43an542lb4zFzBHKjMyg0KE6/RfMvRfj7f/4lOgoyILdHB1A5c2Bh9ZSbBExpMGyJVGNjsnxMFcxC4A2dBk8xcdZbNBpMZhEuiwp66zzpm1xqUhY0CetZdfZerzK674lOmC072sxXhGntLY7meBT6AftBgGXUNiOC1Idkl0qCNcOigGym5YUWfCYCNpFYeJihehb0ZmTCoNGZmBo6h0+QV6kIKzbY4A6PKLusu4RnOfPXsCgf+wcF7aUYoWQ3odexvRaVPpCPJg+HnYVpHGOt/Cec+DIXTdtoxqdWnH+RuAOtE0BvgSnFbyG6gHAI+qM3F50StDClN6lw6XUq6r5ImQbaQcaL1PBBkuyQK/E9EcUVK3iROkFLWQNrElzCBKlrxktEGpgtVEfJhuQVjGSiNkaJK1Xwj6KEZuV5zG8E5J4VGGjirq7otC76/7iZqbRP/hx47AKIYifCqIIIinQdOoatey72SvJmqw5rNIwsiama0iqTg+2DxxrMjyJatXZqXJhbuBlR7gIgc+V8LNE38Ura2I6Fs29sdRhvBj/vpF7Pe+2ZxO9RbW1uUnEccV6a/diVvbWNNTpBtD84dg2rIpUJCM1VulhEzuAkWKTlxPgoW6XLxsiwKNNHrcrMhap8my0WakEWacNbjJSl5d3U6azEikGLrfCDRSqpNTzVtVwDEHRyGTGokKssD2KDJJZ0HL394/SYAJa3WVmODxWhxwkRRmsgG42IAB5C64dYZp9lDy1St2gEUnGSYS05RzYPXFUf+/GpQbcYoj/MQLY0YgT7acL2JRsDSiMZtVCzqcrlhJlikzW6v+b7IrRZD8xXVy5ImbDFU4pMbeKIzTexa3LD1nWarkSaC1J9j3i5Y1PlImp/7i4hGC/PzhqBPP5ydVZkIuMP7PMjRatgMzr96XFw9BXdTMINvHn/+FDZErZlwNjX76th2Kjn76nO7Np5Yxx/bOVb1JIuuXMXif8RSF0/TpDjyPbDPyJClbjvwo11aBd+iM8eO6zVkGVQGamNlsHjG+dAEU85uxPQRNFhUuWHHyS0JXWPgpWsdpxqfd/fZqvAaLOpTwan5Oue/f2PCJqIHiyaFvzuVOtoHix/q098pxrunxpOce6/4YCSo2yN0daPV1ofPogiecQ5RNBJH7Pa38QsiEFaEusuJWn/ahuiP9xWqz2HaBHh1nREtpaKokFZNbRQrmlYmBUWhm3YutziI4uZ4c3jsUwP3YXJnyGli8rGx14aYk4PPFRFZfnK0ZNycGnwl+ek5EcVwLE363FuYgYzNacxKpfunsdSogVu9vmYrwJ8hdrJCZXEBHA71xlBLUQVwfQ0o6OCEN0Kj1v0WESZWMoA70Gh0v0VkR5RgYn0jHWdMdf7wqBubhaSY2APpz1QcDsM4bOiiGU0TQ08AOA5vhPCqcRKksni8Mi5/cKrybSEtRpXQ2ow+2hJe4giEO0we+58ZK0vTWssbWbOqd1vGbuRphJ2GkxNMDJV4eAOQJs6qB+G665KqsDHqlWoNHnNMp9xR+0B375F+PY902vv0F6rvje7t/L/bzn+57dc53KjxN6HnLtbfuX25N//fzvxbicMp+/eJxL31/1Gt//giMmr/x4PXnTxA7wb23gf8dj6gtYMjXuDe8P+oht9cgYza/fG4eBez7z6n3Fv9razej71kQiD9lXxC+zLgVGLQXA7cu4jfr4tomPDq8x/1Iv1L8E5/402E0z+iEEwuNcIuZ1kOOdkiCGnAv2AYpEBAYSGVASNdzVHhH6I2RBtUECjMUBi+D87s6B52jHNYK0JLwvkeVoRzkKuVQ60e/oHoiqZnoFClQBp3mO26ws5rxfFzIOZIT66aasaVlJZld+/hPm9+5T3xvptJHm1o9GXXt3ZeGPqe0F93jz/uKUIZMVIlweJV9Ql/Y7gbe93rvu2NvXJ+Gh5zYwo9S5I1M3m5jDO5SfTlVVLtvPaPP8HiO2b+v1zCSyU/YGZ+Dwxrg1uML3FTxCuWBIt//gOePD7/Onry+PwbV0GCW4Q/46a4I7Md/fO6c4fqy0eTut5yMm0qOB5Nwriu+nvXuO734TRGkuVjGBbH5ExP40zrSZiVSksVnoWFdCUs4TR2YXjSXqZRMm1ShNILu8ST0L9bhMdqoWEBzEepKdzILd5IcBpLMQk3stRYFuFZqxQVp/3iGb1jJsthgrHzTtNeFVX/DjxJ4HtcGbjgLLuM+6MZ0Qjns353U07FZeYqHFxpZrU4xqhJ2GxOTxRXQaaQXB7rNFuc/IVRyhGy07w8GfCyJQoE7n50ZVp34qMu8C1QTBoKZxD+tOREXI4gYFwo3KIwL/zL9eSkbN2iou5eDiuJnOyvUCPHzMCbZ89hSWyeKAXkROfABLx99X3cLmsrFW+XtdX7EBv52igm1i3W2AompeLxhpgsn4QPw2lHZ6xN1Y+7QN5Vr4khfGHniHXBmXFI787fwxcQBu99IdEk1LnctRbp0BbGX7t57nc5CvDUFYIXEmn8YHT+0Ku6pS3ipbYz3aDv1Q420rv55oCxr/Fx7SOD07riqWK6W/HkC53SxP9/wb8CAAD//6geGRVwMAAA",
-		Length:   12400,
+		Contents: "H4sIAAAAAAACA+1c/3LbOJL+30+BZXIjacei4uzMzo4j6SqJM3Nbl8yk4mT3bl2pLYiERMQUwSFBSxqvHuhe457sugGQBEhKtpxNMnvlSjkifjW6G8DX3QDB8e/Ofn7+9r9fvyCRXMbTozH+kJgmi4nHEm96RMg4YjTEB3iUXMZsen3N58T/iS7Zdnt9XT2wOIffn0TIyEueS8hIwu12PNKNNIElk5QEEc1yJideIefDP3mmKObJJYkyNp94QPRdFqfwzNdkux3N6RUPROLDfx7JWDzx8khkMigkwXyPjGzyCfAz8a44W6VQySNQQ7IEulvxUEaTkAExNlSJY8ITLjmNh3lAYzY58R/dgp0gz0czIWQuM5r6S574kFMyJjcxyyPGZEkoDzKeSpJnQZvSh3z04ZeCZZvhiX/y2P9GEfuQe9PxSDe7HQ2XmcPbP4cBkf6sSMKY3ZGEFsOXdAbig9pZdgMhuUlhlCRby9EHekV1rtEZWfEkFCtfJLGgIZmQeZEEkouE9AfkWlchZDSqn8hfGUlFWsRUMiIj+FOMwPASUWREsmWKRcMFS1hGkZTVFjIiBpUimpAIplqyIFKQS8ZSQknOUpoh1UAUiSRQkVlNV5APrXLGSCRWZEmTDcnEKkceMkYo/AELNUNWU5qE2KUkC34FnBa54lT3AuLzjAUy3vhtaWH5JAsWwtOEPOz3Hpj031UPRGa9gR+zZCEjMiQnT8pWc8pj1ci00ukbGtHgMhErqIf96XZ21g2t1WCQuktM39BEZClIg3zqJmV6V7POufAuDa1pMET8YlmOY8qTIC5CM5Z5h24B2PqVgqfkEYH55ihZtQQmECfrmgOydSgYZdsEjL7d9qbegDTaO3q3qTjad2k5bVoU1VjYlNRguBRUnVbLakjs1tW4uBSquopKSeaKZmRGM4UyZxQQelIvYpwlM7Acp+SiziLk+jpDzRL/R0C1CDDGLkT8OUNet1vv2G2lTE6d9d4qhjlBwepgT9d2I9X/Kek912PZcyjOQKuLDGQMn4tYZFDtQfg9C+ffudWQdkOCG2RQUoB+H/rPUYNoNXNlTQ0bKoPUZrUu0Zkopyt8h/iggDqxPSadcr9LgltKzr7Df59M8oqRDtmrsn+29D+oFXij6PPHIQvZJxNdc9Ehty64s9DvqzW4fdIJlWcZXYGRA2eHkbnICKNBRNgVuEvHBEwTQqjSFOESwCqOczDNMgLD3qaGq3xJs0uE2omtlkolr0ypze91ORK4qF+qrlBKgo6BznwLT5gHQ3i0S+73T2wuFP+vKlascadzcEzODBCg7KeVa9FHr1QO3FmC1NZAQpX5ykfML3rrIV3zfPioV3dbVt60Km92Vw5kTdv8L9etWuBbJCjF1ikxmvZh0F7AmPUrMZYNETQRXnWEs9bXmOuDm8HWP8/7S50xeOI2VPDPydjgfsZkkSVPyLZNPkVB1v6Cydd8zeIfRPYXGhesnxRxDD72MXkEI5oVrNkDtkXhUMYLw8V7SPfdjH/8gzwakK8tP8F4QnLt5/SK9QddBTITl+wc/XGg2HvwJ/rH8A+zXkfNOY/j29SDSfMSFsoZzaP+xTfH5Jv3Xf3O2IInr8Gl7ORqKa7YW9FP18cEvGWRdtXB1VjVmQkpxXK3gJ29oES4bmBkcSEd4wB9Tf5g+kRNPia/J0lX04zlUmRNsls7ubVA5ag1n0MRFEtYgDgbXsQMH59t/hz2PXCUwc/3BljwHGMy4M97HHo1aeP2LzfPgNqEJGxFlNPQB8oOfmPwALAMTkWvYeFPHU/DKkzjAsYFrb8ND7aDIFJcQFDDWT8qej1tLKmQ5xBPbE4BEnPWgGSFXJ4OqT7kBEVRCXBYzyVYFhZ6jmad5lKIWPK0yQSsd7ANILFasb1Gjxx0CQF1AICpGNpNHgY3BREh4DhV69Ep1IjV6nn9dM1aTpOqr6XpoISWp5GxuRWVJhFHkq4JONgRAvxNiCXqRSgTNiugC6mCMoizINQLM7rIwWPORJ7vMGVlm25T1rbuYKmeqSaQe1tDhWzAnIC5AZMdoNIp1MvlNguqaqYzIGxeChATllJixc59duWaN7v3V4BXvnJ5+vYi7DB5yIUCdwB5BfZI1xfzOaDjfw1qXrY7uCrSfTwpk2MxNtGK2WV/lLVPwk8igKZOS9pLuu6DFdPPPOlXTB4jB612s6od1DVzyYpcj2uiewjtnB9lmEimE+hph3KqeROLQG15ABXv3wECRFxgcgJT9k2VMtt4X6mAbqK3+GwvNcGf0jPNGG6t5WZyfzXPxHLigVkxgl7Q95DwvpJC5ZbyX8ww2+31nAUiCYG+M3P0owp441jH/hBjWptLlXlqbmXsqdbeudhT2dlU2VOvSG5bs9792FPJ3e9oVzxyNGTvrI1H5T7teCbCjdlsS+gVCWKa5xMPHmfobqmfYcjmtIirDTcyDnlVEzdMKfgg2XAeFzys6ri1DCG9u2LVQQYK8FkSs8WnE16jmRSLRYwbMXFMU8AqT1lvk40s6Pwym2YL3DB+oFt7hGacDtk6pWAPw4mnzJ7JRe4zEVddOazh/iM0KpnJs6FI4o03favZgRZ8odYKqBbq7WmKG89DRf5zVR2PtCqt4RjBeDRGh4eV4PV4amWWY18p16FuDW0KWDOM2Vw2dVfE1jCW5OCnUU9tn5c1ZxnMkCArlrMhl2wJktEd++pQNJu+LtKUyeF5sYQQZwMygyYo/MW8wcuoiF3tOLroECjji6glEcRPy8bUxCyYS8pAtZnMGc2CyCNLJiMBun798/lbj8CEg1lrylrKsDjhSVrIIe4upK16UFMVW3vj1RgiU+XU9sCZpQGLRAwrb+KdG470uQcAxbKLcjcPw5lMOmrXa7gcRJkQ+Ktgw7CYF7MlB52Oq9FexJs0wllMqqdhqZbxiE/bk3jnAO7IHI9QF3uG3klaifEIBtg8dqEd6OGodNrqA66STnSiz73YL8Q/42BXc7SoHg68t92+gZ/SOD4DHzGIjG08JdUJGaz0JZiznfMfPUiKBEYZDTmFKAxWxBvzSP7C2UqvBENlBBxV/OqeLV7VMVy1iJyqlj/qTH81xXiC0efO6V/zWK+AH1+83WEiFMX2XB/rLSUonXi1OwKyVs+w3LGK0yhnMYQ43esBQc+iZJaCTfuoc5Ou7jFv7NSNdTQIvhx4ikoNehA9Us0CzCAP2y6UZpSFRtXTqu14pIk2mWlu4cEQKxL7gf4AHaOwOJXgp0uztwQdpWRFqtSvTtQqUj2gjnL+K+R86/2zJFBOqTdV/uhd5oZub9g2xPYNt/Fvval56B65RiPlIZv54bjOzQmhKnaRvHngHc/KoO8ujJ7qc7A24moOMURmeEBfwtFNoUFbMBMslKGB2qDGVQxB8K9AHeGqhCbHy8FJNlwWoBJvegYxjonEVXy+UJE1BO6/6hDeN45QA7gcIzA2sbIaah0UE3UQP/Eihlb/lJx8+yhdPyHq1P+UfP/o357gFuqCJ8rPOf22Tis3ATLQXmlijo+MfczLTqaurUmnXyWzPH0yHqWVLWn7TODNznJ7adS+EqLuFVMukuMOQwvPjBJGRN70aRy33SIgtLeljpO8qTlUcIZkRkNcy0o666Sy8kMP780Ot4Bh+3RyV8/t082P6N/EZYAa5uRoV6/O0e5HdFiFgrDyysfDqWCY6E1f4vHmLobro9uP4LaMNL3pz+Wh7a7+3MPenX1qj/yoI6DEU3jzFo497383HBKYyGQ47IhhcJrb7aFHRuYQa+ILFeU6sbFTvyFgmur42SYQm1cwhjORgdsM4upkLiGCrlK4GQGuXZWOxBVrRZCyfiOqzstarquMlBc2HsFDR9m5VODcXah9yJ2lBY/lrkJ0RXf2yVjSLoOcrJXTlrHymspzyqYGWi69shj4nksf/SUlMDHw4g1IPxGS+AAMA6BVjlSIXWRoRdXGXvP8tLJe0MxqxZO58MhNjSwmypX6MTTUSrUIrGiW8GRxEI1qFR5IRy3rMop46EaoaI5HeHb8S5igQW7Ng3BalcJAhzsqKA5Lq/9DTNMUmFJRjIUS2kdT/w8rvtWRycTDNiQPRMYwBsLUOSa2WzCtc0PPNe66r1cUDzQSWABsX3d1KKq7gz6clt50WSe7+oEptI++ng81cagOPhHNhVKqbapc4ntUqlf1zioGrME5Uh2qVf4iFdjC7IPhRuCw9rfdGjWrKv+pRD6r1ELsZQ1R47aM7eWpg52Kkx1MtPGnKyZSgNzyjF1rYjybToNicKfTpuyyJPbO8r0x+XzG5ADD0dRc05DcI+XHIOU9mH0KMLs7wDlhVLffbIddB4Fd+3zsNw15h0OTaads+E403HxKSDNu6y7M0i7HZ0CsL7lAKiZsh+qGau9yln3SxdbcvHHynUWnNrvUi/1ojwCYj8mskCQXS4bnrgSrQvlM4OUU6RPyNmIbfRFgkQhcNzNIxgzUmBUxy4+VncNy5wVuCLR5jBtiG5KxAFfRMRF4R4HxzK6JB/yErVOesdx3WO/Ej3IvpBM6qqDoENRwjr/vfaTP7CN1h7RfHF/uPaJ7j+gzeUT1zYBOTKs3ZQ9CtcZrPfe49uVwzRrBDmS7B7N7MPv/A2bq2KcTx/Rm8yEQVr9veI9eXw69ykOCXS5ZhRX3QHYPZP+SQLY3dFaAVofMMhI5I6uIBxHetGdkyXNEmZwBvtCYiLmJcIu8gGSq3wXNigTC5BnFmkUqEnXVXsxhIqgAuQd0YK1ccVHk8Ybot2Ik1DUv79wiLK6OwDuxtz6kOwR/3Ve57zH4gC261zxJ8FjtsyC0fQR7j9L3KP3b3CzVS+LLAXmFkHvAHI/lavSlBn+JuYRnwHxJc8ky4mUsYImMN3jXG3c5VzyO8eW/EIAfUBwvmwPGK3tQXrckNC8xXTGQgmFg4T54d97+rh9bYnbkRFn1VaG5EMiyOhBWj/tvzex4GToQ8XAZDr9x7YXzdmAT3fUrXN2XJfa/I966LbGXFIs5nfGYyw1Sq1N3oMVDtkxBR0mAtP5cp+5AK4/FiuUS6JzrJ/KTfnP2cAlFHIsCKb3RT3fhBnA5R17gl+eSB3dhRG38I5Wn6uEOFCxwBTKvbKht03Ivyex5B/sjJ2ckZZqfjkYLLqNi5gdiOcov1yOz5HN9DcGb/sjlfxQz8joTH1ggfwsMAxZdMf8SZqk/56DP//0f8vjRyXdD+O97dWEbisl/QvGBzDrAo0HjgM9fPaw/5zCoLsc+7Pf88itIF5Uf8r438JnzAYiBdZ32YV9GPB/g98n6vaDIwCD1jnupUDfG8as96FP2bTV1krFJ0TB8jiru9/RLmL3GhwKOD6KWMfwgwl6CAx/a98zd4d6x9S0w1vrURb7iEqxRn/nKLA0aH0tpvkY3GpGXbC7J85gHl36zNICAg5ycNrO7rtVWypEy6/eqwek1P7BACN6Gu6wvrVucvOJhiDcid/PyuMWL+n4GW/1V3Xg+iI/yC2spS/oVhWPS+/sspsllRwPmY4wFcp9p/6+/Uzb3Dr87lt0X9wneGVA3ON4+fWYCPQzzaB7h277v3rz07ZvxRRbb1+LLcfClOIcIKllYrOE1aajuLynMi37vQW/gzBlcU+Ub+YRemFeje+Rr7MMHp4NL1ejiBG8u97z3+kZuv5dDCGopaWsLo89YNferCINURR0clvIiiH/U2X9PT3WknfizHHvaM9/NCFbSq/4mME76sqxK1wwOyqvDhmn36rC+MTwe6Y9A/h8GzOplFVIAAA==",
+		Length:   21013,
 	},
 
 	"data/js/Chart.bundle.min.js": {
@@ -77,28 +107,64 @@ var RESOURCES = map[string]EmbeddedResource{
 		Length:   44371,
 	},
 
+	"data/maintenance.template": {
+		Filename: "data/maintenance.template",
+		Contents: "H4sIAAAAAAACA81Z/Y7buBH/P0/BqkHtDVaS7abXa2IbCJK79tC7ZpFNGxRFUVASZXGXInUkZa/P8AP1NfpkHZKSLMmyL05vF92FZX7MjOaD/A2Hnv/q3fu3H/9+8w3KdM6Wz+bmCzHMVwuPcG/5DKF5RnBiGtDUVDOy/AFTrgnHPCboE+WJ2Kh56KYcWU40RnGGpSJ64ZU69b/2qilG+T3KJEkX3m4X/FWyAtr0Yb8PU7ymseABPDwkCVt4KhNSx6VGZtxDYVs6xzlZeGtKNgUQeQgoQCV424YmOlskBIQR33auEeVUU8x8FWNGFtNg8jPaIFAnViqMhNBKS1wEOeUBjNSK6S0jKiNE14JULGmhkZLxsaQ7Fd79WBK59afBdBa8tMLulLech47t82R0lenzz8M6TPNIJNtKJMdrFDOs1MKDZoQlcl9+QlJcslp9oExoQ2lcCREm0k9ZSZOGpktVCTJvJbJFYxQotRYc6W0BIXIdr8emxWrFCESNMVwokngowRpXw0YFN14PY7kyK+nXjttDWFLsk4cC84QkCy/FzNDaUaO9FKx5VUc142VgqpVR0hecbb3lR6cOcNAV1lRwcC3QnWE1S9K34p+KdB46V7bCEUI8etGhSWP4IZ7OmXXsG+d2pLdCW5SM+Yykuu+7krXCWIuDrx6d3Vg1ZSRhhcSyzCOfapKDZXh4/8NMtLwpi4Jo/7bMcyy3YDI4AsOH0Z4qYcm6zum4YsAeSVfZkUGpkHlvZZohWEqxWQVHOiqCZZx5CCAoE+Dpm/e3HwEThFmz1dyRK1qKUF6U2l9JURZHdEBpp6tto8mDbiJodKoXtocKhmOSCQb7buHdVho5PNQElB+QPKyDH2k+QH3YwXUINUfwaUCjUlGVUU7BpfMm1iu2LTKzhlHT8mu3zEO6PF7CJ+N3YnAeGl+ciXyn2+rMQ4hv1RzCugMSZtPhBAfjNUmx/JRRwAtIQyIhiCq0oTqjHAbyFuvGsppEyFdEIS0Q1QopjTWwSsJHGvg1TSlJIEdpI4euuJAkQdEWQaqS2pclZJprBECHUkxZKUHQBjybGTlSGzmIPMSsBCBEqRS5fQdkKYojyqjeBvOwaCn+Gx6p4rUdqwZ3O5qioDJzv69JNY4MQDs3uY59+pGQsPLgba4LKYkWTQ/8mRCumn4m1p3sMNeyEzydLf8CKxeOD9nROLhWDU18gO02NP4pI3yQnmAlBme6Y9BrKbfbSRO2Y89YIyqvvQGYWJP9/rC5Uji87HaEJ/t9923JErDE2Lrf93g7GYDhiJgDGDx9K22JLV2VDyrRoGpyLN5I/fbHhJs3NA0CqXG/f/GzjMarlrFuOEb/LGNgfH5y0jl+eHoAhw+Y+7wDuq0tFSaEEU16AHyEG20gzWgCK7IGSDjOoDVmJbHQ/t27/f6Yu3N8qUDuBBTa9gMcxd5ZvYbxbQiz2h7orzvr7wbB7D5aNnvVReWwnz9msBctCnAxAD6qvf1r0Q3SzZZvkgRAy9ECxM0apGhnRpt+4CxOfzJoyU5mx9brT0aoDb5W7nE2nLs9cDi9+Cr3Z6jKf76d9SrYsJ1Teb9i/cqkqM9LrW6RmGcvzd5gDZDL3RniXMr5JS10APgoJqaADj0TNyR6EZAHnBeMBLHIn8xOh+ePYqY5mT2ZHbcmIX+eIb+91BCb7HsBm00mX/mTqT+ZoenvXk1eHhl6QvFpX/FvePJ5as8uVRvw5ozSX7+aTJ4sOu8lInAW2T5WgOKMJHBW65k7QTP0Av5nXxycb4V8pOAkpbS1bk/ll9nT7fzqZPY4e98K/yVsOVZGpKmCMtUY1Ch3wbHBM3m3OSycr2AOZ4d2NdM5yJ8YyWRzZZYKAaWhvRxwzfMXPyfytTE2T/yXXe90LgX6BTbU7afqfYkTirWQUPh/qJrob5RsThT85yQdqh0j7NC7XBRNSF6Ag3hsRH136F0uSjGxIUqDmFvXQlVCv9g8wZgojaAPrvUFukDNqUKbojRVmsZfoIatR42QN7ZxuYD2Od5r19hDoroXPGc27f+4KDOtC/UqDFdQwJeROfeE6v4hLNw9lHL3UN7yj1T/qYzQjRR3JNb/DworDeksuIcFGqQU/Pmff6PZZPp7Hx5/QD66NdPozzB9obKdOxMHFt376QP8hnd4jd1orfHzcVpyWx2Mr3b1C56PR0F1gyD/YS90jSn/HF0FBMfZEIfh0RlVV+bSfTyKS6mEHF2PCmEWjQROe7EwbrtpUExbFE6St8bF45ErqUdXr9uE++uLpEmSgwpnBV4FwD/KRalIWYyuG5loTK5QT6zaUFNhjElgb3euurO7/oVZGKLvSarRW0bj+6A/G2NF0PRVfzgRcZkTrgMmYpv50eLgHK3leNQEp2eK+TMXufevnw1o8gOU2Pau6KQusyNd1ua3CLJxlysX6eGK1UAUhI8bCddo9K+IYX4/wEACgKA12P3OZd/xSds6Y/tuLJ81rYqubnR/hXE/vkApbX9O+y/VhQIeXxsAAA==",
+		Length:   7007,
+	},
+
 	"data/node.template": {
 		Filename: "data/node.template",
-		Contents: "H4sIAAAAAAAC/8xZ4W7jNhL+ffsUc2xa29hYSoIW1zqSgW2221tc77pocj0Ui0VBiyOLCUWqJGXHMPRA9xr3ZAdSki3ZcrJbFIfbH2uRw/n4cWY4HDLRn1//eHP3y7vvILO5mL+I3A8IKpcxQUnmLwCiDClzHwCR5VbgfLsN3vzGZFVFYd1RC3O0FJKMaoM2JqVNp1+TRiS4fIBMYxqT7Tb4pxaFxpQ/QlWFKV3xRMmAJ4qARhETkyltk9KC6ycQduElzTEmK47rQmlLIFHSorQxWXNms5jhiic49Y1z4JJbTsXUJFRgfBlcfASdxJhwoZQ1VtMiyLkMEmNaYnYj0GSItgUyieaFBaOTY6R7E97/VqLeTC+Dy6vgSw92b8g8Cmu1j8Pok/l0/ZuMahssSskE/k6IehmBpQuBRmmL+hkguykwJhYfbXhPV7TuJfMXfhCsuWRqHSgpFGUQQ1rKxHIlYTyBbT0EYEU1FIpLayCG920vwHarqVwiBP9QDA1U1Ys/wXYb/FRKy3OsKjjvjkXJqqrt+HDdBRd0gcKD7/tTpWHshBzii2vgEDUcAoFyabNr4C9fQoclNDBBUZpsDPzlJUx2aFV3ukTJlC8h7uo6M81gJLjEUYc2o5bOuuPaWWbN73lX5EYbtGYG73sqjdIMiMFESWbIeV9cT1Ov70CUciFmkFJhsCepPuxbVUeiCuc/c0BaoymUNHyFM7C67EP5xDE7YMy4KQTdzI5GA7hgmo3elUWBFhpvj3rc+vBKCcuLQ0oAuWLO5lwyfBwdzMGlRW0wsYNr77UytUJ9Clwi1WjsE/BugafBfb46pv746hEH3Lw32wBr57ODjs2zMAPmb0j9UIfUkepzyk0w3lrN5XIGo5+pKPvu622Y42jrCav9DuttMfsIMTCVlDlKGyzRfifQfX67ecvGJKFyRQ2ZOMGNOzYe7ZhcMbLfsE1iyjc/cIkQg8Q1+PQ5TuzjebOFJ9fNpDWLbgKMwvaojBaKbZqcKOkKEkGNiYmkqwXVUP9MGaa0FO1ZAhAxvhvpzjXKJeppKkrOdmP6oxogNyvqzhhHoLRWySYT1w1yoGbVcikQEiUELQwy4lNC0+0o1P1tN9VLd65/VmsToJrTKT4WVDJkMfGx1/Q69lqJ3VQ9au6YKKhsyRg9VVJsyPyupiPpii+pyydR6MY9oerqg6mH/18NjcLalB13hIyvDrzD2W7he3/Wxmx9vzNuD73j2qIUYiowtYe2K0XHjS2cpKuDcb7KaUcuNFKW6DJfTLnFnMwjeqL8IfNoMa+z7PS2zHOqN1G4mEchnUeh4AdcwlL0rdOzxcCCNF9mRytKlc4PQtN1EaC+LOiTrKrQINVJRiBHmykWk3c/3t4R0MoFbSM7skWHCJdFaadLrcriaBxA5MWdCmbnQsepjWwChaAJZkow1DG5bRjV1alFnQ8hD3OYLqwcGL3fwq0PrYSFlbus0VA05SLnlsyjnbOXYlNkLohh9zVtzRKFfH4cwyf9d6IzCp0tnvB8r9lpRKGk7edQstunwuyye8vILneCOov7LdYkdPBleUwydME1g6uLi+LxGvwdYAbfXHx+DTnVSy79dpp9tW/7aJx99bmzSw22m6aYfyEXpriOwmLX5+vflnPd8P9PF0oz1MiaprGaF7uWq71Qml3bVw7dfG51z5I2m799HYU2O+x1Be9Q/62ltjRDkm81lUk2KCm5sEOCn5QYngRRDvW/oVwgG5LcZK5OHxTdKUtFXxCFXTO0Rf4ZP4czhFnclPv7Wt6ZrReT2y1PAX+DwJkDgaSeGIGqaj3GHKQmsN0CSgZV9aR+UtPvAnCZKgLD+l5dIgS/0Fy84QKBFLqUDYA/QNuEe9bPuBrdTTbcboO3r6uqQ69vNeZD3gH9ut1ymWg441VF5p1GFFp2oNS7qg8J/WpPSusAGhTXBbzPzT6YXtmaTdNaqpOgLsSeg9yhPQVUx95JcROAJ+U+Cg+lh3HYvUBGod/BbSrbZ7WjZDHQk+ndQ0aqlEXt/Vl/nqjujtPi8Qgxzdn0yydrhKHC4HQBoCnj1CodkvlPzSf8zHH9B1QAfwDbzNrCzMJwyW1WLoJE5aF5eAyLumAxdcFC5t9z+9dyAe+0usfE/n9RNxZXGDxgXgQpD8n8P/+Gq4vLv0yvLi6/gSncOjH8DfPid9E+OHnr+Nq1n3meaTDOxu2LzHjSuemdjUdBc+rp97uM9mE0CZAm2bCO07IZN5MgMWY8SkptlB6dj/yrA+rRJPDH4fjg+WEYqgtHGbtxhh+PXJG4wtH+Fjd0o/4oTI25WuEzsJNAyfEoV6XBshiddx6vcHJ8LzZrbpMMxhisM55kk0P5wEU6DOEHTC3cCJ48BMfyhBqEy9nADby9+AqV+PsTxHtzWavHo53Tjpbl/rlLwsP1i0FGf+eM+drnCU5XA5zctVzi+l/+Yv2JfNpnwgLleIdxDqNfF4LKh0EVDAqNK5T2dV0kj59Y5zNvDx1XVZOuVcKw+w2vGKvNMs2oZAK1f0Rcalpk0+bt8JRuD+cu4wa4AQqCWysQMpo8bE7oPv/GoaQn1XldHePKTrY993YhPYnv0YLNELwNwWbUApcr9YAMShOcVnVurjfMu/bJtveW0uFpXtnvHLqn0/MCT2HcR/niC3ilNd0E3PjfA/EEDvbT9ih4+zSbVb7hkvllev/AGkcrhKWywfPabqEp18Z6ChD3CL2/+HAUVn5RHY3JAElP6m0Ka88CaMPLZighbbn697NgeId52ZHN3e4K6gfj93sGwa/+6fPDwMbwXGusYZpDFmno/1i2NLgBo9zVnMslCP6AQL4m4NMf5EilgTWeAJHobkkK7su8cL9u3fWJo9Ua1txm8PY11EXw1yT4eII2LyAG8pnXJPCypnr9aUu8TbQSwpHS+Alzn41Hmc3FOSwU24wmAZU8pxbH26HBAMbPcqeKmUuWeTEJVJoatONJYFUxpFOdw1cXF0OZrnouyXVy3N4Y1Q6q/7ZZP2lGYf2Hwv8GAAD//7F1Wuo5HAAA",
-		Length:   7225,
+		Contents: "H4sIAAAAAAACA80a7XLbuPF3/RQ4Xu4kNRJl56NNZEkzPie5Znq5eGJfOzcezw1EQiJifh0AWtLo9EB9jT5ZdwFSJEhKdpxOpzOJRQC7i8V+YRfA+Js3H8+vfr14SwIVhdOjMf6QkMaLicNiZ3pEyDhg1McP+FRchWy62bjvfvfj7XY8NB1mMGKKEi+gQjI1cTI1H7xy8qGQx7ckEGw+cQD5FxGm8M1XZLsdzukd95LYhT8OESycODJIhPIyRbDfIcMq+ZhGbOLccbZMAcghAKFYDNMtua+Cic+AGBvoRp/wmCtOw4H0aMgmJ+7xA9jxpBzOkkRJJWjqRjx2oadgTK1DJgPGVEFIeoKnikjhNSl9lsPPv2dMrAcn7skz94Um9lk60/HQoD2Mhs3Ml+Ofg0KUO8tiP2SPJGGW4So6g+WD2Jm4h5Bap6AlxVZq+JneUdMLMtNAZMljP1m6SRwm1CcTMs9iT/EkJt0e2RgQQu6oIGnCYyUB4rroJWSzEWCcjLg/Jz6TwODRn6DP/ZTFikdsuyX9KiyLfYDImzenVeIhnbFQEy/754kgXRzkZHJ8Cn/HOQ9uyOKFCqDr6VNS4ZLkZNw0k0EXRk9Ib0dtW50ODHXOFzBdBRfFNCIdsEbWqbDtU0VHVbhillH+268OITQ4HAxeWyg50og4ksHkvnT69rCZxqyvNjTnISDOaSiZNbLdbPicuD/AhMg0Sl1ut32yZ+KI+ZzGh+Z1I5p2C/2j+sHRVCZiVOlulg+azHZ7Sra9BzJKyCwRPhNvqAxAMC/75OVNDUAz8In6PAPRHdeXqQ3npuzcVgCSFLmVNRUJJlPo5XegUiUymx8dJkc1MflcpiFdjxrQAA+uM+pcZGnKFMltu2OxaJNPklDxtM4SIRE4CVgYOBxbdWpzwPIZhGpPtWraagXJHRP7iMeMwtrVAfK4wP3EdXRusr46W7EWoy7F1qr2bV3N63vJtIg/Z+onY8cN1PuQcw+4VILHC5DQP2iY2eqzwkPB+VH74LaMJ8XXcFh+kTeCLgkl6Co6gDHqBYTdwa7YJxRiccAMO4QrFBnEvCVXAcTvJjUMVBEVt6C4PVH3Qz66rTC42Tk8eK2WGQw7fWPEuvMKvrDP0vzB6Kz5/7BjpaICOlfo1ybo4dpHuw2ki8mH6tkKQ2orIKHHXGNs153VgK64HBx3ymkL4HUDeL0f2FMl7fyvWjWgJGMxrmJrjeSSdkFpb0FnZRyMenWby/ekfCKMoG6+8WjX/jjvRqajd1rzwjnp6n3smJTR9bRue3qzxYWs3AVTF3zFwneJ0GbbjbMwhFSqDzFSW3t9BsTFxeEar3MubqDdtTv++IMc98hTclJDB3G5kt6xbq9tQInkll1i2gUUO9++on/xn886LZC4ETwEDozmJ3AU3Be61y/65MVN27wztuDxBVVBK1cRRMOrpJuu+gSSoiRtg0Fv3MHMEqWSaP8CW2fBFaHfgGbRkfqooKfkeT4nSvIZ+TOJ21AxHCeiTnZbbZZB5fToqGHPfuJlETggWsPbkOHnD+v3ftfxaAzpnNPDgXNMvYE/55nvlKRNnuOmYQYi1FGk6sqlA+VJYLRGdQBYzJZEp6pdYKGfk+kVzBl2q8nmeFiUJeNZ4q/z/DOmd8QLqZQTBz5naJr6Z+CzOc3CIm8HSJ/vILGGoMCFGMzDjPs7GBsqJ4SzMlGBQQYy0G+cZ72m4dTQVLJYgHF6SRjSVDLf0WlQ3o0smP6im4oF1lDfGmyHUMHpgK1SCt7uTxy98+W9yL1Iwt1UFmuYkgNSwYwUA8i61870yrADGHxBMeaAaAHuACrWYgNN/n8FOh4aUVbUMQR91LTD/d3CS30aYRa63wnXol5RbQohbhCyuarLLgsraizIwU8NTleUBeRMgIV4IotmA65YBCuje0pNGJpNTY43uMwi2A7WsGaQBIX/Ia/xMsxCWzqWLFoWJPgiaKwI9pqoZprYBbaktx6bSeBRQnLnBQ6B4jtIQNQXHy+voBpO0GjzsYYsKozwOM3UYCGSLG3AAaQerlSLOxUiT4VlOwRSLY8FSQiON3Euc47MSQDkAlEb5XYeBjMVt0CXLlzoUMUE/u+iRs6izGYRB5GOd8pehOs0QCMmu69BIZbxkE+bNrxXf3s6x0OUxQHNW81KYzwE/eafbcGuDIXBSXmiYwq8d+AvKeSuUE5b7mrySP13sKQiBhDH1DYTB3EgbYZ9B4s3bF1iY7v9zpnOc3q5k+eZn5kL63j3zLs9NBeP54kzpd5tnCxD5i+Yv5fSB4qFR0xjjx2iWGrWcA8sN9GdaVQ27Rlh+znZCTDd7+NYuGEuMNxJ2JleKqoYpnKQUUv09s0GM3KiU7niYOO4WNUn8DVYyR8EP0hloif2TOiTOIuBd6a7TzNDRU4/QC7vBZqm+TxAdaYBkG6BpSkXDYt2Lpi0kEtdvy1bL+TXQhH9N1fyUWuo0vGAx7rGqQS3fVEriyu2UgtelitVA1DAfZ/FRWCBPAASIkiAS9uARbjv36AEHkZiDvquEin0vzd1yOPLniikv1fSmZ6HEGHadkc7UlwFXELBx8MMXHJJJan6j60b9xfJBBjEbE2qizW9rd7qvgN3ZiKFClfhCRANk3ihC0ss3cSaxACoZ+dF/7zEsGaxKLVP9olRmYDsRhZi0duO83aVckiEz4CoS8h7RZjpwJp4s/HRBVuBc2ruUUt4hTFIvLCoYr/n2MaZHVwpZHWlke+x3r0m+xCDrazQElppApKFzFNtuygx26CMCuuEUJ3CVElsAhFspWAvWItGLhqu63OJ5714QNvVQ9qQyQTqq4omO71TUkEsB/bhf9PAtx3CHK4VXoNWBNkqWjJ+jodm+ABGhbYzfds0xZDfMs0x6Ra2ZMmy15wDIr8Wa6XngNNX5q8HEGsihxQSqirYOhL5quDysOSqYRZCO1Ut5zKe1ieJgP3Su2Xq6+fJILbUZvk1yYQe/Xrqxtdr9N8WAQBPkPqEuQuXPLPKvS+NxjDh9Kx03HpMtiOyrdyxKaV1/ZJX1UTfL4GiGW5uI/Ls+DhdnRJ9mTUir4+/O8UjIyirda0yelm29W4IHZh0GmL2Hlw7sC/zlo/gIPqEMKVSWYfuBpII+NEA5iwf25jS6P1ks9GmPCfOd+6zuUOaJ/Ykv3qA/SH2NZnXL2EnSJnwGNAJ2QEaF69flgRcK62oytEs8Ao0dgV8VRYXPJ/mB+e4rYHewbo8pnULydvznY70rVahZNPQfwfmBgECmGlKJXi6ayFXLMZCvmI+SliBSQXTKz0bfNT6z0DudMHIpVleG8hlQEUNF1qiGizyM9nm4lt48TFtQ0h9a+s3B2t6yDm7H/oEoS+MRmFJkO/bGHWmLScYamnu8ZB0+n08k+lpRfdfq628re8zDmvu/Zs2pfysN6EWZUEWkLWq0WTKrSMZD1XbAKbtrZMwFrf1v9O5R9vIud7aW4euEkXDh5jXE94nTxgZTfLKxDYyq1bVvoiZkZ0UQR1RaMxHkhD3NxsCqrZuEVrwTWZiEdBFAmnH1+hQILi/0ih8h9HFSSFe5QT0wdq+2onhawKscnR+X5KvGb6O1kjoN5gr9gQIxxREu0a7t5TPJdoG9WqL/PUyTJYHC1cjwakEuHpJ2kq9LNUaw5XiVxsjpr+mvNOtRbKXaFFZHiK5o3aIkLHdvcO5Ae8d11ZcH31wxKmeljSCTUtPIHaPUeZJAkmEtgfzuefUuHnc0oQIB5E/eHHw7LHtwHH/oYOgsP2qRAyd6af8k/yDs2X7yeJ9xGA7pjNIntUa6ZWtR1HjPotSkFfsIbX3ZetR1NAJmFRA6dJ8ER2iHrfOJAyTDGl9Ml+P4wh8WQ7NGQ+XkCw/jhl9IoJ0zvTHo2hUzq6A0IfqSdZXHzD/F4w2UCqVo+FwwVWQzVwviYbydjVMzXm4NOfhzvRHrv6WzciFSD5DCfb/xbpU7I65t2DDUPmCjP/9L8jZT/46gD+vyYBc4jD5Oww/iu3awa4JM7v2PS+tchpPKo9rKlfKT7odN0+exPVuY7zp9FxmXUT3rGvoJ7qO7+GDuG7Hy4RMRKff0e9omABcnVV1aw902klVyVHfP0fBdzt4NHLHOvUrzW3/i2kKhpe095DtuUCjEyVQimZpp195h8Z6zUcfcsmVF8CYuwy4F/Tq4y2vRIZD8hNUauQ8hKLZbY57UOuQk1HL85Li/jVMPH09RyaluJQS3c5OaY1l6cdPUMXflhe7FkcfuO/rFPoAT89aeNL3/Gz5T31v+4X8FC/+UhZ3dzT6pPPbLKTxbSsKcyGS4c3xG1Nvdw+s856HNRVVbXtVqZQPX7RkznzfiGUAWQfISOjnNAtB02CQP1fbh2vR0cdV8A/f5CjIhkhAvdv1Htz7r9qTWDNVeSjZZXcKbP/IUqzdIj8y8/RHyxC+qCI8vktuoQbKpLsfFdVsHOaieH1pXdVX+JRn6i1S1+xYWtCvTiwq339PzoSga5dL/Vsb7pGaP20axmuzma/yHc/PFbR+yJJ1IOIuEuXej40LnXMhlWYBllll6Pr4pmFWelEVjF4Lk5qp93NgBLkACzB8AYcxnn/75Wsst93DTKpfl3nlwc91yYH7m74xumlxDM2rodXOZptEcvY/ZgUbYMMywbPo3cGp88ohOvyRiNFYwjr3EIkZFtsJ+ZxFKf7ius2OIyB66PuA92+IqaVeOe7DGVRAb0KcbzWmQ54aVk+/bImXHmZ7yJRgXzA3bJv4PL5P8MUJbHg05hGUbt1NGzC+IcRZrpJ0hMEySsGV53PJIJThC542nG2fvDw+bot02/uCXCXGlcIon/zYT2fMi5nx0Lz5/w+viJjxBDAAAA==",
+		Length:   12292,
 	},
 
 	"data/radiator.template": {
 		Filename: "data/radiator.template",
-		Contents: "H4sIAAAAAAAC/6RY3Y7bthK+36fgURLYBmzJPydB1msbONgc4FyctkGTtiiKIqDJkcVdilRIyt6toafobZ+uT1Lon5Ilx7uLIFhqhvxm5pshOfTqXx9+uP3868f/osCEfHO1Sv8gjsVu7YBwNlcIrQLANB0gtDLMcNj8iCnDRir0M4PDysuF+YQQDEYkwEqDWTux8SfvHVslcAhrZ8/gEEllHESkMCDM2jkwaoI1hT0jMMk+xogJZhjmE00wh/XMnZZQnIl7FCjw187x6P6keKTAZw9J4vl4z4gULiPSQQr42tGBVIbEBqVyB3kFhCaKRQZpRZoYKEm8O+3dfY1BPU5m7mzu/tsNmXDvtLNZefkyO6LAmGgCX2O2XzsKfAU6sMJaTG9QrPi65aYqGPTKiLR5LDnMMjHOh1tJH4shZftipCMsimFU/OV4C+Uag7ccyrEFYHwpTTlOc1qOVSUsBxQd8xFCIVY7JpZoelNKIkwpEztbtJWKgrIlvhRmcgC2C8wSMRGAYqapzCLu07E/YIlm0+mbptzHIeOPp4v2oAwjmE8wZzuxRFusgTMBuT6puazjStWToHBwPo0eGnMzDuvJeXwTIjnHkYYl0hBhhQ20GJjoCJOcmyZcF7MGHkzpMAffdHMnpAoxP3WuAqySVyO36QgZpfwcGRbp72dva9IbJM26c+H8D/geUnvoe4jBGaP/KIb5GFXyMdJY6IkGxfyaMEzud0rGgqa0SrVEr6bT6amPrUxUxZemrC5JqZlhUiwR3mrJYysxlfd2MWXnSy7rs+gSGQvzhUgeh8LaDkxMitVzCNuAc/cthH2IRi051mZCAsZpoxKK4tlKY2SYprxVuT3r3WzXn8CktbRE0+ghYxRpyRnthHN9zDhQ5EagCAhjIZ0mh8xn7WJPD7FYL9EULaKH7P/0vJ2TU8pWZnzXLlh2LwDtIWGWkrBYLBDFOoAeFkiAxe5SGqbvrp9NQ2Wok4dK20PE9N31JbAvYSIWT+PievFsLixTnWxY+j4+rheXQb+EEamiAItLCcEYP5uQ2lInH7W6hw6M8UXAL2HjszS49OzEAd8/e7jP3qf/zuBeRnHDyhMpLsx08lvoesj1ff/bkC9mtoMBynTE8eOZO6FY2nGdGBnl1rMboD+Ixtrq2CXkXD/ScfMq4Niw/UlLVN5qlieLxeJprZvtKmV7qx3oN29f/L1gp4xbzcgl7UVGcj2zuHvPdx9yD8rn8rBEAaMURA2msCgtZR0Fmulvu54+B9r9etkEuLNWP9JAaJXti8nM8Z7vT2v32Q2ySu2ftFtWB1btFCayrnXLJbnvLuGt5PSm5V+Wx8nUnc2bDq686mW28sqH8Crr6gjHWq+d8in3JX3zKck5qPJRlwdXzMs+Ck2qUyhDXjtZmEXZHQJm4Kaalc6jaUmmnK6dubP5GEcRGPQpDkOsHtEqI5vRtaMNNrF2Nn//9efKS6WblWdoZc4zqhwfjyq9HpH7yWADGiWJ5VPh7PGYa5PEQRQbPCkf268bz9hX1ryWzzmO3UQ7m1XUkDubzP3N8ejept9JUnkeNbzPIOsPhFaU7e1vhGrorAidphblRLWFKRel/+353umC1K0eo8VedMqcFgV6PLofcw3eQZK8sSIuzFoR2/F5jQD7MwmCJmWhZgXW/HXDPEZFgXl3eI9zacnN66EfC5Ju+OGo2nOvhwO33JO/VZn/fTByAZOga0W6xgRMj1yi9XBAYqWlGowHkWTCgBqM3CA98IY2c50wNhSm9DZldjjAJD2IBqMbe2IyfhKaglDu4SzgyJViOAhlrCGOBuMKEw1hhFqw+sAMCdAQ3EPASDBqao/tIvM89H/wDbrljNy7bS3BGtBs2RZTSeIQhHG5JDhzZF2TY4waDqrktELJrl4F+P7mqsOT77KfAhDp92V+4sseKyTg8AsTVB6e5MchW+LKCMSwQhijwZctx+K+YwG4kYI9CPMBfBxzM+yNrSFLmrm8qkbFvHJQ/36XnefpMb65Wnn5T5//BAAA//8PtA+rCxUAAA==",
-		Length:   5387,
+		Contents: "H4sIAAAAAAACA6VY727bNhD/nqfg1BR2AEuynbVoEtvAlhbYh60r1m7DMAwFLVEWE0pUScpOZvgp9nVPtyfZUX8pWXKdGEESisf73d3vTtSRs2/e/nz76Y8P71CoIrY4m+l/iOF4NbdIbC3OEJqFBPt6AENFFSOL7ZYGyHmPI7LbbbfFANlouyWxv9v9gn2KFRfoN0o2MzfXyfUjojDyQiwkUXMrVYH9xjJFMUDNrTXoJVwoC3k8ViSGpRvqq3DukzX1iJ09jBCNqaKY2dLDjMwnzriEYjS+R6EgwdwC934VLIExfdjt3AADAI8d+GMhQdjckiEY8lKF9LyF3AJCeoImCknhNTEQgNxJ9+5LSsSjPXEmU+dbJ6KxcyetxczN1cyIQqUSm3xJ6XpuAYIgMjTCuhzfoFSwedNNePoeS+DUFQWVbhmaVI8lmVnGRvlwyf3HYujTdTGSCY6LYVL8Z3hJSh2Fl4yUYwNABZyrcqxzX45FNVkOfLTNRwhFWKxofI3GN+VMgn2fxitzasmFT4Q5EwAT9obQVaiuIaEhEVQ1hVnEfTL6N4gm4/HL5nyAI8oe95XWRCgK1WJjRlfg7BJYhmIhuXxXc1nHpcV2WDg4HScPjbUZh/XiPD7b44zhRIJrkiRYYEVaDNiQGi/npgnXxawiD6p0mJFAdXMXcxFhtu9cBVglr0Zu0xFR32eHyDBIfzN5VZPeIGnSnQvrB8LWRNtD70lKrBH6TsDbO0LV/AhJHEtbQsqCmjDs3a8ET2Nf08qheF6Mx+N9H1uZqIpPp6wuSS5hy+AQKl5KzlIjMZX3ZjFlG00+12fR8cA39Rl8S6PYeB1obBfaUxK1AafOKxL1ISpxzbBUthdS5jcqoSieJVeKRzrlrcrt0Xeyt34PRtcSFGDykDGKgA/qd8I5AaaMAE5ChAfbloG0nxxvOmkXu97EUgmm0CUY07/jw3b2dilTmPFdu2DYPQK0h4SJJuHy8hL5WIakhwWINeYbwFodzcXrq2dz0bTWyUhzSR8vr6+ONnAKO/BRj48mZnwCMZWhTk4qaQ8d4z46mrCnMJHGT+Pi6vLZXBimOtkw5H18XF0eB30KIwy+gMeREVyNn01GbqWTh1zUQwHY/CrgKdFzkQCTx5YDxvjZDNSWOlmoxT1MgO2jgE9h4xNXuPRsPxXBwQ//5I3+OYB7ZJGZVp5IcWGmk99C1ldmQfB1yJOZ7WDApzJh+PFAv1CodrQaiie59aw76A+ioVt9ejzvUK/a0ZXBiQwrut5rl8uOx/AE2HhaW2+6Cmcko1XsN282hb1g+4wbjeoxrWdGcr2y6MsOd6YcYg4Y31yjEJp2EtdgAnrowlLWbaKJ/Lrr+qjYPsuVDaIzafWqDYRW2Z5MZo73fH9ab595eBLa/l4rbnTn1ZtC4+xEs2Tcu+8u4SVn/k3LvyyPNvg3bTo4c6tT+8wtL1NmWcfvQacu51Z5zP+sLwYEnB6JKA/8eXDFuuyhkGiZQBkyCHSYRdltQgrFVa3S63xdkprTuTW1uq9vtlvC9JXDhzRJiEIf0wiieixudNAsSwj155ZUWKXSWvz37z8QF8wuZq7yK5dcJcrxdit0A4Gcj6BCJNrtDL+LgMB8Jt3tLNjbFLbLW5vz5n3IeXkh8sLQaEWYI5rHMWsxSxrzMJG5DCC3+nm3q2JIGnFkkPUDPMKWYT7DTAWdlazVlKKcsvakZqX0v73e3VfQbvUYLd5cq6yAopwB/kMuwSuw8dKIuDBrRGzG5zYC7M9pVg9FWWfl2LwwU49JUY7uHV7jfLbk5nwYQFupt4fhRfWGng8HTvkG/1nVwF+DC4dgL+zS0DoqpPLC8aQcDrxUSC4Go0HCaayIAM1Qb49Dk7lOGBMK+/6tZnY4wJ7etgYXN+bC3ehJaIJE4MJBwAsH9AcRTyVJk8GowkRDcoFasHJDlReCxIGX2wsvmtJtu8hcF/0IeyW6ZdS7d9pSD94kNLluT/vcSyOoGwc2PZw5Mq/JUUoMB1VyWqFkH2pBMGyVHZ78lF0qQdn2+jLd82WNBYrJ5nca+3zzJD82mYrDExIPK4QRGnxeMhzfdygQB3aZNcT9lgQ4ZWrYG1tjbtfM5Vk1KtaVg/pKONv99aa/OIOvQHbZ/j/V3j/ofRcAAA==",
+		Length:   6013,
+	},
+
+	"data/reliability.template": {
+		Filename: "data/reliability.template",
+		Contents: "H4sIAAAAAAACA+1Y627bNhT+n6fg1GJ2gEhqigLbWtvA1q7bsKYNkmzDMAwDJR1bTChSIyk7geEH2mvsyXYoSrIky27SDVt/LEAsXs759PHcSGryyat3L69+Pv+apCbjs6OJfRBOxWLqgfBmR4RMUqCJbWDTMMNhdgGc0YhxZu4moRty0xkYSuKUKg1m6hVm7n/uVVOciRuSKphPvfU6+EHxHNvsdrMJ53TJYikC/PGIAj71dCqViQtD7LhHwja6oBlMvSWDVY5CHkEJAwLftmKJSacJIBj4ZeeEMMEMo9zXMeUwPQ2evIcNQTqx1mEkpdFG0TzImAhwpCZm7jjoFMDUQDpWLDdEq3gX6VqH178XoO780+D0afCsBLvW3mwSOrX7YXTJ9PUnYe2eSSSTuwpS0CWJOdV66mEzooq4h5/AnBa8po+SCWskrSkpE6D8OS9Y0sh0pSog+1ZQLRlLoDBGCmLucnSR63g9NSMXCw7oNc5priHxSEINrYYtBTdeD1O1sJH0yGl7hCpGfbjNqUggmXpzyq1sOWrZK8mbV3WoWSujUk1GK18KfufNrhwd1GALapgUaFqUO6BqQ9Iv4f8t0UnoTNlyR4j+6HmHJc3Ct/50xqx93xi3g95ybV5w7nOYm77tCt5yYw2Hj55cmVi1ZKQwQmJVZJHPDGS4Mjqc/zgTzc6LPAfjXxZZRhVWlQgNQfGfsx6VsOBd43RMMbAexRbpzoLmUmW9yLRDGEqxjYIdjhqoilOPYAlKJVr6/N3lFdYEaWO2mtsxRYsIE3lh/IWSRb4jh5LldJU2Bm5N40HLqQ5sj+ScxpBKjnk39S4rRq4eGkDyA8jDHPzIiAHpbQbXLjSC4H9TNCqKuogyhiadNL5e8Ls8tTFMmpZfm2USstluCO/1357BSWhtccDznW6rMwnRv1VzqNZtK2F62t3YsF9P5bNPRaTzF5Mwnx0d7UsKLFeRblfNbTLYsFpCmQOdeocaXpUVj2w0ofqFfewGP4Id1BYysdpv7aOv7ZJmoOIjgF9toG3en/g+KWkQ3x8oM45oGwMrFpA5bgi445J6rW1foZCt+o0GdspfP5IKwxmSqov7HMubHlJLQOimn8ol7NR1o3bCx6SlFfFskg7NVTYannxNGS/U/vkzwKptWIYLkHgoiC2l98tGYFYAAm10GP2NFAvQhsSFUuiUWp6gXYDe7GrhSG/567XCwxuQoHTgZtM3FgqwOQkuS8DNpvZJYpVw61ivQSSbzS63ZOYU32K92WywPNYNwC14s/ErRSSUDCsHpdkPStS2PySUFKrcqElwdnV1cV/Jr14fkmwbBGk0tlGF0CekjeSmLsFGpr7P6occVIp2KlkZ3ju1rJuQpfmGE9Ll/mBC/sdpaEl/nJn28u9nWBXQH5ZhrpLXB6LHndOGXQjeUCBs0szbl1Pl7P8pdd+UGjgebJudXX7PSKqa6+gc72WgygR0zcOXqj3XKTyU+1niP+smauds0U81dxIYPEsrmjBqpMJD9UXVJD/iXXnPYfoQ0vYoZMHaB6OHQrEEshwNJGIL9d2293AozeUK90eEuXQtMnjgucfy8C4kCwt04VofwMVQoy0TfDJtWPwBNCgHVYJ8WTYeDpBhbKEtqYgBUc62vSGo7uVp6Bb5zwRlakyun4fhgpm0iIJYZqG+uQ1zd8fT7o7nzb5h5tsiIudKXkNsPgbC2sASghsM0GDO0J5//kGePjn9zMefL4hPLu00+R6nH0i2U3Bcseh++9ne/cJruqRutGb8eDwvRHkvHR+v6xc8Ho+CagdXvzR7ya+j4wBonA5pWB2TMn1sP2iNR3jE1FKNTka5tEGjULPc2MdtMw3CtKFokry0Jh6P3LF/dPyiLbg5eRCaggwpHAQ8DlB/lMlCQ5GPThpMMoZj0oPVK2biFGeCFd5J0+Pu7Lp/GQ1D8gbmhrzkLL4J+rMx1UBOn/eHExkXGR4kAi5jt6NNt8YxRo1HjXN6S7F/9iPJzYujASZnLEnKs9peLk93uCztdz5Y/cREIlcP4rEqVQKZgxg3CCdk9FvEqbgZUIAAS9AS1/3KfRsY711bZ2zT9eVR06rk6kb3C6f7sIk38vIT9V8Py1qNsxYAAA==",
+		Length:   5811,
 	},
 
 	"data/report.template": {
 		Filename: "data/report.template",
-		Contents: "H4sIAAAAAAAC/+xZbW8jtxH+nl/BMtdKBm6XVpyijU0tUKS9FrhrbJydAv1ILUda2lySIWd1Ehb6Qf0b/WUF903vPicXFE0vMGDxZfjw2ZmHs9SI/+bPt98+/PPuL6TAUmdf8PhBtDCLKQVDsy8I4QUIGRuEcFSoIburnAMk78FZj6SuSdo20zc/SEM2G85au3ZNCShIXggfAKe0wnnyR9pNaWWeSOFhPqV1nX7vtfMwV6vNhs3FUuXWpCq3lHjQUxoK6zGvkMRxStguuhElTOlSwYdIg5LcGgSDU/pBSSymEpYqh6TpvCbKKFRCJyEXGqaT9PIjbMhmw/IQ2MxaDOiFS0tl0jyEnhiuNYQCAHugkHvlkASfHyM9Bvb4QwV+nUzSyVfp1w3YY6AZZ+2yl2Hskzlcz1kfMz6zct1BGrEkuRYhTKkRy5nwpP1IJMxFpXv6hHCpBsvoSqEM+GSuKyUHm32rDijuCn7HJhKoEK0huHYwpW2HHixDu1hoILnVWrgAkhIpUHTDkUI73g8Lv4hK+rJdTYnwSiSwcsJIkFM6FzraNqORvbd62GqPWvSyE6YnE3xijV7T7KGlY8RSLQQqaziLds8sjZJMGvj/lilnrSt3wsGkWh5ER8nhwbfxbJ3Zx35w7h76TmhdpXWiYY6Hvqv0Thh7OCOWB3bNweotZx6EzH1VzhKFUNKMi9Pnn2Z81uWZ5L4qS+HXnM0yzkTGmVYHVFil952z54oTz+PVojh6oLn15YEy4xAlIo8qOOIYQPi8oKQELKyc0rvb+wdKvI2a7eaOXLFDRBlXYbLwtnJHdoTwZro7NggrHCIYOfXCpsRpkUNhtQQ/pfcdozYfIvjyFPJpDskMzQnr7QnuQ4iGzNAMSaOjGKpZqZBmfIj1Qq9dETVMhlbSu4UzlR1L+Gz8zgxyFn3xTOT3ujsdzozom6dy3TYTFpPsdgk+vlo4KyY/PUN6++HsEcutTkKZTGIGTEqZTOiL1dwv3a6dHMraZd2L2s4JrCCvopiJWAhlwqm392si9ob/hGSzuebMHeCimMWk3fJoO83/ZGa9BA+y6wb0yg293BoJJgz9wi7BH58T9BlHmX1bCLMASThD2Qzs8OrnmvuGzDhDfw7m/kk5dwamn3sBzBuh9BmUbuoFIA8WhT6J0c6cheCs8dhRdB8KFYivDEFrn0hdo69MLhAG3PeVQVUC2WxIgOj/QNCS3JZOA0LaRPbHnaHhdFyR5vYzpW3Mk5lFtOU1mbgVCVYrSb6UV/HvhjS3r+vJ5eVvafbOLgJnxdUv8DjVtY+yG5z7zi7+DiGIBYTN5iA0Hnr3OCGlMotr8nu3It+41Q3NuOsnS+EXylyTyxua1XUaBeAyzpyHw61BBzjaJfvOYqHMgviGEcj06KzWNRi5t/DlQa5rNd9KCYKtfA5h0PunSqEF+oWKId6BuqfWKmDStK+NNXCU0Q51c+DJg6g2l6Yohoe1g83mmtR1+p0o4cis4XDyra1VxkMptM54biVEsDdKR7C6Tt8pA1FnzQxnnd3Rxaq7Wp2aOJbUR+9h597GW7BnJbfN95+quQ7pMxZd54FfVfdR1XXXg08XXQf0GYvu0JWft+ieVd3t258hzX1v8h+X6PptpApOi3UT3pv/RyXevv1VhEPm2/1e7LLfmVlwN8MN8sRI4Yfi69xaBN+UmdrmGbEcf7E+FfdSJl8/W2Q6VVk6W0HyQiqB1jOave+a5B/N1/hPLiH9DGQLRBeuGVsoLKpZmtuShacVc23FK7QVL5r9VeHfqhm58/YRcvzfoh4QlpA+QenSuWI0+/e/yFeXkz8kX11OviEJuY/T5C2U7ifR3qvWtOLar4xvK2PsUSxFO9ozfzWeV6ap2o0v6h7z1XhUXI0u0pkycjzKtcqfRq9Jb0jGsASDF6TekmpG0oDW3XnrRFsNHl/cbC1ejbFQ4SI1sMLxSKrl6CJt69Y7Zptt89WYFlf0Im3KHjskd3ftMfMQxqO88sH60euRs8og+NEO7Jb8SwBEhXZ0glTf2P8Bof3dgLP2Z6H/BAAA//+/3drGJxoAAA==",
-		Length:   6695,
+		Contents: "H4sIAAAAAAACA+1a627bNhT+36fg1HR2gEiKmw5bE1lA0bXb0EuCxB2wn5REW0wokiUpJ4aRB9pr7Ml2qKttyWmaBMN6QWCbl8OPh+d8PLwl+OHX45eTv05eodRkLHwU2B/EMJ+NHcKd8BFCQUpwYhOQNNQwEp7kUhKDTokUyqDlEnll0nv9MeHo+jrwS7myTUYMRnGKlSZm7ORm6v7iVFWM8guUKjIdO8ul90ExCWl6dX3tT/GcxoJ78OUgRdjY0Sn0EOcG2XIH+avoHGdk7MwpubRqOAgkDOHQ2yVNTDpOCIARt8jsIcqpoZi5OsaMjEfe/ie0gQH5sdZ+JITRRmHpZZR7UFIrZhaM6JQQUwPpWFFpkFZxF+lc++cfc6IW7sgbPfWeFWDn2gkDv2x2O4x1ZTbbB37tsyASyaKC5HiOYoa1HjuQjLBC5Y+bkCnOWa0+SCa0kbSmxJQT5U5ZTpNGZl2qArK9ErUiYxXIjREcmYUEF5UZZ6OZEbMZI+A1xrDUJHFQgg2uiq0KZXldjNXMMulx2dpBWFHskiuJeUKSsTPFzMoWpVZ7JVjT1Zpq1srQqFZGK1dwtnDCSakOtKAzbKjgYFqQu6GppaRbwP9XooFfmnLFHT74Y8M7NGkG3vqzNGbt+8a4a+grrpU5Yy4jU7Npu5ytuLGGg58NuWJi1ZKRAobEKs8ilxqSwchw//yHmqiKM+5ZnmVYLWDIYAgMH0Y3VPFztm6cNVP0jEfRWdoZ0FSobIOZtgioFFsWdHTUBKs4dRCEoFSApU+OzyYQE4TlbFXXMcWKIpTL3LgzJXLZkQPJorqaNoZcmcaDVqea2A6SDMckFQzm3dg5qzQq46EhoHwPcr8ObmR4j3Q7g2sXGo7g0wSNSkWdRxkFkwaNr2dsIVPLYdSk3NosgU/DLoW3+m9LYeBbW9zg+bXsSibwwb9Vsi/WtZEwHYXHc6Ls0gJBdXT3CKnE5dYpBnPQ1Zk7shHQzRJ35NyazXXTtu1ok9YyrBZqMUXkisASCmRGeAY6677Vew/hteIXBgoPA19u4Boc2aBd6lFmim83EgrYSJIqC8sUlU0OrJUQrpt8KuZEdeeJUWFgkvBlCjsRkIUdRVIUrOhV1xX7Daj1oc0WmLMLKuUWmLruFjCvMWVbUKqqW4BMhMGsF6Os2QoBJdZiHe9OUqqRymGJFeICHGcgHWNDGtzTnBuaEUBGmlj7axAFvmSSEUO8wrOfN4ea2XGAit0PRIXC5+B6mNHZIRrJK6QFowl6nBzYvyNU7L4OR/v7T5zwrZhpmE4HX+B0Wi6VpV1jXBjKO6I1nhF9fb3hGkVq80icJJTPDtFPYJjn8uoIQqCsK2Fxm1F+iPahFJYYSwAJ/ofmm10T2Nl0egnfC5MCOOxGrUYk8TpzFVryZK3h7Z28XNJpSyWiRa5iohu+35cKJdAXSga7B6pGzag2bpE+5IKTTkTb5M2GJTe8WmyaLBkmsLhC9IXm3ntY1DtihQ69qza0D3SGGQuDWCTEgr2GngAMUm/BppZnRQ3sLku5zsaq2lr1VXQp9cl92LbVuAW7kXJtvL8v5yqkb5h0lQW+s+6TrKu2B/cnXQX0DZNu05TfNuluZN3xmwcIcx94/HmBru4moRqOlYvCvUdfIxOP33wn4bbIZy0wgeOCfgAGWhwULWBnWhq+uCv4uth4z1PwlpNvGk4KS0HCZs7KY1ubT7GqantOmpu0bzzaJX11Jm2Iv3IslYpyM0XOE+/p1EFepcIWkZEVOSHgYvDhDICebD0H9xO5c7R94FWciUuizQNQukaqKa2/B9d+W986wiIXrVLpwFLp1RyzvLh7t8RtLy6+/mi8ekspwx95pOVRc57vKUlV8xQ2FcIQVVz6l8kt7Opec/YRBejx7MYr/757/q33+QonFBuhfCc8rZLoz+JSFfdb/iYswiiOKKNmYeHa3F3AaEIyCcbisQX7o83dBUyX1PfbMPEeKKbvNEjBmMgt1GmZupM+BhtttYFfmM40vpMqmBFVwLwoEneByIBvYFXMYwI479rcAzzoPABZU2OkPvT9GTVpHnmxyHx9ceXL8v1Jl+9PTvgbNb/nETpR4pzE5v+lujZkTrwLoK83pWDjf/5GT/dHP7vw9Rwi7JmtRm+g+k5qr72dlMFl/Z26fafyz/Ecl6W15jvDac6LN7Th7rLG3BkO0oPBrhdRngwHMaPxxWAP1YJoCPpys4uWrVJFiaeNkOABicu32eHuUSuxMzQp1bseBz2GA1AZ8MtX5BWx6za5M3TSA2fXKx4hVpRc7bXGjLUGNXOlhRrsDaSwFFaDFdhW+dsA4NyIQY9SdWL9Ob98xYetRvFPGv8CZDTZU7UhAAA=",
+		Length:   8629,
 	},
 
 	"data/results.template": {
 		Filename: "data/results.template",
-		Contents: "H4sIAAAAAAAC/8xY747cthH/fk8xoY3uLnISfUaAtrFWQOL0D9A0MXxOi8IIAkocrXhHkTI52vVhsQ/U1+iTFZRWOmlXe46Bosh9uBXJmR9/nH8cKfniux9fv/vXmz9BSZVOr5LwA1qYzZqhYekVQFKikOEBICFFGtNbFC4v4S36RpNPeDfbSVRIAvJSOI+0Zg0V0R/YcUkrcw+lw2LN9vv4J6drh4X6eDjwQmxVbk2scsvAoV4zX1pHeUMQ5hnwMboRFa7ZVuGuto4Y5NYQGlqznZJUriVuVY5RO7gGZRQpoSOfC43rm/jFJ9jA4cBz73lmLXlyoo4rZeLc+54YPWj0JSL1QD53qibwLj9HuvP87kOD7iG6iW9exl+1YHeepQnv1H4dxpTMqX7Cew8lmZUPR0gjtpBr4f2aGbHNhIPuJ5JYiEb39AESqQbJYEqhDLqo0I2Sg8xU6ggUdkU3kgkEGiJrgB5qXLNuwE7UyG42GiG3Wovao2QgBYnjdKDQzffTwm1CJD3rtBkIp0SEH2thJMo1K4QOsu1sYO+sHraaUAtWroXpyXgXWaMfWPquo2PEVm0EKWsSHuSeUA0hGbXw/y/RhHemHLmDS7U98Y6Sw8Ef/dkZs/f9YNwJ+si1daN1pLGgU9s1euTGHs6I7Ylcm1i9ZOZQyNw1VRYpwoqliZjPf5YmWfqmqWuk6LapKuEeEp6lCRdpwrU6ocIbPTXOxBQz53FqU54dqLCuOonMMMVA5CEKzjj6tugxqJBKK9fszY+37xg4G2L2uHZmihERZeqGoo2zTX0mB5C0y8e0IfxIgwcDpz6wGdRa5FhaLdGt2e2RUVcPCQP5rdANttzfoasOh7m95llFGZkZ6cec7p1KBjIyQxk5kvZNViliaTJ4f6Mf6jJENQxPUW+ohKv0PKgvevTCZMKDdZ6IhclwNEi4Ef3jXPVj6VWvVN6cXXflzQBZp78zma9fJbweVPZ7VUD8g5Xo4XC4upQ/EYnMjwvsY96ECNximy6T0kgiY8cEeia0ZulAaZonXYbMlHcSWXS8Lcc7fxFF/ekgimaqSthsjFELg1AIiaAM9GzHbiCRaexVf2lHYwCN0P6PMuskOpTHoSen6mGUWyPR+GFc2i2e1XRyZ4FCZRqsn3Aq59ZuSdDFxW+dMHl5cbVRmi4tvrX68p6I5tLaG2UMyvPVhJ+ebb93wmzwLLgGU5xlUhuL+AHi9tDACqF0uHEPh94bMiA6Bvs9oJGnmHMYeRlUJiDKFJbBZ2BYV5fCTEF2whllNk/itOnQXyHPJ/XZWIl8v4///EGambqXkEyH1YSTvCDQEnxSoguRiyLQdsNtCW7D5RsKbIbRxj4JHoLo10APqJ8C7KJrTmQuvtDIidUT3qbepytqq6w9Pha8Ov3BgmkDdYcOobCNkddQCcpLZTZAJUItiNAZSHIrMR0urYS347itqufUxoV8UoAvzJRueH8orCV0bWHqHp/ugi/0v7nVUSWjr6Y1b1LhT8uUVhebHyekEmQdZ+nb4yP8Q+FurvuZ9j5zTeD/hmJJVPuvOd8oKpsszm3F/f1HXnctmu9aNJb+RdFfmwzeOHuHOf0WCHvCLcb3WNVxoThL//NvePni5vfRyxc3f4QIbsMy/A2r+jPJTpqHLnSmr26PrRu/E1vRzfaMny+LxrRt5XK17zd4vlzEx7vQvR8K28+LVYwiL+c0gg6Vyq/C++hykTfOW7e4XtRWGUK3WMXtFbkcm2kWZgwlpHwdTLxcdBf5YvVqLHi4/iw0h5Xd4pOAq9ia5aKyjcemXlwPmLDEFZzA+p2ivIQlxrtS5eVquro/vR84h++xIHitVX4fn67mwiPcfH06LW3eVGgo1jZvXwBh/WgcIrdcDM45OUr4C+8496+uZpj8XUkZ3nMvc3l5xmUbXtNx909lpN19Fo9dqxLbGs1yQLiGxS+ZFuZ+RgHj2uEWDX3XNfLLi2ebzB2mvrwanh5twHnoJlFjTvDum28hE6GHswZK4cvQMf709vvBIOHEjdOwPvdDTPaWnDKbETVVwLJxOm6vkeXi2WI1iZmQU31vDeL9sVdewJdhj9jXWlGr9P7mZ/gSFixkG4lsufCl3Y2MdBgf5nXb8HTsdyUa6NAdQndIlPHV7P6LLtQDtokzH3Z6It6PHhxO3+63Boy7TyDt+JHg6sjwcCQ9/SDUfQdKePdR778BAAD//yGYCkflEwAA",
-		Length:   5093,
+		Contents: "H4sIAAAAAAACA81Y/W7bNhD/P0/BqcVkY5HUFAW2tbKBNt0X1q5BnG4YiqKgJNpiQpEqSdkxDD/QXmNPtiP1YcmW3LoYhhVozI+7H3+8Ox6PCr96+eby5s+rH1CqMzY9C80PYpgvJg7hzvQMoTAlODENaGqqGZnOCJZxiq6JKphWYVCOlhIZ0RjFKZaK6IlT6Ln3nVNNMcrvUCrJfOJsNv5byXJo0/vtNpjjJY0F9+GPgyRhE0elQuq40MiMOyhoo3OckYmzpGSVg5CDQEITDqutaKLTSUIAjHi2c44op5pi5qkYMzK58B99gg0COrFSQSSEVlri3M8o92GkJqbXjKiUEF0DqVjSXCMl40OkWxXcfiyIXHsX/sVj/4kFu1XONAxKtc/D6JLZ1w+D2kNhJJJ1BcnxEsUMKzVxoBlhicofLyFzDH6r6INkQhtJY0pMOZHenBU0aWS6UhWQWZXIlowhUGgtONLrHFxUdpw9NS0WC0bAa4zhXJHEQQnWuBo2FMrxehjLhYmkB6W2g7Ck2CP3OeYJSSbOHDMja0cNeylYs1SHmrEyKNVklPQEZ2tnelPSAQ26wJoKDqYFuSOqJiQ9C/9fiYZBacqWOwLwx553aNJsfOfP0pi17xvjdtBbrs0LxjxG5nrfdgVrubGGg589OXuwaslIQoTEssgij2qSwc5w//mHmWh6VeQ50d6syDIs17BlMASG/4zuUQkK1jVOxxQ9+5F0kR5saC5ktheZZghCKTZRcMBR2aTnIEhBqQBLX72Z3UBOECZmq7kDU7SIUJ4X2ltIUeQHciBpp6tjo8m9bjxoONWB7aCc4ZikgsG5mzizilGZDzUx5JeYFcRyv4H+dtu3Vj8rL9K8R3p3pmunao7gf5NGKtKqiDIKRg4b7y/YOk9NVKOm5dWGCgM6PQzqQY8ODIaBsc6RWOh0W50wAI9Xzb7sB3aoldKLg+sOhurZfPo1j1T+LAzyRmWzoXPk/yYSoiB9nw2dH8hskWon2N25MRG4JPa4dFIjaDjVAXqAGXOmDaXuOSlPSE96BwCvui3bK3/lefXukOf1ZBWzWBsD0hNBc8j+cL2imm3bDSAEObVS/WB7bQCYs3+9SEgIZZJUXbjjaN70gGlCuGr6qViSg5yu5UGg6HRqrA91Sdo3N9NYD06+kJjH6eBsQZkemryGVDC4JiF8aO6Kck6Sw1kY2dvbZgPsFuQguBpTHJwkG4vkI/LtphHclpSZG3e7rb2RGES4MDYbRHiyj9mHAcUdqHRAKJ9D4XYCBoO/bYAVlpzyxUkYQubApEvkc3DskaqvoYedHM/BrgHkzh8/Jrwnd4Y6mTaz4J5kQMASPCpRhtmgCLIVtU3jNuSea8Om6S3EUXATiJ8D3aB+CrCM0D6RvhgFs3esDjLm+H46K1tlqOZ2STOHg4y4DfYVpAk0FwVPzlGGdZyCm5FOCcqxhquPozAGuWlz8YWB7fs2Mx9Sa18GnSQ+MJLK5g0yh2KcSJvcyubxSnqghoZKzMsS70k3b3Zuif1UBwl+qICSOKFYCwmV1HXVRL/DA2mggjqGRBjFEWVUrw3Yrnc6FE1IloOBeGygftn1TodSTKyI0gAzK1vIpsAv2B4UwKIwQNdl6wu4wOlWhgn8UqVp/AU04C0qLchz2zgdIIPYAltCFiGA8nrX64PqVsx9T4d/JyhTrXP1NAgWVKdF5MciC9TdfZCXhb0qC3tn+hPVPxcRupLilsT6/0BYabIk/h0EqD+nYM+//0KPH11868Gf75GHZmYa/QrTJ5LtlJxlsug++HcFf3CLl7gcrRk/HM0Lbh8jo/GmXuDhyPWrCkq+a66y9+7YJzhO+zSMjk6pGpuvGCM3LqQS0j13c2GCRoKmLaxGbTP1wrShcJJcGhOP3LL8c8fP2oLb85PQJMmAwlHAsQ/6biYKRYrcPW8w0YiM0R6sWlG4IGDGX8GzIx13Zzf7FUEQoFfw4kWXjMZ3/v5sjOH5fPF0fzgRcZFBHe0zEdvPBmiyM47WcuQ2ztnbivlnXsZ3z856mLymSWK+jgxzeXzAZWk+7pDVH5QnYnUSj5VV8UVO+KhBOEfuh4hhftejQHxIQUvY98vy+Tca3FtnbNv15VnT2tkA9g5vEMIgJaCb5y9QhE3lD3ZNsUrNO+Pt9avGIGbHhWSw1wM/+FrM4BnBFy1qUDiOQNy3hcPIfeCOOzFjzlT9IkP4XfXCctE3Zg1f5XD9WaV3F+9hzHXMaQPZkatSsWoZadvezKUtk0v2q5RwVKJDFVNukiT+We/6bhnqBpv7kTIrHYn3yoPN7u16E/BT+eHM9ncExxXDbUW6+xmx/HoID1z7Kfgf90DuhRsWAAA=",
+		Length:   5659,
+	},
+
+	"data/rollout.template": {
+		Filename: "data/rollout.template",
+		Contents: "H4sIAAAAAAACA81Z/Y7jthH//56CVQ+1F1hJt5cATe5sA8ldkhZJLovzpkFRFAUljS3uUpRCUvYZhh+or9En6wz1bUub2x4QdAHbJOdD8/GbIald/OHtT2/u/n77DUttJlfPFvTDJFfbpQfKWz1jbJECT2iAQyushNX7XMq8tIuwmlakDCxnccq1Abv0Srvxv/BqkhTqgaUaNkvveAx+1rLAsfhwOoUbvhNxrgL88pgGufRMmmsbl5bRusfCvnbFM1h6OwH7Apk8hhwWFD5tLxKbLhNAZeC7yTUTSljBpW9iLmF5E7z4DWsYmhMbE0Z5bo3VvAgyoQJcaQyzBwkmBbCNIhNrUVhmdHyp6d6E97+WoA/+TXDzMvjcKbs33moRVmIfp2NozLn8ImxSs4jy5FCrVHzHYsmNWXo4jLhm1Y+fwIaXsjEfORPRclIouVCg/Y0sRdLyDLlqRfRU0D0eMqC0NlfMHgpMUTXxzsRsvt1KwKxJyQsDiccSbnm9TCZU680y11tC0h8raY9xLbgPHwquEkiW3oZL4nWrZL3OZfuogWkUZRRqjDHaz5U8eKu7yhyUEFtuRa4wtMj3iChB0nfqfy/WRViFspeOEPNxlh2RtI53+ayC2eS+De5Aey+1RSmlL2Fjz2NXyl4aG3X4c8bnCqvhjDQiJNZlFvnCQoae8fH6R0q0ui2LAqy/LrOM6wO6jIHg+JHizJSwlMPgDEIx4o8W2/TCoU2uszNk0hJCKSYUXNhogOs49Ri2oDTHSN/+tL7DnpATZmvaRSh6hghVlNbf6rwsLviQ05HrsrHwwbYZJJsaYHuskDyGNJdYd0tvXVtU9UMLaPyI5nEb/MiqEe6ugpsUWsXw0zaN2kRTRpnAkC7aXG/loUgJw6wd+U1YFqFYXUJ4Mn8Ti4uQYvFI5gfT3mQRYn7r4Viv6zphetNtajhulovVXYollCdgmC6VEmrLgMcpi0ohE5ZvGOywx7NIcxXjnoOtiVmU2HAhSw1McwuOqyeDAo6n4MYyhNpbfjCnE/a8gwkYe+eexVHW2DJ+YGJDzIeZJjNsawQpULAHVNGagmtC16aQ4jusOEOQQe18gyhhwrI9NyzD7h0swqJ1s18RDnZC4SYJkxWhq1B1JfHdN3cTO4ZTdwn+heQRSIbUpUeeeysKA5Y8LQ8YP7I+qAU6RXVRVOMdlyU486soe4/30Y+32TaxxW2kGX6i9Z3Kpq67hc6PXlIfcWawGdclO1XYq58L3G/hvEyHRYeV8CcVmeK1g029eDwi1rbAgq8d5gAD3NbTy37Lfz5AT4XQEJ2p5MiT3qTq/aige/TxWICO8aTHgjel1jhASCPiUcrVC85UWzdTJXLtcI8y5C0L3jnS6TQohP7+ofMthtpM4Loh08bN+hPflHFMcswdGOuz6Ss25oM36tlUN6PjN4/oAFWZUE3ctx/lGncGSOopHhlF0c4QZgko085T6kF9v6wegNamq68pYni+T88JLt5jhDWe/GGM8L5UowLf1j3yvQNfn46znkEdysimDmPObqRih9xi+EgdevgCsVDHJyExPBEdj6CwXIbPT1YNEEit/crF3SaXTA3Cxqlt9m6rAd/CpCIKxBQRnajpPZ11hChAtA543j2d/NqdoZ7zkDmWFkIu7au2aulha9pdevX62cqtsDq/OP/dMUePHgPKJBbX9gI6FeEH2lrXAOqjcDUMRQWr2t0919RL6hvJeDujzkPN7NtfEzVoym3iK8oEKlr4sXk72+an09UEv3N6UpsT/QRo9IkN4ro+/C6v+2zKd9hogW7hmOE9HvrSwRmobrjNyaVuyf1W239U/8Q22GcmVlLdvhbY4P2YDjZ0H3TDxy+3E80cL0d+lvifD5M3uPicXyLwbjJ1p9E8EdzmGi837+sh+5uA/cSl5jFNIAWPhBT2QMq62dNViQSyAgOkYlL11272dFVG5rR1opp1NWqaxpPdq46R5Fpz9n6yLVgNhizBX2GsiP8HM7gE7ZR85QZPV5AhtjCWCH9ALT92szFVw0vsI6fQTwRlam1hXoXhVti0jII4z0Lz8CEsqru2qe7a3uo7Yf9SRuxW5/cQ2/8Hg43FO1XwgAANNgLj+Z9/s5cvbv7s49eXzGdrIrPvkfxEYwf3wqpZDN/Bdaf08J7veLXaWPx8vimVuw3Nr47NA57PZ0G9I+p/tFvEP2dXAV34xiRIxqbCXNGLxfksLrXJ9ex6VuQEGo2SbqOc98M0qqaviifJGwrxfEb3tR3Mrl73GU/XT9KmIUMTHlV4FaD8LMtLA2Uxu251sjlcsTO1Zi8s7g5zCNw2cTWkHs9fCoQh+wE2lr2RIn4IzqkxN8BuXp0vJ3lcZnhkCmQeuzd5bNkFx1o9n7XJOXOF/uhl1cPrZyOW/CiSxJ19Jm15eWHLjt63wv4XoZJ8/yQ79k4kyAtQ81bDNZv9K5JcPYwIQIAtaId+v62ucvNJ3wZrp2Eun7Wjmq8ZDN80Vy+Y8WTo/k3wXzVuT0c3GAAA",
+		Length:   6199,
+	},
+
+	"data/slowest.template": {
+		Filename: "data/slowest.template",
+		Contents: "H4sIAAAAAAACA81Ye2/bNhD/P5+CU4vZBiKpKVpsbW0DQ7sX1keQZBuGYRgo8WQxpkSNpOwYhj/QvsY+2Y56WZJlr+mGtQFi8XH34/Fe5HH62at3L29+ufyaxCYR87Op/RBB08XMgdSZnxEyjYEy28Cm4UbA/FrINWhD3koGeuqXgyVBAoaSMKZKg5k5uYncL51qSvB0SWIF0czZbr0flciwze92Oz+iKx7K1MMfhygQM0fHUpkwN8SOO8Rvo6c0gZmz4rDOkMghSGEgxdXWnJl4xgDBwC0654Sn3HAqXB1SAbML79E/SENQnFBrP5DSaKNo5iU89XCkFsxsBOgYwNRAOlQ8M0Sr8BDpVvu3f+SgNu6Fd/HYe1KA3WpnPvVLtvfD6ArT55/6tYGmgWSbCjKlKxIKqvXMwWZAFSk/LoOI5qIWHykZbyitKilPQbmRyDlraLpUFZBdFVSLxgqQGyNTYjYZmqjsOD02IxcLAWg1IWimgTmEUUOrYStCOV4PU7WwnvSg5HYIVZy6cJfRlAGbOREVlrYYtdIrKZqlOqJZLSNTLYxWrkzFxpnflOIgB19Qw2WKqkW6E6zWJd0C/v8infqlKlvm8NEePetw1mx8b89SmbXtG+V20FumzXIhXAGR6esuFy0z1nD46dEVgVVTBgo9JFR5ErjcQII7o8PxjzPB/DLPMjDudZ4kVG1wy6gIiv+C90Txc9FVTkcVA/tRfBEfbCiSKul5ph1CVwqtFxzIqIGqMHYIpqBYoqYv313fYE6Q1meruQNVtAThaZYbd6Fknh3QIWUxXYWNgTvTWNDKVDu2QzJBQ4ilwLibOdeVRGU+NIDCDyAPy+AGJh2g3kdwbUKTEvxvkkYlos6DhKNKp42tF2KTxdaHSdNya7VMfT4/dOGj9jsyOPWtLk5YvtNtdaY+2rdqDuW6fSaML/pHG47Uk9n8JgaS65wKovLU8ASIjAjQMCYpUhPMSIU/ELkCRQwSZxSR0I9e0Y3e7TChbfQ50dUKEVfaeKRciaxjiWGaSJxQEOJxZtcgRsolDipAOEwVCPUNuqdUCGbX13YVrtAnGcfpWiqK9DH6vLB+D8yb+llrF5+ngc5eFGNnx4Ib026g29l/H9Q2PFZQxHInbyOHU0X3A6sOZK902A9iBDvJbXWI3Ff20+cug3/g5EIAt7oItOX+zHUrBbvuULosBG1jYOYFEuHBhjcHUu+17XNIZE+vhgM7xa8bSIVhCazq4nnNs6aHojFIddOPrY/0U5JRB2Fg4kKLeMeKh+au8lQfm3tTOMWx2WdPTUwuQVlX4+LoAq+pQV89nMWRnrDbrcJLIxCvUPdu198aEvCIeDa+0Hsr/THLgsfVdgspKyIEfaI+Ih528q81lY8B8Baz3W53mGsNmzeznaU6p6ygAdjrLf661eJzG5DVmVvJgbtjRxawGj9FoMGaWhOvVP97kV4+e/pedKUxhkiHzFHspJMtC9c7yJfdYCmibjhYyrgcDJaPHCJW6I8QIieCoFDjYRAMG9g6a+24TQPwXrvbuZ+UR/4bNxs4lvfNzql0ZCRWTRkYYT2ER6x1yrJ5upg5UsbgZdhNmPuk67yds7DvfuXJNXiHVRS1i0czXmavqib5CWvUI5fYU0ggOA244GZjwfa9+0NxBkmGCkpDC/X9vnd/qOragjC9K9K9t4c1iMwt0FXZ+gBZDDXaSoJfrg0PP0AMKkAVIF8VjfsDJOhbqEuahoAob/a9Iahu0TJUvf03Thkbk+nnvr/gJs4DL5SJr5d3flbWVrqsrZz5t9x8lwfkUslbCM2nILA2sAJviQ7qRRz1+def5PGjiy9c/HlGXHJtp8kPOH1PYTsJp0wW3TeXfc3l39IVLUdriR+Oozwt6sHxZFsv8HA88qpTTf3a3Fh+G008WwsMcVgeE3M9sQ9J41GYKy3V6HyUSes0CjmLw27cVtMgTBuKMvbSqng8Kq+po8mLNuHu/F5oChIU4STgxEP+USJzDXk2Om8wyRgmpAer19xgXTQGb421YDzpzm77RaDvk9cQGfJS8HDp9WdDiqXRxfP+MJNhnuDZ7AkZFi83ZLZXjjFqPGqM09uK/bOPE8sXZwOSvOGMFfeXo7I8PpBlZd/XYP0zT5lc30uOdcHiyQzScYNwTka/B4KmywEG8DAFrXDfr8qafHx0b52xXdeWZ02roqsb3ZfF8kER6+DicfhvC5oXAy0WAAA=",
+		Length:   5677,
+	},
+
+	"data/stats.template": {
+		Filename: "data/stats.template",
+		Contents: "H4sIAAAAAAACA81Y/27bNhD+P0/BqcPsAJHUFAW6tbaBNe26YWsbJOmGYRgGSjpbTChKIyk7huEH2mvsyXYUJVmSJScuhmEFGvPH8ePx7uPdUZMv3ny8uPn18i2JdcJnJxPzQzgVi6kDwpmdEDKJgUamgU3NNIfZtaaaKc1CNfHtiJ1NQFMSxlQq0FMn13P3a6ec4kzckVjCfOpsNt4nyTNss/vt1p/TJQtT4eEfh0jgU0fFqdRhrokZd4jfRBc0gamzZLDKUMghKKFB4G4rFul4GgGCgVt0zggTTDPKXRVSDtNz7+kD2hBUJ1TKD9JUKy1p5iVMeDhSKabXHFQMoCsgFUqWaaJkuI90q/zbP3OQa/fcO3/mPS/AbpUzm/h22eMw2sp010/8yjuTII3WJaSgSxJyqtTUwWZAJbE/bgRzmvNKfZSMWC1pTEmZAOnOec6iWqYtVQKZXUE2ZIwCudapIHqdoYtsx+ks0+liwQG9xjnNFEQOiaim5bBRwY5Xw1QuDJOe2NUOoZJRF+4zKiKIps6cciNbjBrtZcrrrVqqGSvjokoZJd1U8LUzu7Hq4Aq2QEqnAk2LcgeWGkq6Bfx/JTrxrSkb7vDRHx3vsKg++M6f1piV72vjttAbrs1yzl0Oc921Xc4bbqzg8KcjV1ysSjKQyJBQ5kngMg0Jnoz233+cCWaXeZaBdq/zJKFyjUdGQ1D8z1lHFT/nbeO0TNFzHskW8d6B5qlMOsw0Q0il0LBgT0cFVIaxQzAExSla+vLj9Q3GhNRwtpzbM0VDESayXLsLmebZnhxKFtPltdFwr2sPGp0qYjsk4zSEOOV476bOdamRjYcaUPke5H4d3ECLHundDa5cqAXB/3XQKFVUeZAwNOmk9vWCr7PYcJjULbcyy8Rns30KD/pvYHDiG1sc8Hyr2+hMfPRv2eyLdbtIGJ+38hp2q5lsdhMDkblQJJ2TrKAqSZcgicbxjCpNkC9v6Fpttxi41uoMA1ySUQkRCdYFTc5MY8E0CSQVIaYmjGBmCMSSyVQkmMK8iZ/VWzYJWrCACcxZMExQ1Fzt+Pnu7c1A+C7A9pk44TQATnB26pgTODNzHLx/Zrgl+EiymnhUAJUMte0l5TkUyltrOcNBrZVMSsoNEXP2KcN8AV2atUmDbvxKBCp7Vdj5ZCi0YdIJVFOvXUgztl9CEclaWQtXOGVse2Kcjctfr8kVtvaDGMIdXG/5UUK8LjrHgzRYZYHe7ga6aDai9pQDCOiW1ZXTk2zsQZvCmLeAzLEswLqLVLZqUGezwTyAQUwDcUq+esZIyIMHU1ttlt4NH9zmdbn8ETu1bPd5uzWsPbhjK0Ttmi2ODozEsi6J51gbYhgyetvm4cJuICZgYeAmkfu8HRFaN6ObQC0Be/O5pBGjOpWY2K/KJvkZ6/WBhH4ICTijAeNMrw3Yrnc8FIvQS2ggERqoH3a946EUT1egNMJc2xb5kEagPuN4WI+luQG6sq3P0MUwzmjSSFzHYuDTSBYg3xaN4wES5BbaEm8YIMr7Xa8Pql3A9V3Cf4eUsdaZeun7mHPjPPAwH/vq7t63ydtVts50Zu+Y/j4PyKVMbyHU/weFlYYleHdIUG/O0J5//0WePT1/4eKfb4hLrs00+RGnj1S2FXBssGi/P3cp3b+lS2pHK42/HM9zUZQe49NNtcGX45GHkREfUFr+ViQjc5TfR6ce0DDuW2HW6JipU/OoHo/CXKpUjs5GWWpII3FlbOqqcdNMvTBNKBpFF8bE45FNOqPTV03B7dlRaBISVOEg4KmH60dJmivIs9FZjUnGcEo6sGrFdBjjjLfCujg+bc9uugWx75Of8AFGLjgL77zubEjxNXf+sjscpWFeVJA8DYtXLJnujKO1HI9q53SOYv6Zh9rdq5MeTd6zKDKP9WFdnu3psjTfGmD1CxNRujpKj1WxxEszEOMa4YyM/gg4FXc9C8DDELTEc7+xZeB48GytsW3blyd1q5SrGu2vLPbjCj4Liq9kmw2WnphRq5y/bYJO7J3YlQ7mgpi/bpBKfLzhq8B2lUb8uodZOgKh6n5xE7oRQ8u9x5KOZx+wxJ742Oibs8mpf/IK3zRDc9d5GAJEEA0JfEcZH569iKlYDE/jzpr1aY0jnTNuNtJAEa9l5cIYOMfmZKGJZ5UhT/EFVho+MoukgyIgou12X4loVqz2jPW2W0xqVQO4wl+3XIgKRf2LvcK2ByWMgQ8JZCBDZDDxCmsrdYWF5KPkzXlzCY+Wt+54SFyBYaEiXumePtk+B1mL7VEVQc2nPvNFa+q8cJCL9hW9wktAJJgvuBB5xRa9qMb8rexW3Axz/ezUPzsWmEa9FgAA",
+		Length:   5821,
+	},
+
+	"data/timeline.template": {
+		Filename: "data/timeline.template",
+		Contents: "H4sIAAAAAAACA81Y/27bNhD+P0/BacXsAJHUFAW2tbaBLl23YcsaJN6KYRgGSjxbTChSJSk7RuAH2mvsyXbUL0uy7C5FMSxA7CN59+nj3el49OSz128v5r9dfUsSm4rZycR9EUHlcuqB9GYnhEwSoMwJKFpuBcweHoI375ncbolP5jwFwSVMwnKt1EvBUhInVBuwUy+3C/8rr1pC5TuSaFhMPcT5RYsMZX6/3YYLuuKxkgF+eESDmHomUdrGuSVu3iNhG13SFKbeisM6QyWPoIYFiU9bc2aTKQMEA78YnBEuueVU+CamAqbnwdMPsCFIJzYmjJSyxmqaBSmXAc7UxOxGgEkAbA1kYs0zS4yO95FuTXj7Pge98c+D82fB8wLs1nizSVia/TuMLpm+/SSs4zSJFNtUkJKuSCyoMVMPxYhqUn75DBY0FzV91GS80XSupBhT7S9Ezlmj09WqgNxTQbd0HIHcWiWJ3WQYonLg9cysWi4FYNSEoJkB5hFGLa2mHYVyvp6meuky6fPS2iNUc+rDfUYlAzb1FlQ43WLWsddKNI/qUHNeRqOajNG+kmLjzeYlHbTgS2q5kuha1Dti6lLSL+D/K9VJWLqyFY4Q49GLDmfNxnfxLJ1Zx75xbge9FdosF8IXsLB93+WiFcYaDr96esWLVWtGGjMk1nka+dxCijujw+8/rkSzqzzLwPo3eZpSvcEtoyMo/gveoxLmouucjisG9qP5Mtnb0ELptJeZbgpTKXZZsMfRANVx4hEsQYlCT1+9vZljTVAuZ6u1PVe0iHCZ5dZfapVne3qoWSxXr42Fe9tE0HGqE9sjmaAxJErgezf1bipGZT20gOQHkIc5+JGVA9q7N7gOoZUE/5uiUVE0eZRydOmkifVSbLLE5TBpJL92yyTks/0UPhi/A5OT0PniSOQ7w9ZgEmJ8K3Go1u0qYXK+O+Gwqp43C9nB1JWKQdgYebNriPEsIjqXpkzfrAXyhYxM9rKYqyctjVwxLCmVg+LTj5TGKAOrhsiWgXTlsrVhqzvusMnsDeUi14DPxkFv7RKw2lg8tYlVeJjFagX6uF4Edg0gyeII6kWutdtxpUPwnAJ619XEkT5CmzmvX2MzQSMuuN0E9S5cFCzbV2a5Lko16VhdzufXj7X45s0hC77o6t4U+9pue1Tr6SLiZ+TQk0q1G3BhNA4E8Njabn0UJOtTaLsLZRf/T5NEGBue7adUNU5cQnwgvbRKh5JgroZmr7E4Ds2/S0AeS5CHB41tKJBgjt+GO2+iz9q8OoWhiBW8R3VFPJeGrqfAiFT+YA4Lj8SHB4K+Ji2gPWPsW1G3Y83lAnvSnfFwoji/FKlRC8fC26T8XB1ccq4bXCRFs12UoVfWFRwnLNWxFHLsCxpDKdWuk53cOjCT6KYZX2BXCrpoO0rxeEt5oJnElsRPmf+825J02o3+0Y0dwaFyrCnj1CqNLcV1JZJf8aZwoJU4hrR7ex3YbvR4KM4gzdBBMnZQP+xGj4cyQq3BWIS5KSXyMx5A5iO2h52gyh3QdSl9BBdLrXFM8Jsby+OPoIGXMl2AvCqExwOkmFvoSypjQJTL3WgIqts6DvXQnyYpE2sz8yIMl9wmeRTEKg3N3X2YlR2uKTtcb/Ydt9/nEbnS6hZi+38gbCysILjDBA0WHP3591/k2dPzL338+Bqv/DdumfyIy48k2+nGymLRvfnuOt/wlq5oOVszfjJe5LLoysenD/UDnoxHQXXU6d+Lq6Lbyh+j0wBonAxZOBubcHPqrvPjUZxro/TobJQplzQaLYsTcNx20yBMG4oyduFcPB65e8MKRqcv24rbs0ehaUiRwlHA0wDtR6nKDeTZ6KzBJGM4JT1Ys+Y2TnAlWGNHnpx2Vx/6rXgYkp/w6kcuBI/vgv5qTPEeef6iP81UnKfYAAZCxWXjM905x1o9HjXB6W3F/bkr4t3LkwEml5yxoqk5yOXZHpeV+5UD1u+4ZGr9KB7rwiRQGchxg3BGRn9Ggsq7AQMIsAStcN+vy5vR+ODeOnPbbixPGqnSq4Xu7zvlzzp4Iyl+qfsHCKZldboTAAA=",
+		Length:   5050,
 	},
 
 	"data/valid.yaml": {
@@ -106,6 +172,12 @@ var RESOURCES = map[string]EmbeddedResource{
 		Contents: "H4sIAAAAAAAC/9x9W2/rOLbme36FZ7+ch4Ec3i9+Oo093RhgTtcUalc/BQVDkelEJ7bkluTsSv36A/mim0lLlmSZUgGN3rHNdSG/j2uJXKQcx5n9r2j/+vUcvv638pLFr/vdTiWLxe+RG8Sul/hhsFj8pnZhlDxtVRL5Xrx4ms0iFYf7yFPxQt/8X4m/WSz+eWjwNJvNZoG7VYu82eGzjfuqNovZb6UPP93NXh10zGbOzJklYeJuDn+lf/9e+gtylv0u/vB3O7XKvvtR+Rtlv1y7/qbwxT/Kf4LK75ZJuIxUnLhRUmkyS8JZ9Zu89embguTfLj7Jf+29u8Fb4Zvvlb/zX4b7ZBmul/FX4GXf/v99MgvXs9JneYvYe1erfdHHHxefFCwJo0h5if+plkejcpuyb2aVb9LWiZ8O8C1gSFsUcfD7+e8qBHau9+EW1P1a+RvMCYCCCIyJPP6Xj6G/yX/3j+IfYE4QBFQgAY9tRN5hKvr0vfynPyp/gzmAlEopQd7Gi8Ig76niH2AOAMBCgIIG9afKR+rvxT9S2SC1KPNF8pwL/uorp0LxDzGXQiDlAFpyfbnxg7L/s9InB9s4kIBBmf13AZwL3JTbEyykgHmr+H3p7pP3MPL/Uqvlh8qt/BG/z/KvZsWvUr+lgJgxdLaj7Mrr3vtQScmXykd8ng5lqQ+8MFj7b8sohZ76LMwc3w9fzC6/QHOJEBcAMyCZwFA0mojwnEsMAOGAHlpx8jQ7keTGGfLUqMiL74WPbpkdU1aqTxUkN5pwbFO04O/5J7cakE+l+0iVJtDi34XJau95Ko5zyFT+Bk+b8C1ePDnXXPqv8O1pNtuoz9T4RRAmR/ZuVRy7b2pxoI4fvM3AAVmp8GMEWsy+Pf9I3Df1snX94I/n7+F2GwbP6c9fnlXi/fEtnejct4P/ziwTnDHTmXkb92Btir208emHq+PPVmrt7jdJNl3+BwKQO4A7SP6O8ALyBZBzxqUUXELxvwFYAPAfT7ODmalxKvGedwdXn71wpZ5V8OlHYbBNh+d5F4Wr/SFiP2/DlKbx89GG560b+GsVJ/GzH/jJfLdL/UgngsUMs25d+bfdbuOr1cxzE3cTvs38YIbmjMxi5YXBqti1R7n6DjT1BoRzwAijECNc6Y2zB0/nnGIZJ26yj4/gPIWIl9SxPwzwP+cdi8WPQ8sDxhI/Sbs6/f3h77Y9/67cKHlVbqLv/HP3k8O/zy4synaXvlsmX7v8B4evvDBIXP9gw3LnJu9nVhYgfPrk/56teSpF0IKadBLcu6kLy+NgHMMWJxIdM6fzqM0qAdnJ+ypHf/rv94rOEwnKNLhKBIEklVAUh352ysoWs7W7iU/dcMyVFrMk2h8/KeRIxR+eUsTLtksv3AfJ4jQNFVqXvzhPpi9/nLq/kinlgtPo9PK8j6PnTei5m+dXP8gR0QaP30zCvg2BUlZB6XX3dLDNUq/2mG2gVANiSDGhkjJ2AeIsNewdthggTqCgOthmGM1Qm4PREtimIErT2fmqK2Z1kgYBLMRaxGo9uytczRr1Ey4TkDI4HFalIIALCGHDKdYisJ5jWPwVf6YQaAPRc9tOmIyTSCXe+3VEUkOgz6zvL9j/ONpTCfVlRbqZUmIpOeF14b7UZ2U8xiXNbdDICAWUjxCNpzWClyNw2mBxd86P2yPx+NtlrJL97ra8s2K+DozFVZDmYPy1YNJTaTmlpEs3G5bWWIp4LK7POMV+K6Nxd6n6RkhCMIcCUgIYJeOD5PcoDMaCx+r0WLRdB8Zsha0TEi+0GJ6C8sW7IgyzJb97YxAxBLigUI4Pg3kWdMLN8f/mXhis2+eUl7K+DYTTK4mlxsGeUksNcuvV6qCM0gSTQd40v+wLvRwgyfAE0KsS70OpnYocL9xu/cTZRaozjHVCh8IzgnV41ro8ILDN+vWTNRSYDAxwgaRkXMhJAjyMkzsgPIyTwSCObod46vRDMZ4ZYAA54QzKgVGOMaWYiPGuFLy68XsbLKftOoH12jaMaY21ZHR/iwPfz7tSxbWBXIcBbkwIJmrWBbJeKoPPKypsAzsiAIGIjxd23j7atIFd2u5hsDsYfWfY5TpMsxwhdatRWSf1jDoKGaSYjBd1K/UaRrt3N2gDvazxw/CXm39nEFYUGZCIJK3dBy13Wt94JFxKMuJZcBO/OpHaKDdu9cRUaP4wTBZduDMqL1QZcIkJgrQGl9Wu6xuZnAvO2ZiRGa7bQTJcPxCL4fr+IAzX19EHBGa16Dv1Us+wY0AQCkf8NBKoxHMT1gZ5p6YPA9/Z9Dvjr6TGBEGo2yEqQ7DYXX2jEAvJ8Ygnv7jlM3H8yGfieIBn4rjumRhJLGv3yu/0TMyYRITiEcPOi5Rq9WhybPk46B0Nvzf4ClpMz8aAIFIHv7yv+gaglEjwEUffeL8KW8FvvwofB77U6HtDL9Nh2l8BVLP0XAHeuZd6hh3HDEEx4lWZRG2CduUYx5YPg97J8DuDr6jFAD/O6xcFC33VNwDTh1054mrJJFKt1l/Sdo8DX2r0vaGX6dADDwEMaF3AzXqpZ9gJKKAUYLyw2yaRk/hBq7M457YPg19m/J0hWNZjeuCVhNStuZR6rG8oUskRpOOF4qe/bYPCT3/7MACmJt8Ze5kKE+wEhrAGducu6htxEggM0XgR5+4SP9mvWsXdc9u7Yw8CA/gy6++MwLIebUE4EVRCVjf7lbqsZyxKRIDAE8Cic+yKLpB0Cr35UGSefRkIoCV1BpwKAnFTnDolVPYIVwwIEZBhOgG4rtzE7QTWVIAFUD34MRRQc2X68zUYcSZoU5hmPdgvSKHkkEEpRl1q47uBs3b/3bLW5tT6cfAseHD/cpuSJu0xBQo5FJLV19sUO65fVGJKgEB8xCs9q9Bzjl3UCpVZ6weiMvfg3qisaNKjkkEGaW0VWLnj+kUlgwBxOOaFoI3/GscbZxV6rUpustaPQ2XBg3vX31Q06YM44oTi2hqwcsf1i0rBAaRi/LU4ThK5Kz/tX3fTviynKOVxKNV4NEi1zqVG/RMS5wBK2Khw56JDe0UvAYhhItGI59R44+7f3hMVtdrQPjd+HFZz+++9t11WpJ9PAYQA1pWUlXutX0BCzikDHE0AkMjxNr4Kkk7APAuxAKCZP0MBtaxQP5UySTkkTQFb6s1+gYsxFATzES83JW78EatWwf/U9HEgPdt+733yoho9IAnBlOG6rfJCf/ULQyIQQhyR0cOw9aJnsf3DATnIkuelLgM0MeC19eIX3dcvPhkGCDJARr2F3rqa49z2cbjMrL//lnpNOQeUgkoESP2++p3KOQjnggIO4Jivrjjcr/ge7qPN13OnR6FvZnHf7g/XmisrLxzs6X6KEnQb6TTUxwmOIGp6JUVX3EoBBCScjxm3PWB1SHwSIz6HwmQjHEIBdOdy7oZDKhlFdMw4dHfJ8+kNLPONHyfzVd7Thw/a47NW9AC4pUbc1jt+Xzw31G+qR4aak9/3grkEgAoMxgrz+NUPnl+/EuW9K++jHZ7LMgYArtACt+LK/RCqU2SYciUDzW+j6o5FAjkbYw1e9Upw1998PUvpdATmFYEDoFTW3LR+6eQAiatJqwG/lHBMhsMvF1CO8ULhQiYWb549FSXxc5yoTzX/UNvd3HPnXtQhWbgi9P44vnJL4DVn75zw1mk2pcAMDZUBUwAgoHDc03Hez3JNFeMrNAd94DiXNgCAcQMAF9wbCrlVlabj7BJxNhxmCSMcTWbV69V/+3Q8N3Cjr37WvQoCB0Aua7TyVXRyuLWvC63GVQdG4HD4FRiwcV84HCduEs//O57HKvrstAZWFjQAXs0pb9WpO0+yOm2mY/MAQTIYPiHCCEkwpVWx/hbB7o9QjBqveQ28xHXt3QQMAwHwcBhlUAI4Qoz+/U/lHV+E9+oHaQ87byqZ7XcrN1Ht3y14KWsAnFZz1quu6ZCavTu5JVLrFZrmU4rp5fJr9l7nfrGKEJVUshHuduWvzm07fQ6Awmr+mRutg1z23u2WkKtIv8BX5VXeRXhl7//uG16SAIZG+wifbHft4JVsd/eHF9EH49To+8XeTLph8whBNNwSEcKIEAhGu+L56UbtMXZqPADO9AtAZ+Pvh7WSBtMOkWQID4c3Rhka9wr7YdNiHu+3cYcVnUzGAOgzb7MXXLnzuk1FkWm3RxAx2KMwIgARQPlEdiu3K9oRkxeyBsAma7JHeXZtsO3JkkITVjEfLkwTLOioL7fZhpvNl/O2d6NVq4vm8uadMHmQszzIafdS6KIf/RUp/zM3q3rxXFWfqeQIgdri+WonljG6vbChDVApAVTAkb5QcpmO/YsK3NeNmnVE7KWUYYDLNROq0S/ThHpo0BnD9apNKan2zQHrkmF3Aa8gHAM53ll2F+72Gzfyky8nHTjVbm38Ukq390Fm4pYncbe9AP2Kc/3NwL9eGFmZiE3aTdkB57V3gRr6ufKGSJNhbQDOGAccTGF23nkdJuWdNyigWd2UvPN6nYmNWDYqNr1bEgJy0zTcL1il4GCMLzXNu9n9DP3VTG1dv9XB5ULzQQELkRGxRY8GxuyFatPUC4DAj4Mtx1DgMb93aB+4SaKClVo5+91b5K5Uq0UFjZhOIM7lLc/yajaPDHmEzr/+Eol/XZpZySSM+o1vTa+72MzU1WWU742WtYG5QEACgMZeR+LukrkXBuv56pmCnpD/rbH0b8My4vp5q9qu6Gn5zcyQNvboSMMoQRw1XkjumRgMCwjlhIghZRK5Qbw59HJ/jCiLHZgKpxvzG3Ch4v3jSKAzRB8yCAC0+UHbXuEvCQaYiFFv8B1rjJxTjI0P/d8e9TppQ4PdvMui9XVAjJv1m55JEW1+uLZnaAvBqJzK6cZKz/eyb1iROTTMeZPNxKrfA4K9zgrTARwMmHwM5CmifJRvM71ylD9W3j591u/x7oSzyKEBL5pfoJB5PXACc8UIE9yJpA9KXiiVCI8X7k2emuar3cebs2p9bOI2HQMTAumz+hv7ZSCGtLHKRBnOMX8QZSQEeLwFfc0HIdys7s2YcLMamjCoI2HSXrGOL5lRpmcIjuGD6MIQIoSNeHdgE3rupt3jwqlpJ4SfZLQr3jrb3t9q/38VXHL0eky7VAyKuot4NyXpRVC2gp6UUoz7ZPKxQ+ZvKmifvOcyvt0fiebzyAVXepo/y1g0a9KumBMGMWONj8mXkdkGjRxzgcaYah/OJR79X7YEYt76/gi8uGSvan9PZzfL6NMqMSWvjMtrxzUrvdUvEBlDiGKGRnsgfu1H6qe72bSbEM+tu02FZym37cdX7O9pFvzHSV5pGiwrMR4+kc2vyluX1bQCnmSCg1HvlAQq+RlGH8/+2tnv5qtnAJxueKwROgRMzZvk17y9J3ob6dZGdSwJ4c0X03oANcaIci7GenXDVEF9sd3dzN2ecoMKqm/QrZ+qMUKci6ZXPHRGNUdcCCwIG+tUHYVh8jyP4/dnd5+8h5H/l1otP9RXjOatV7Wuy+yG6Th+P4hqda61xtmeJuofJxNLE3UT1aZLSwRsfsFOXFbeAtFYCATAeJdtzT3dN5oHQTK7EckPQnFN2fWwCJaQMzrW09vHivbTfZzv6f9WSy8M1v5bl8tNS4IGAa4wHhTQ+tbniQENdus0m87OMoDhTQcG+oAvBZhhMtJEOVJx4kbJMu3iNogtth8Ap5Tp0t+SEz2luhVUGvQYplBMqbia1l50W/+wFBJTPOL3RL75ieOFUavr9s5tuyHyK3ZXWz9Y7hN/0+6ka+ZDfxtVP4pWVbaryurM7+7hdS+FLvVfBZoa/S3wSQAlEIMR759u3Y9W2EzbDYVL4+UXqe3DYDJXZdrDB0zU4THrszthUUok2Qg3VH947yoFxUsYbL6Wh4LUNpDMWw8FzIsXR+o80QH0/LseEGpUeQFUOaeUU+11kXHRHqfak/eBK8QcgTFOnYckytuEgcr6Ypb2RasiFJ2cweArdWmo1rO+0lENdmqUVmHcdJm1N5hSKRjp/7k+ifb3R+mxrL8HmGoFDYVTrN0t0Pt2b6Be0fpwpAopJMPjRKq/3YVRMnvbvc0+1Nfs/3xHTIq/wTZYNYjqhFZ3l9z4Up2rXvWE0n+6/rkI5ZdwpV5OUDl//bddslj8P/X1UtbrNLBNm+VyRokg+Bqu3VTlh/rK/zz9K/9s5aWKXHgd6UWKXEU9AxhDMtaFq9M98sv2d/KXJdwX5FqMV1zoCdp/y5BjVGJa+JdUsBqMVnusPCHnqG0xDVMCsCRjPJb5I35flrdYXuKPP/8zfleb8NWJwrDVEZ2KiAFWVy/OXTZwTPuQdtGs2+LrD5Xsd+nfL7lKp7FxptIDysjlGm2sN92ZxWcbziu45yHpe+WWQQYA5WQyNFgp9y3cdKJBQcQANLg4S9PAMetocGGcYc+MIEKkjTRAEBIOJxINEvWp/jOO3+fHl4yG0dt8/9GeEmZxQ9CjSZS44rBdVKkz1EAbSjCjVtKGUiI5nloSFTubfdvbFzVi7EumMgeHpUdZrXOTkYbEilCCeHtqlIaod3pgRCmHcGrJVVd6VMTYl2TZTQ+tkQZ6QKyrDbGFHgIxgMSUk65OVLku0u7ky2IKNTHYQCesO+tlCZsIgQwKNL1crMtdY1pBNuZjVy8WuydfyoqdGw01PbBIDGEXopSGqneqUEQZBnx6eVlXqlwIsjE3s50qBkNNVKGCC4upwqFEAkw7R+tEmzqhtudpVtOpmdEGajHJiLSXWgwyRicUhbIU4eNz2zlX+/jc2pimpa4NzZNMp9PcPAMjOAea10/ewIjzsPRPBkEw5Gx6KVkHMhRl2JiI2UmGS/NM4YFhDK0kA8eQAiynnXS1JcYVebanWhYSptZUE3m0B7usIA+XGCA5vbTqNQw/OudVqRAbE6uDc0NzI1fq3GCgKbdCpBsjsqHpnRKCSMjEBNe7ulCiJMTG9MpWSmgMNAUJTICwlBKSYzSVyhVTEG9Nj2sCbU+yrKRNvbEmClEAuZ0UkgRzOaHS4bzsyA8+Us+7l3+dBFlZAHZ2cvAN+pJi50ZDTfX1vONSb2mo+qYKBxADidEEC8E6UuVCkJXFYJZTxWCoqSQMwG5783emCgeASDrxorAutKkTan1hmM10ama08YJZhi2mFkQMQjbBav3XTfjWfWVsE75ZuTKWOjf480ym1LnBQFOJi2AUd3qGOQ9N/5SQQnAwwQr9LpQoCbFyZcxSSmgMNC0WQ4aQnZRAFEEM2cRXxtrS45pA61fGbKRNvbEmCjEMmKUUkpxM6XE/C/V+2DnN8kMbkyw/HJwYZ5VOY+NMRABQ0C5EOA1J7zTAjFMOxPSSq/Y0KIiwMbGykQYXxhloICQi3EYaEEgQQXDaCVVLSpjF2Z5MWUeVOkNN0QMTwaykDSMYSja9JCr5qdwPteqcSZ3k2JhOnV0cmiElvc5tZpoumCCAddpZLA5T7yShGFJIyfRSrI4kqcqxMdmymyR6Mw0koQRSi0kiBZMCTTsB60KYGpm2p2IWE6mRyQZSAUQAsZZUjAqC2ATXuLZu1L3MPhViY2J2cG5onuRKnRsMND3mC95tMzEbmt4pwZEEk0zGulCiJMTGNMxWSmgMNJbZC0ItpQRnFJKJbya2psc1gbYnXVbSpt5Y4348xdJOCgmEJJxiorV693adE61UiI2J1sG5oemRK3VuMNDw+MExl50okQ1N/5SQCBMwwUSrCyVKQmxMtGylhMZA47MHktxOSkgigEQTvzKiNT2uCbQ90bKSNvXGmha1KEa2UkgKAOUEzzPGB0J0TrXi/GvLkq2Tg0OTpKjWuclI0zYKorjTg3xhiPqmhwCcU0gneIaxGz0qYmxMvGymh9ZIEz046lbxe096QCwEBRNPwDpQ5bpI25MwaynUxGDjsjEm2Fo6SU4goBMsoQ8SFak48YPuRxYLsqwsqi+4OnitZFW3c7u5Bt5IwrtdyVIdtt7JgxhCjIAJFt53J49OlpWl+KMgj9lc4w3EkEKryYMhR4xO/Mb7rkRqINf6An77CdbYdNPrVjnDwm6yMSyntKiQ31ql/OS9+wViqRQrbw87uDf4BS65VucWE43XtXDZqcAyH57eiSGlYBJPcIuzEzHKUqy8K8xaYuhMNG3SCILs5IUEnKDJ1/K358hVidZfDGYndxqYq+cRkVBwSwOMhIhhKcQEy8qCOFBJ98KygxgrS8uODg5eElBQ69xkpOlaMM5Ap6WAwhD1Tg+EoaBwgu8j6kaPihgry8wspofWSEMSJiGX1Fp6cIIYm/j1+R2ocl2k9SVntlKoicEGOjEqOLOVThhJysgEk7EPte1e458KsfJlkKlzg7/PK1Pq3GCgqaJGSt4pxmRD0z8lpJQIygm+ELIDJUpCrHwlpKWU0Bho2jNBQGI7KUEoIpyQib8Wsi09rgm0/sWQNtKm3ljjhZRAWBpVKCACwgmWlcW9ZFqxralW/BCGxLckW3EtLyjGotNycHxHYnAmIMQTrO7vJd+KbU247CWGzkQTMQBB0FJiMAwYQBMvB4t7z7riMaRdlpKngbnGe/g63oF8TyJxSAjm00u91n7nvGvt25h0rf3BuXFW6TQ2zhRSIO52I+VpSHqnAacQMUmnl2i1p0FBhI0plo00uDDOtOsBOaBW0kAiRqfySnpTwG5JCbM42xMq66hSZ6hpERgzImykjSBECCKnl0Tt9tFu0/2N20cxNiZTJweHpkdRrXOTkYaaRoG6re0WRqh3dkiIOeETzK26saMixsYcy2Z2aI00HSkRgNpLD4EwIBOvne9Alesibc+9rKVQE4NNT/FUQmwnnQQAFEjG2SQr6J0weuujhD6VY2kN/cHFB5Q45nqd28w0cYQh3rVOOBum3kkCEURgkle1diNJVY6llfQWk0RvpumBXkqK7CUJpwxhNPlq+taEqZE5gnp6W4nUyGRj9TCH0FpSIcgw53B66dnrJnzrYc/xKMbG5Ozk4CPeVN98/7H4a9MFewJ1u+u4MET900NyivkEDzh2o0dFjI1pmc300BppfMEE7XYi6570wARwDCZea9+BKtdF2p6QWUuhJgYbL/uiDFlLJ0kgIhO8Wz9KhXfOxQ5SbEzFju4NzZOCVucWE02BhgHSqRYsH57eiUEYg1OqiswSgE7EKEuxMQmzlxg6E00hgzEJLSUGhVIIAqedgLUnyVWJtqdflpKngbkmIgnGha1E4ghyNMII8w9/o14O8I2/Yi/ZzFfP63i+i8JEeYlaLd/daLXxg4947oXBug2LvjWW/q0bpQ4aalIzUCHUje7r2JSKuJE//3T94PTPX8KVejnBLKPNwZTF4odKXvTWnH/Zxnw9uxgGujvD1362sR+frIqz+1mOn+R0O7fRWnCdXEVCXiUaYwSNsrzm738q7+XYY45hUNuEqGsC704nVKVTAyd1FEqbPYpCTU02nqpk4PK4sTo75DQYIVuoxYmgcIx7OXWzYPy1vV8IKwm3MIKVnX9wADsb0zR+aYzX85BCgDDuP3ydDeiJYgJIIaWYUPTKRrSn4FXqcDtiVxm0jwtdVfI0NNgQuDAFDN4auCp0sINTAlKE0CTC1oeKArWZ7yI/SD76CleXQu0IUxpnhw9PJSOuhCWTsYYDPAQT2E84KinuiTKSIUjwyB+iyiPXPvxcdvDDwo4GjIOGGy0ZagzUEwBiKjQXK1+GFx28H8kNeOAuFZMIJ5/b+Xbr7pZbP1i6q1XUV0TRyrUjqOhdHj6uVO24ElqumGws6oEQ9RJdqrp7IhEERHA28ueciyFsH2O03fywMKPH5qCRxkSPejMN8QYiTprEGwPgH04YjgHCeCpRJ/7p7nZ+oOK4x5BTEWpNvKk6+5BgkxtxPdJojTXdayMRon2FmVxxT5RBGAPBxx9jCiPXKcBUOviR0aUKxqFDyyUZagw0BRVCgGwWVC7g/VhuSCnlGO/oMK+8RG6wCrf+X2r56S7jneupntfHDAqsWiszdcLD1s0uDapfQ7vqhGE9jUMpRZ/raZdG9EQ9TAUgYyzC1qzzaEa38zqboeMfveZmAvIj1t/MpLrBcGNI0126ZlyXM9LEGr4RRAnjYkqhLv6Ko3/3HN1ymVYFtIKrD4thBxvqw1bVVEMhggCCwz4j1UFvX2QRmEIGJxGcjsPWOR7l3fvoEFTA4SOiTokG180zxBaCKWweWoq4fiQnKOOQwmkEkEAl80Ala3+TqGgerJdeGARJ5Hofy8TbLTdhGPf22NRYlx0Bp3nXDB+Imth2JUDd6JopcEkI+glcTezpibyMYMEpH3dAazT87QNd4+F4WABsjv9BA+MttGznjulOEyJZg0B6A89s5C5HHAE6wmu2f3W9jxRW4U4FQbJbteHmuW0n4gXJriYwwgrrLkzXser0o36J9UuyWyy+b3wVJC8pLM6f6y0yZphEc9nPrmCvU+5ZZxaker2D3vyTDKiFj1M9PSFbQAAZYWNOKU9+P3fB+DetpG93hjwx5oIXPg2T6ZmQX2eYjgMIUQw549fytIcgnjAIRluEnebGfqtLE48t74toBHWIPtlsAYSLlhi2bxhASF7DbKEfHwJgAtFhGWCkDxKRihM3SpZtZ+pi+/uCGeseA0rm95Tn/5JBxqDCdC4GQ4avJd8XfVW+TCCHaot7BAiUiAs86jOYm9BzN4dJonXKkIm4c66AmTFXyL0YcIb9V+K97GO13CfeZZJQsciQIQtIa3ODfeJdgDX/7GRAT/Mq4pQJCcYM6NS/v8KgA57PEu4NZ26Ec+aDLWguG2SYiwGSjFkFZ4wAkOM+I58O/uaz4y5AQUg3UB8FqevAFkZcF50ZBtq/HQ1eLL6HwfplHYaX2L4wypQVE3T9kGB01pWKKn9WyJvP7cKwL5ALCsUo3/DlvasUVi9HBLaB97FlDukchdWruCq6tBdtnX5zHYE6QReI4XMkOFGO7kWjRS1O0YOro8wxhAKMeZTfw320+WozyseWt4zySVf3US4KuhhlOocC8yajXPDg+ihTCAQa8yivXL/dIB8a3jLGR03dh7ggR8NjihoNcG799fFlFBHCRzy+P5X6aDfAx5a3jPBJV/chLgq6GGMy5xTBJoNc8OD6KAtI8Sjv6Tx32DYMkvd2w3xqess4n7V1H+iSJM1IMwpRk5EuOnF9qKWgcJR34p+7LFCfqtVh2UPDW4b5qKn7IBfkaIZYYtloiHPzrw6wgIgRRMb5CPm69z5UMkR+fanN9Hx3/NXVkTYI00RnAaXUDva6rKl5ni2wIGR8c/d7GCeL2c+fP0sX9T4dXc09nR09BXBOJKaIEUlmR1efPvxgtZi5u93m6ylSuzBKlusw2rrJYsaejr23/FRR7IdBSjMxR0/HbaB9dByT7MutGycqKt8YrKRkLsB4jdmKQAZcQdCKeyvXJVyusMKvriKv6PUpidwgdg+rDsv93l8tZkwI9QpeXWf16kGHCEUdl7jQke4KylcGxGolnzw3cTfh26nJaoWYwGTtCEq4Q6CAjkBr6Kw84UHXBZ6E5Mlz0wlheW4ZH8C/mAVhstzHavVUWAtZzPK1kKfzD/fBCQZPQRjuzgOR/nu5U8HKD97OnxlH7X8CAAD//7STHGiaBgIA",
 		Length:   132762,
 	},
+
+	"data/webhook.template": {
+		Filename: "data/webhook.template",
+		Contents: "H4sIAAAAAAACA6vmUlBQSitMyVOyUqiuzirOz1PQcwNya2t1QDJF+TmpSDJBQC5UJq0oPxdZD5ALlSnJRxIPyYeKJpYgiTqWQEVTC/KTM8ASeq4gZm0tVy0XAKfQmumUAAAA",
+		Length:   148,
+	},
 }
 
 //
@@ -15,8 +15,8 @@ import (
 //
 func TestResourceCount(t *testing.T) {
 	out := getResources()
-	if len(out) != 12 {
-		t.Errorf("We expected 12 resources but found %d.", len(out))
+	if len(out) != 24 {
+		t.Errorf("We expected 24 resources but found %d.", len(out))
 	}
 }
 
@@ -7,6 +7,7 @@ package main
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -74,3 +75,58 @@ func timeRelative(epoch string) string {
 
 	return (timeDescr(ago))
 }
+
+//
+// Describe the length of the given number of seconds, without the
+// "ago" suffix, for showing durations.
+//
+func timeDuration(seconds int64) string {
+
+	if seconds < 0 {
+		seconds *= -1
+	}
+
+	switch {
+	case seconds < 1:
+		return "-"
+	case seconds < 2:
+		return "1 second"
+	case seconds < 60:
+		return fmt.Sprintf("%d seconds", seconds)
+	case seconds < 120:
+		return "1 minute"
+	case seconds < 60*60:
+		return fmt.Sprintf("%d minutes", seconds/(60))
+	case seconds < 2*60*60:
+		return "1 hour"
+	case seconds < 48*60*60:
+		return fmt.Sprintf("%d hours", seconds/(60*60))
+	default:
+		return fmt.Sprintf("%d days", seconds/(60*60*24))
+	}
+}
+
+//
+// Parse a period of time, such as "14d" or "90m".
+//
+// This accepts anything time.ParseDuration does, along with a number of
+// days given with a "d" suffix.
+//
+func parsePeriod(period string) (time.Duration, error) {
+
+	var d time.Duration
+	var err error
+
+	if strings.HasSuffix(period, "d") {
+		var days int
+		days, err = strconv.Atoi(strings.TrimSuffix(period, "d"))
+		d = time.Duration(days) * 24 * time.Hour
+	} else {
+		d, err = time.ParseDuration(period)
+	}
+
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid period '%s', expected something like 14d or 12h", period)
+	}
+	return d, nil
+}
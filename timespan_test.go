@@ -60,3 +60,55 @@ func TestString(t *testing.T) {
 	}
 
 }
+
+//
+// Test that durations are described without a suffix.
+//
+func TestDurations(t *testing.T) {
+
+	type TestCase struct {
+		Seconds int64
+		Result  string
+	}
+
+	cases := []TestCase{{0, "-"}, {1, "1 second"}, {45, "45 seconds"},
+		{-90, "1 minute"},
+		{1800, "30 minutes"},
+		{60 * 60 * 5, "5 hours"},
+		{60 * 60 * 24 * 4, "4 days"},
+	}
+
+	for _, o := range cases {
+
+		out := timeDuration(o.Seconds)
+
+		if out != o.Result {
+			t.Errorf("Expected '%s' received '%s' for %d", o.Result, out, o.Seconds)
+		}
+	}
+}
+
+//
+// Test parsing periods of time.
+//
+func TestParsePeriod(t *testing.T) {
+
+	valid := map[string]time.Duration{
+		"14d": 14 * 24 * time.Hour,
+		"1h":  time.Hour,
+		"90m": 90 * time.Minute,
+	}
+	for input, expected := range valid {
+		d, err := parsePeriod(input)
+		if err != nil || d != expected {
+			t.Errorf("Unexpected result for '%s': %v %v", input, d, err)
+		}
+	}
+
+	for _, input := range []string{"", "d", "steve", "-1d", "0h"} {
+		_, err := parsePeriod(input)
+		if err == nil {
+			t.Errorf("Expected an error parsing '%s'", input)
+		}
+	}
+}
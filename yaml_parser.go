@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
 	"strings"
 	"strconv"
 
@@ -33,6 +34,27 @@ type Resource struct {
 	Type string
 	File string
 	Line string
+
+	//
+	// The time, in seconds, puppet spent evaluating this resource.
+	//
+	EvaluationTime float64
+}
+
+//
+// SlowestResources is the number of resources, ordered by evaluation
+// time, which we keep from each report.
+//
+var SlowestResources = 20
+
+//
+// ResourceTime records the time puppet spent upon a particular type of
+// resource, along with the percentage of the whole run that represents.
+//
+type ResourceTime struct {
+	Type       string
+	Seconds    float64
+	Percentage float64
 }
 
 //
@@ -57,6 +79,12 @@ type PuppetReport struct {
 	//
 	At string
 
+	//
+	// The offset from UTC of that time, such as "+00:00", if the node
+	// reported one.
+	//
+	Offset string
+
 	//
 	// The time puppet took to run, in seconds.
 	//
@@ -72,6 +100,11 @@ type PuppetReport struct {
 	//
 	Branch string
 
+	//
+	// The puppet environment the node is in.
+	//
+	Environment string
+
 	//
 	// The build time.
 	//
@@ -92,6 +125,11 @@ type PuppetReport struct {
 	//
 	LogMessages []string
 
+	//
+	// The messages which were logged as errors.
+	//
+	ErrorMessages []string
+
 	//
 	// Resources which have failed/changed/been skipped.
 	//
@@ -104,6 +142,16 @@ type PuppetReport struct {
 	ResourcesSkipped []Resource
 	ResourcesOK      []Resource
 
+	//
+	// The resources which took the longest to evaluate, slowest first.
+	//
+	ResourcesSlowest []Resource
+
+	//
+	// The time spent upon each type of resource, slowest first.
+	//
+	TypeTimes []ResourceTime
+
 	//
 	// Hash of the report-body.
 	//
@@ -143,9 +191,24 @@ func parseHost(y *simpleyaml.Yaml, out *PuppetReport) error {
 	}
 
 	out.Fqdn = host
+
+	//
+	// The environment is optional, as not all versions of puppet
+	// will submit it.
+	//
+	env, err := y.Get("environment").String()
+	if err == nil {
+		out.Environment = env
+	}
 	return nil
 }
 
+//
+// timeOffset matches the offset from UTC at the end of a time, such as
+// "+00:00", or "-0500".
+//
+var timeOffset = regexp.MustCompile(`([+-][0-9]{2}):?([0-9]{2})$`)
+
 //
 // parseTime reads the `time` parameter from the YAML and populates
 // the given report-structure with suitable values.
@@ -166,6 +229,16 @@ func parseTime(y *simpleyaml.Yaml, out *PuppetReport) error {
 	// Convert "T" -> " "
 	at = strings.Replace(at, "T", " ", -1)
 
+	// Keep the offset from UTC, if any, separately.
+	at = strings.TrimSpace(at)
+	if strings.HasSuffix(at, "Z") {
+		out.Offset = "+00:00"
+		at = strings.TrimSuffix(at, "Z")
+	} else if m := timeOffset.FindStringSubmatch(at); m != nil {
+		out.Offset = m[1] + ":" + m[2]
+		at = strings.TrimSpace(strings.TrimSuffix(at, m[0]))
+	}
+
 	// strip the time at the first period.
 	parts := strings.Split(at, ".")
 	at = parts[0]
@@ -219,6 +292,8 @@ func parseRuntime(y *simpleyaml.Yaml, out *PuppetReport) error {
 
 	runtime := ""
 
+	var types []ResourceTime
+
 	//
 	// HORRID: Help me, I'm in hell.
 	//
@@ -228,9 +303,37 @@ func parseRuntime(y *simpleyaml.Yaml, out *PuppetReport) error {
 		match := r.FindStringSubmatch(fmt.Sprint(value))
 		if len(match) == 2 {
 			runtime = match[1]
+			continue
+		}
+
+		//
+		// Each other value is a triple of the name, label, and
+		// time spent upon that type of resource.
+		//
+		entry, ok := value.([]interface{})
+		if !ok || len(entry) != 3 {
+			continue
+		}
+		seconds, err := strconv.ParseFloat(fmt.Sprint(entry[2]), 64)
+		if err != nil {
+			continue
 		}
+		types = append(types, ResourceTime{Type: fmt.Sprint(entry[1]), Seconds: seconds})
 	}
 	out.Runtime = runtime
+
+	//
+	// Record the share of the total each type took, slowest first.
+	//
+	total, _ := strconv.ParseFloat(runtime, 64)
+	for i := range types {
+		if total > 0 {
+			types[i].Percentage = (types[i].Seconds / total) * 100
+		}
+	}
+	sort.SliceStable(types, func(i, j int) bool { return types[i].Seconds > types[j].Seconds })
+	out.TypeTimes = types
+
 	return nil
 }
 
@@ -297,6 +400,7 @@ func parseLogs(y *simpleyaml.Yaml, out *PuppetReport) error {
 	}
 
 	var logged []string
+	var failures []string
 
 	for _, v2 := range logs {
 
@@ -337,10 +441,16 @@ func parseLogs(y *simpleyaml.Yaml, out *PuppetReport) error {
 
 		if len(m["message"]) > 0 {
 			logged = append(logged, m["source"]+" : "+m["message"])
+
+			switch strings.TrimPrefix(m["level"], ":") {
+			case "err", "crit", "alert", "emerg":
+				failures = append(failures, m["message"])
+			}
 		}
 	}
 
 	out.LogMessages = logged
+	out.ErrorMessages = failures
 	return nil
 }
 
@@ -358,6 +468,7 @@ func parseResults(y *simpleyaml.Yaml, out *PuppetReport) error {
 	var changed []Resource
 	var skipped []Resource
 	var ok []Resource
+	var all []Resource
 
 	for _, v2 := range rs {
 
@@ -375,41 +486,34 @@ func parseResults(y *simpleyaml.Yaml, out *PuppetReport) error {
 			}
 		}
 
+		// The resource, and the time spent evaluating it.
+		seconds, _ := strconv.ParseFloat(m["evaluation_time"], 64)
+		res := Resource{Name: m["title"],
+			Type:           m["resource_type"],
+			File:           m["file"],
+			Line:           m["line"],
+			EvaluationTime: seconds}
+		all = append(all, res)
+
 		// Now we should be able to look for skipped ones.
 		if m["skipped"] == "true" {
-			skipped = append(skipped,
-				Resource{Name: m["title"],
-					Type: m["resource_type"],
-					File: m["file"],
-					Line: m["line"]})
+			skipped = append(skipped, res)
 		}
 
 		// Now we should be able to look for skipped ones.
 		if m["changed"] == "true" {
-			changed = append(changed,
-				Resource{Name: m["title"],
-					Type: m["resource_type"],
-					File: m["file"],
-					Line: m["line"]})
+			changed = append(changed, res)
 		}
 
 		// Now we should be able to look for skipped ones.
 		if m["failed"] == "true" {
-			failed = append(failed,
-				Resource{Name: m["title"],
-					Type: m["resource_type"],
-					File: m["file"],
-					Line: m["line"]})
+			failed = append(failed, res)
 		}
 
 		if m["failed"] == "false" &&
 			m["skipped"] == "false" &&
 			m["changed"] == "false" {
-			ok = append(ok,
-				Resource{Name: m["title"],
-					Type: m["resource_type"],
-					File: m["file"],
-					Line: m["line"]})
+			ok = append(ok, res)
 		}
 
 	}
@@ -419,6 +523,21 @@ func parseResults(y *simpleyaml.Yaml, out *PuppetReport) error {
 	out.ResourcesChanged = changed
 	out.ResourcesOK = ok
 
+	//
+	// Keep the slowest resources, so that the report can show
+	// where the time in this run went.
+	//
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].EvaluationTime == all[j].EvaluationTime {
+			return all[i].Name < all[j].Name
+		}
+		return all[i].EvaluationTime > all[j].EvaluationTime
+	})
+	if len(all) > SlowestResources {
+		all = all[:SlowestResources]
+	}
+	out.ResourcesSlowest = all
+
 	return nil
 
 }
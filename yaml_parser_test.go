@@ -39,7 +39,8 @@ func TestBogusYaml(t *testing.T) {
 func TestYamlDates(t *testing.T) {
 
 	tests := []string{"---\ntime: '2017-03-10T10:22:33.659245699+00:00'\nhost: bart\n",
-		"---\ntime: 2017-03-10 10:22:33.493526494 +00:00\nhost: foo\n"}
+		"---\ntime: 2017-03-10 10:22:33.493526494 +00:00\nhost: foo\n",
+		"---\ntime: '2017-03-10T10:22:33Z'\nhost: baz\n"}
 
 	for _, input := range tests {
 
@@ -54,6 +55,9 @@ func TestYamlDates(t *testing.T) {
 		if node.At != "2017-03-10 10:22:33" {
 			t.Errorf("Invalid time result, got '%s'", node.At)
 		}
+		if node.Offset != "+00:00" {
+			t.Errorf("Invalid offset result, got '%s'", node.Offset)
+		}
 	}
 
 }
@@ -237,6 +241,9 @@ func TestValidYaml(t *testing.T) {
 	if report.Skipped != "2" {
 		t.Errorf("Incorrect skipped: %v", report.Skipped)
 	}
+	if report.Environment != "production" {
+		t.Errorf("Incorrect environment: %v", report.Environment)
+	}
 }
 
 //
@@ -296,3 +303,93 @@ func TestMissingResources(t *testing.T) {
 		}
 	}
 }
+
+//
+// Test that the time spent upon each resource, and resource-type, is
+// parsed from a valid report.
+//
+func TestResourceTimes(t *testing.T) {
+
+	//
+	// Read the YAML file.
+	//
+	tmpl, err := getResource("data/valid.yaml")
+	if err != nil {
+		t.Fatal("Failed to load YAML asset data/valid.yaml")
+	}
+
+	report, err := ParsePuppetReport(tmpl)
+	if err != nil {
+		t.Fatal("Failed to parse YAML file")
+	}
+
+	//
+	// The runtime should still be the total.
+	//
+	if report.Runtime != "3.7930047059683174" {
+		t.Errorf("Incorrect runtime: %v", report.Runtime)
+	}
+
+	//
+	// There are eleven types of resource, excluding the total, and
+	// most of the time went on retrieving the catalog.
+	//
+	if len(report.TypeTimes) != 11 {
+		t.Fatalf("Unexpected number of resource-types: %d", len(report.TypeTimes))
+	}
+	if report.TypeTimes[0].Type != "Config retrieval" {
+		t.Errorf("Unexpected slowest type: %v", report.TypeTimes[0].Type)
+	}
+	if report.TypeTimes[0].Percentage < 77 || report.TypeTimes[0].Percentage > 78 {
+		t.Errorf("Unexpected share of the runtime: %f", report.TypeTimes[0].Percentage)
+	}
+
+	//
+	// We keep the twenty slowest resources, slowest first.
+	//
+	if len(report.ResourcesSlowest) != SlowestResources {
+		t.Fatalf("Unexpected number of slow resources: %d", len(report.ResourcesSlowest))
+	}
+	if report.ResourcesSlowest[0].EvaluationTime != 0.193997472 {
+		t.Errorf("Unexpected slowest resource: %v", report.ResourcesSlowest[0])
+	}
+	for i := 1; i < len(report.ResourcesSlowest); i++ {
+		if report.ResourcesSlowest[i].EvaluationTime > report.ResourcesSlowest[i-1].EvaluationTime {
+			t.Errorf("Slow resources are not sorted")
+		}
+	}
+}
+
+//
+// Test that error messages are collected from the logs.
+//
+func TestErrorMessages(t *testing.T) {
+
+	//
+	// Read the YAML file.
+	//
+	tmpl, err := getResource("data/valid.yaml")
+	if err != nil {
+		t.Fatal("Failed to load YAML asset data/valid.yaml")
+	}
+
+	report, err := ParsePuppetReport(tmpl)
+	if err != nil {
+		t.Fatal("Failed to parse YAML file")
+	}
+	if len(report.ErrorMessages) != 0 {
+		t.Errorf("Unexpected errors: %v", report.ErrorMessages)
+	}
+
+	//
+	// Make the first log-entry an error.
+	//
+	str := strings.Replace(string(tmpl), "level: :notice\n  message: Tidying 0 files", "level: :err\n  message: Tidying 0 files", 1)
+	report, err = ParsePuppetReport([]byte(str))
+	if err != nil {
+		t.Fatal("Failed to parse YAML file")
+	}
+	if len(report.ErrorMessages) != 1 || report.ErrorMessages[0] != "Tidying 0 files" {
+		t.Errorf("Unexpected errors: %v", report.ErrorMessages)
+	}
+}